@@ -1,4 +1,4 @@
-//go:build go1.20
+//go:build go1.20 && !go1.26
 
 package allgs
 
@@ -105,3 +105,8 @@ var gStatusStrings = [...]string{
 	Status_Gcopystack: "copystack",
 	Status_Gpreempted: "preempted",
 }
+
+// IsDead returns whether the status indicates that the goroutine is unused.
+func (s Status) IsDead() bool {
+	return s == Status_Gdead
+}
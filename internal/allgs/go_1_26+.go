@@ -0,0 +1,123 @@
+//go:build go1.26
+
+package allgs
+
+// defined constants
+const (
+	// G status
+	//
+	// Beyond indicating the general state of a G, the G status
+	// acts like a lock on the goroutine's stack (and hence its
+	// ability to execute user code).
+	//
+	// If you add to this list, add to the list
+	// of "okay during garbage collection" status
+	// in mgcmark.go too.
+	//
+	// TODO(austin): The _Gscan bit could be much lighter-weight.
+	// For example, we could choose not to run _Gscanrunnable
+	// goroutines found in the run queue, rather than CAS-looping
+	// until they become _Grunnable. And transitions like
+	// _Gscanwaiting -> _Gscanrunnable are actually okay because
+	// they don't affect stack ownership.
+
+	// Status_Gidle means this goroutine was just allocated and has not
+	// yet been initialized.
+	Status_Gidle = iota // 0
+
+	// Statu_Grunnable means this goroutine is on a run queue. It is
+	// not currently executing user code. The stack is not owned.
+	Status_Grunnable // 1
+
+	// _Grunning means this goroutine may execute user code. The
+	// stack is owned by this goroutine. It is not on a run queue.
+	// It is assigned an M and a P (g.m and g.m.p are valid).
+	Status_Grunning // 2
+
+	// _Gsyscall means this goroutine is executing a system call.
+	// It is not executing user code. The stack is owned by this
+	// goroutine. It is not on a run queue. It is assigned an M.
+	Status_Gsyscall // 3
+
+	// _Gwaiting means this goroutine is blocked in the runtime.
+	// It is not executing user code. It is not on a run queue,
+	// but should be recorded somewhere (e.g., a channel wait
+	// queue) so it can be ready()d when necessary. The stack is
+	// not owned *except* that a channel operation may read or
+	// write parts of the stack under the appropriate channel
+	// lock. Otherwise, it is not safe to access the stack after a
+	// goroutine enters _Gwaiting (e.g., it may get moved).
+	Status_Gwaiting // 4
+
+	// _Gmoribund_unused is currently unused, but hardcoded in gdb
+	// scripts.
+	Status_Gmoribund_unused // 5
+
+	// _Gdead means this goroutine is currently unused. It may be
+	// just exited, on a free list, or just being initialized. It
+	// is not executing user code. It may or may not have a stack
+	// allocated. The G and its stack (if any) are owned by the M
+	// that is exiting the G or that obtained the G from the free
+	// list.
+	Status_Gdead // 6
+
+	// _Genqueue_unused is currently unused.
+	_Genqueue_unused // 7
+
+	// _Gcopystack means this goroutine's stack is being moved. It
+	// is not executing user code and is not on a run queue. The
+	// stack is owned by the goroutine that put it in _Gcopystack.
+	Status_Gcopystack // 8
+
+	// _Gpreempted means this goroutine stopped itself for a
+	// suspendG preemption. It is like _Gwaiting, but nothing is
+	// yet responsible for ready()ing it. Some suspendG must CAS
+	// the status to _Gwaiting to take responsibility for
+	// ready()ing this G.
+	Status_Gpreempted // 9
+
+	// _Gleaked represents a leaked goroutine caught by the GC.
+	Status_Gleaked // 10
+
+	// _Gdeadextra is a _Gdead goroutine that's attached to an extra M
+	// used for cgo callbacks.
+	Status_Gdeadextra // 11
+
+	// _Gscan combined with one of the above states other than
+	// _Grunning indicates that GC is scanning the stack. The
+	// goroutine is not executing user code and the stack is owned
+	// by the goroutine that set the _Gscan bit.
+	//
+	// _Gscanrunning is different: it is used to briefly block
+	// state transitions while GC signals the G to scan its own
+	// stack. This is otherwise like _Grunning.
+	//
+	// atomicstatus&~Gscan gives the state the goroutine will
+	// return to when the scan completes.
+	Status_Gscan          = 0x1000
+	Status_Gscanrunnable  = Status_Gscan + Status_Grunnable  // 0x1001
+	Status_Gscanrunning   = Status_Gscan + Status_Grunning   // 0x1002
+	Status_Gscansyscall   = Status_Gscan + Status_Gsyscall   // 0x1003
+	Status_Gscanwaiting   = Status_Gscan + Status_Gwaiting   // 0x1004
+	Status_Gscanpreempted = Status_Gscan + Status_Gpreempted // 0x1009
+	Status_Gscanleaked    = Status_Gscan + Status_Gleaked    // 0x100a
+	Status_Gscandeadextra = Status_Gscan + Status_Gdeadextra // 0x100b
+)
+
+var gStatusStrings = [...]string{
+	Status_Gidle:      "idle",
+	Status_Grunnable:  "runnable",
+	Status_Grunning:   "running",
+	Status_Gsyscall:   "syscall",
+	Status_Gwaiting:   "waiting",
+	Status_Gdead:      "dead",
+	Status_Gcopystack: "copystack",
+	Status_Gpreempted: "preempted",
+	Status_Gleaked:    "leaked",
+	Status_Gdeadextra: "waiting for cgo callback",
+}
+
+// IsDead returns whether the status indicates that the goroutine is unused.
+func (s Status) IsDead() bool {
+	return s == Status_Gdead || s == Status_Gdeadextra
+}
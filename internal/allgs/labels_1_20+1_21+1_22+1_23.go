@@ -0,0 +1,34 @@
+//go:build go1.20 && !go1.24
+
+package allgs
+
+// Before go1.24, runtime/pprof.labelMap is a map[string]string. It is walked
+// with the map implementation this package is built with; the label layout of
+// the runtime config does not apply.
+//
+// See https://github.com/golang/go/blob/go1.23.12/src/runtime/pprof/label.go#L38
+type labelMap = map[string]string
+
+// Label returns the value of the pprof label with the given key.
+func (g Goroutine) Label(key string) (value string, ok bool) {
+	labels := g.labels()
+	if labels == nil {
+		return "", false
+	}
+	value, ok = (*(*labelMap)(labels))[key]
+	return value, ok
+}
+
+// RangeLabels calls f for each of the goroutine's pprof labels until f returns
+// false.
+func (g Goroutine) RangeLabels(f func(key, value string) bool) {
+	labels := g.labels()
+	if labels == nil {
+		return
+	}
+	for k, v := range *(*labelMap)(labels) {
+		if !f(k, v) {
+			return
+		}
+	}
+}
@@ -0,0 +1,58 @@
+//go:build go1.24
+
+package allgs
+
+import "unsafe"
+
+// Since go1.24, runtime/pprof.labelMap is a struct wrapping a slice of labels
+// sorted by key.
+//
+// See https://github.com/golang/go/blob/go1.24.13/src/runtime/pprof/label.go#L20-L40
+type labelMap struct {
+	list []label
+}
+
+type label struct {
+	key   string
+	value string
+}
+
+// Label returns the value of the pprof label with the given key.
+func (g Goroutine) Label(key string) (value string, ok bool) {
+	g.RangeLabels(func(k, v string) bool {
+		if k == key {
+			value, ok = v, true
+		}
+		return !ok
+	})
+	return value, ok
+}
+
+// RangeLabels calls f for each of the goroutine's pprof labels until f returns
+// false. The labels are walked with the layout in the runtime config, if it
+// provides one, and otherwise with the layout this package is built with.
+func (g Goroutine) RangeLabels(f func(key, value string) bool) {
+	labels := g.labels()
+	if labels == nil {
+		return
+	}
+	cfg := g.config
+	if cfg.LabelByteLen == 0 {
+		for _, l := range (*labelMap)(labels).list {
+			if !f(l.key, l.value) {
+				return
+			}
+		}
+		return
+	}
+	list := *(*[]byte)(unsafe.Add(labels, cfg.LabelMapListOffset))
+	base := unsafe.Pointer(unsafe.SliceData(list))
+	for i := range len(list) {
+		l := unsafe.Add(base, uintptr(i)*uintptr(cfg.LabelByteLen))
+		key := *(*string)(unsafe.Add(l, cfg.LabelKeyOffset))
+		value := *(*string)(unsafe.Add(l, cfg.LabelValueOffset))
+		if !f(key, value) {
+			return
+		}
+	}
+}
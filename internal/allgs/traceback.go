@@ -21,7 +21,11 @@ type GoroutineIterator struct {
 	cfg   *snapshotpb.RuntimeConfig
 }
 
-// Iterate calls f for each goroutine.
+// Iterate calls f for each goroutine. The runtime.g pointers are read from
+// runtime.allgs as uintptrs, so they are exempt from the checkptr
+// instrumentation of race builds.
+//
+//go:nocheckptr
 func (it GoroutineIterator) Iterate(f func(Goroutine)) {
 	allGs := *(*[]uintptr)(it.allGs)
 	for _, gPtr := range allGs {
@@ -30,7 +34,10 @@ func (it GoroutineIterator) Iterate(f func(Goroutine)) {
 }
 
 // NewGoroutineIterator creates a new GoroutineIterator given the actual address
-// of the bss section.
+// of the bss section. The address of runtime.allgs is not derived from a Go
+// pointer, so it is exempt from the checkptr instrumentation of race builds.
+//
+//go:nocheckptr
 func NewGoroutineIterator(cfg *snapshotpb.RuntimeConfig, bssAddrShift uint64) (GoroutineIterator, error) {
 	if cfg.VariableRuntimeDotAllgs == 0 || cfg.GoRuntimeBssAddress == 0 ||
 		cfg.VariableRuntimeDotAllgs < cfg.GoRuntimeBssAddress {
@@ -44,7 +51,45 @@ func NewGoroutineIterator(cfg *snapshotpb.RuntimeConfig, bssAddrShift uint64) (G
 type Status uint32
 
 func (s Status) String() string {
-	return gStatusStrings[s]
+	if int(s) < len(gStatusStrings) && gStatusStrings[s] != "" {
+		return gStatusStrings[s]
+	}
+	return fmt.Sprintf("Status(%#x)", uint32(s))
+}
+
+// WaitReason is the reason a goroutine is waiting. It corresponds to the
+// runtime's waitReason enum of the Go version this package is built with.
+type WaitReason uint8
+
+func (r WaitReason) String() string {
+	if int(r) < len(waitReasonStrings) {
+		return waitReasonStrings[r]
+	}
+	return fmt.Sprintf("WaitReason(%d)", uint8(r))
+}
+
+// MakeGoroutine returns the Goroutine for the runtime.g at gPtr. Goroutines
+// are normally obtained from a GoroutineIterator.
+func MakeGoroutine(gPtr unsafe.Pointer, cfg *snapshotpb.RuntimeConfig) Goroutine {
+	return Goroutine{gPtr: gPtr, config: cfg}
+}
+
+// Ptr returns the address of the underlying runtime.g.
+func (g Goroutine) Ptr() unsafe.Pointer {
+	return g.gPtr
+}
+
+// SchedSP returns the stack pointer saved in g.sched. It is zero while the
+// goroutine is running and its context has not been saved, and if the config
+// does not provide the offset of g.sched.
+//
+// The stack pointer is read at the offset of g.sched itself: sp is the first
+// field of runtime.gobuf in every supported Go version.
+func (g Goroutine) SchedSP() uintptr {
+	if g.config.GSchedOffset == 0 {
+		return 0
+	}
+	return *(*uintptr)(unsafe.Pointer(uintptr(g.gPtr) + uintptr(g.config.GSchedOffset)))
 }
 
 // PC returns the program counter of the goroutine.
@@ -52,20 +97,41 @@ func (g Goroutine) PC() uintptr {
 	return *(*uintptr)(unsafe.Pointer(uintptr(g.gPtr) + uintptr(g.config.GGoBufPcOffset)))
 }
 
-// BP returns the program counter of the goroutine.
+// BP returns the frame pointer of the goroutine.
 //
-// TODO(https://github.com/DataExMachina-dev/side-eye/issues/756): Note that
-// this is currently buggy when the goroutine is in a syscall. In later go versions
-// (go1.23+), callers should be able to use syscallbp.
+// Note that this is stale when the goroutine is in a syscall; use SyscallBP
+// instead.
 func (g Goroutine) BP() unsafe.Pointer {
 	return *(*unsafe.Pointer)(unsafe.Pointer(uintptr(g.gPtr) + uintptr(g.config.GGoBufBpOffset)))
 }
 
-// SyscallPC returns the program counter of the syscall.
+// SyscallPC returns the program counter of the syscall, or zero if the config
+// does not provide the offset of g.syscallpc.
 func (g Goroutine) SyscallPC() uintptr {
+	if g.config.GSyscallPcOffset == 0 {
+		return 0
+	}
 	return *(*uintptr)(unsafe.Pointer(uintptr(g.gPtr) + uintptr(g.config.GSyscallPcOffset)))
 }
 
+// SyscallSP returns the stack pointer of the syscall, or zero if the config
+// does not provide the offset of g.syscallsp.
+func (g Goroutine) SyscallSP() uintptr {
+	if g.config.GSyscallSpOffset == 0 {
+		return 0
+	}
+	return *(*uintptr)(unsafe.Pointer(uintptr(g.gPtr) + uintptr(g.config.GSyscallSpOffset)))
+}
+
+// SyscallBP returns the frame pointer of the syscall, or nil if the runtime
+// does not record it.
+func (g Goroutine) SyscallBP() unsafe.Pointer {
+	if g.config.GSyscallBpOffset == 0 {
+		return nil
+	}
+	return *(*unsafe.Pointer)(unsafe.Pointer(uintptr(g.gPtr) + uintptr(g.config.GSyscallBpOffset)))
+}
+
 // Goid returns the ID of the goroutine.
 func (g Goroutine) Goid() uint64 {
 	return *(*uint64)(unsafe.Pointer(uintptr(g.gPtr) + uintptr(g.config.GGoidOffset)))
@@ -85,3 +151,62 @@ func (g Goroutine) String() string {
 func (g Goroutine) Stktopsp() uintptr {
 	return *(*uintptr)(unsafe.Pointer(uintptr(g.gPtr) + uintptr(g.config.GStktopspOffset)))
 }
+
+// WaitReason returns the reason the goroutine is waiting, if its status is
+// Gwaiting, or 0 if the config does not provide the offset.
+func (g Goroutine) WaitReason() WaitReason {
+	if g.config.GWaitreasonOffset == 0 {
+		return 0
+	}
+	return *(*WaitReason)(unsafe.Pointer(uintptr(g.gPtr) + uintptr(g.config.GWaitreasonOffset)))
+}
+
+// WaitSince returns the approximate time, in runtime nanotime, at which the
+// goroutine became blocked. The runtime only records it lazily (during GC), so
+// it may be zero for a waiting goroutine. It is also zero if the config does
+// not provide the offset.
+func (g Goroutine) WaitSince() int64 {
+	if g.config.GWaitsinceOffset == 0 {
+		return 0
+	}
+	return *(*int64)(unsafe.Pointer(uintptr(g.gPtr) + uintptr(g.config.GWaitsinceOffset)))
+}
+
+// Gopc returns the pc of the go statement that created the goroutine, or 0
+// if the config does not provide the offset.
+func (g Goroutine) Gopc() uintptr {
+	if g.config.GGopcOffset == 0 {
+		return 0
+	}
+	return *(*uintptr)(unsafe.Pointer(uintptr(g.gPtr) + uintptr(g.config.GGopcOffset)))
+}
+
+// ParentGoid returns the ID of the goroutine that created the goroutine. It is
+// 0 for goroutines created by the runtime before any goroutine, and if the
+// config does not provide the offset.
+func (g Goroutine) ParentGoid() uint64 {
+	if g.config.GParentGoidOffset == 0 {
+		return 0
+	}
+	return *(*uint64)(unsafe.Pointer(uintptr(g.gPtr) + uintptr(g.config.GParentGoidOffset)))
+}
+
+// Waiting returns the address of the first sudog that the goroutine is blocked
+// on, or 0 if it is not blocked on a channel or a semaphore, or if the config
+// does not provide the offset. It is only meaningful if the goroutine's status
+// is Gwaiting.
+func (g Goroutine) Waiting() uintptr {
+	if g.config.GWaitingOffset == 0 {
+		return 0
+	}
+	return *(*uintptr)(unsafe.Pointer(uintptr(g.gPtr) + uintptr(g.config.GWaitingOffset)))
+}
+
+// labels returns the goroutine's pprof labels, a *runtime/pprof.labelMap, or
+// nil if it has none or the config does not provide the offset.
+func (g Goroutine) labels() unsafe.Pointer {
+	if g.config.GLabelsOffset == 0 {
+		return nil
+	}
+	return *(*unsafe.Pointer)(unsafe.Pointer(uintptr(g.gPtr) + uintptr(g.config.GLabelsOffset)))
+}
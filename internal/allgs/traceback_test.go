@@ -0,0 +1,42 @@
+package allgs
+
+import (
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataExMachina-dev/side-eye-go/internal/snapshotpb"
+)
+
+// fakeG returns a Goroutine backed by memory in which every word is the
+// given sentinel, except for the words set in fields, keyed by offset.
+func fakeG(cfg *snapshotpb.RuntimeConfig, sentinel uint64, fields map[uint32]uint64) Goroutine {
+	mem := make([]uint64, 64)
+	for i := range mem {
+		mem[i] = sentinel
+	}
+	for off, v := range fields {
+		mem[off/8] = v
+	}
+	return Goroutine{gPtr: unsafe.Pointer(&mem[0]), config: cfg}
+}
+
+func TestGoroutineMissingOffsets(t *testing.T) {
+	// Programs from older artifact stores do not provide the offsets of
+	// waitreason and waitsince.
+	g := fakeG(&snapshotpb.RuntimeConfig{GGoidOffset: 8}, 0xdeadbeef, map[uint32]uint64{8: 42})
+	require.Equal(t, uint64(42), g.Goid())
+	require.Zero(t, g.WaitReason())
+	require.Zero(t, g.WaitSince())
+	require.Zero(t, g.SyscallPC())
+	require.Zero(t, g.SyscallSP())
+	require.Nil(t, g.SyscallBP())
+
+	g = fakeG(&snapshotpb.RuntimeConfig{
+		GWaitsinceOffset:  16,
+		GWaitreasonOffset: 24,
+	}, 0xdeadbeef, map[uint32]uint64{16: 1234, 24: 7})
+	require.Equal(t, int64(1234), g.WaitSince())
+	require.Equal(t, WaitReason(7), g.WaitReason())
+}
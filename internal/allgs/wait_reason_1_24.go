@@ -0,0 +1,52 @@
+//go:build go1.24 && !go1.25
+
+package allgs
+
+// waitReasonStrings is a copy of the runtime's table of the same name,
+// indexed by the values of the runtime's waitReason enum.
+var waitReasonStrings = [...]string{
+	"",                        // waitReasonZero
+	"GC assist marking",       // waitReasonGCAssistMarking
+	"IO wait",                 // waitReasonIOWait
+	"chan receive (nil chan)", // waitReasonChanReceiveNilChan
+	"chan send (nil chan)",    // waitReasonChanSendNilChan
+	"dumping heap",            // waitReasonDumpingHeap
+	"garbage collection",      // waitReasonGarbageCollection
+	"garbage collection scan", // waitReasonGarbageCollectionScan
+	"panicwait",               // waitReasonPanicWait
+	"select",                  // waitReasonSelect
+	"select (no cases)",       // waitReasonSelectNoCases
+	"GC assist wait",          // waitReasonGCAssistWait
+	"GC sweep wait",           // waitReasonGCSweepWait
+	"GC scavenge wait",        // waitReasonGCScavengeWait
+	"chan receive",            // waitReasonChanReceive
+	"chan send",               // waitReasonChanSend
+	"finalizer wait",          // waitReasonFinalizerWait
+	"force gc (idle)",         // waitReasonForceGCIdle
+	"semacquire",              // waitReasonSemacquire
+	"sleep",                   // waitReasonSleep
+	"sync.Cond.Wait",          // waitReasonSyncCondWait
+	"sync.Mutex.Lock",         // waitReasonSyncMutexLock
+	"sync.RWMutex.RLock",      // waitReasonSyncRWMutexRLock
+	"sync.RWMutex.Lock",       // waitReasonSyncRWMutexLock
+	"sync.WaitGroup.Wait",     // waitReasonSyncWaitGroupWait
+	"trace reader (blocked)",  // waitReasonTraceReaderBlocked
+	"wait for GC cycle",       // waitReasonWaitForGCCycle
+	"GC worker (idle)",        // waitReasonGCWorkerIdle
+	"GC worker (active)",      // waitReasonGCWorkerActive
+	"preempted",               // waitReasonPreempted
+	"debug call",              // waitReasonDebugCall
+	"GC mark termination",     // waitReasonGCMarkTermination
+	"stopping the world",      // waitReasonStoppingTheWorld
+	"flushing proc caches",    // waitReasonFlushProcCaches
+	"trace goroutine status",  // waitReasonTraceGoroutineStatus
+	"trace proc status",       // waitReasonTraceProcStatus
+	"page trace flush",        // waitReasonPageTraceFlush
+	"coroutine",               // waitReasonCoroutine
+	"GC weak to strong wait",  // waitReasonGCWeakToStrongWait
+	"synctest.Run",            // waitReasonSynctestRun
+	"synctest.Wait",           // waitReasonSynctestWait
+	"chan receive (synctest)", // waitReasonSynctestChanReceive
+	"chan send (synctest)",    // waitReasonSynctestChanSend
+	"select (synctest)",       // waitReasonSynctestSelect
+}
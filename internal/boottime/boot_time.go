@@ -3,6 +3,7 @@ package boottime
 import (
 	"errors"
 	"time"
+	_ "unsafe" // required for go:linkname
 )
 
 // ErrNotImplemented is returned when the boot time is not implemented for the
@@ -15,3 +16,10 @@ var ErrNotImplemented = errors.New("not implemented")
 func BootTime() (time.Time, error) {
 	return bootTime()
 }
+
+// Nanotime returns the current value of the runtime clock in nanoseconds. This
+// is the clock used for the runtime's internal timestamps, such as a
+// goroutine's waitsince.
+//
+//go:linkname Nanotime runtime.nanotime
+func Nanotime() int64
@@ -0,0 +1,455 @@
+// Package dwarfprogram compiles snapshot programs from the DWARF debug
+// information of an executable, without the help of the Side-Eye service.
+//
+// The programs it produces are stack-only: they fill in the RuntimeConfig
+// needed to stop the world and unwind every goroutine, but capture no
+// variables.
+package dwarfprogram
+
+import (
+	"debug/dwarf"
+	"debug/elf"
+	"debug/macho"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/DataExMachina-dev/side-eye-go/internal/snapshotpb"
+)
+
+// ErrNoDWARF is returned when the executable does not contain DWARF debug
+// information, typically because it was built with -ldflags=-w or stripped.
+var ErrNoDWARF = errors.New("executable has no DWARF debug information")
+
+const dereferenceFunc = "github.com/DataExMachina-dev/side-eye-go/internal/stoptheworld.Dereference"
+
+// CompileSelf compiles a stack-only snapshot program for the executable of the
+// current process.
+func CompileSelf() (*snapshotpb.SnapshotProgram, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+	return Compile(exe)
+}
+
+// hchanType is the type id of runtime.hchan in the compiled programs.
+const hchanType = 1
+
+// Compile compiles a stack-only snapshot program for the executable at the
+// given path. Besides the stacks, the programs only capture the channels
+// that goroutines are blocked on.
+func Compile(path string) (*snapshotpb.SnapshotProgram, error) {
+	exe, err := openExecutable(path)
+	if err != nil {
+		return nil, err
+	}
+	defer exe.close()
+	cfg, hchanByteLen, err := compileRuntimeConfig(exe)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile runtime config for %s: %w", path, err)
+	}
+	cfg.HchanType = hchanType
+	return &snapshotpb.SnapshotProgram{
+		RuntimeConfig:        cfg,
+		PcClassifier:         &snapshotpb.PcClassifier{},
+		SubroutineClassifier: &snapshotpb.SubroutineClassifier{},
+		TypeInfo: map[uint32]*snapshotpb.TypeInfo{
+			hchanType: {ByteLen: hchanByteLen, SerializeBeforeEnqueue: true},
+		},
+	}, nil
+}
+
+// executable gives access to the DWARF and the symbols of an ELF or Mach-O
+// executable.
+type executable struct {
+	dwarf *dwarf.Data
+	// symbol returns the address of the symbol with the given name.
+	symbol func(name string) (uint64, bool)
+	close  func() error
+}
+
+func openExecutable(path string) (executable, error) {
+	if f, err := elf.Open(path); err == nil {
+		d, err := f.DWARF()
+		if err != nil {
+			_ = f.Close()
+			return executable{}, fmt.Errorf("%w: %v", ErrNoDWARF, err)
+		}
+		syms, err := f.Symbols()
+		if err != nil {
+			_ = f.Close()
+			return executable{}, fmt.Errorf("failed to read symbols: %w", err)
+		}
+		return executable{
+			dwarf: d,
+			symbol: func(name string) (uint64, bool) {
+				for i := range syms {
+					if syms[i].Name == name {
+						return syms[i].Value, true
+					}
+				}
+				return 0, false
+			},
+			close: f.Close,
+		}, nil
+	}
+	if f, err := macho.Open(path); err == nil {
+		d, err := f.DWARF()
+		if err != nil {
+			_ = f.Close()
+			return executable{}, fmt.Errorf("%w: %v", ErrNoDWARF, err)
+		}
+		return executable{
+			dwarf: d,
+			symbol: func(name string) (uint64, bool) {
+				if f.Symtab == nil {
+					return 0, false
+				}
+				for i := range f.Symtab.Syms {
+					// Mach-O symbols are prefixed with an underscore when
+					// the binary is linked externally.
+					if s := &f.Symtab.Syms[i]; s.Name == name || s.Name == "_"+name {
+						return s.Value, true
+					}
+				}
+				return 0, false
+			},
+			close: f.Close,
+		}, nil
+	}
+	return executable{}, fmt.Errorf("%s: unrecognized executable format", path)
+}
+
+// runtimeEntries holds the DWARF entries that the runtime config is compiled
+// from.
+type runtimeEntries struct {
+	structs   map[string]*dwarf.StructType
+	variables map[string]uint64
+	functions map[string][2]uint64
+}
+
+var (
+	wantStructs = map[string]bool{
+		"runtime.g":          true,
+		"runtime.gobuf":      true,
+		"runtime.m":          true,
+		"runtime.moduledata": true,
+		"runtime.mstats":     true,
+		"runtime.sudog":      true,
+		"runtime.hchan":      true,
+		// Since go1.24, the pprof labels of a goroutine are a labelMap
+		// struct wrapping a slice of labels. It is only present if
+		// runtime/pprof is linked in.
+		"runtime/pprof.labelMap": true,
+	}
+	wantVariables = map[string]bool{
+		"runtime.allgs":           true,
+		"runtime.firstmoduledata": true,
+		"runtime.memstats":        true,
+	}
+	wantFunctions = map[string]bool{
+		dereferenceFunc:         true,
+		"runtime.stopTheWorld":  true,
+		"runtime.startTheWorld": true,
+	}
+)
+
+func readRuntimeEntries(d *dwarf.Data) (runtimeEntries, error) {
+	re := runtimeEntries{
+		structs:   make(map[string]*dwarf.StructType),
+		variables: make(map[string]uint64),
+		functions: make(map[string][2]uint64),
+	}
+	r := d.Reader()
+	for {
+		e, err := r.Next()
+		if err != nil {
+			return runtimeEntries{}, err
+		}
+		if e == nil {
+			break
+		}
+		name, _ := e.Val(dwarf.AttrName).(string)
+		switch e.Tag {
+		case dwarf.TagStructType:
+			if !wantStructs[name] {
+				break
+			}
+			t, err := d.Type(e.Offset)
+			if err != nil {
+				return runtimeEntries{}, fmt.Errorf("failed to read type %s: %w", name, err)
+			}
+			if st, ok := t.(*dwarf.StructType); ok {
+				re.structs[name] = st
+			}
+		case dwarf.TagVariable:
+			if !wantVariables[name] {
+				break
+			}
+			addr, ok := staticAddress(e)
+			if !ok {
+				return runtimeEntries{}, fmt.Errorf("variable %s has no static address", name)
+			}
+			re.variables[name] = addr
+		case dwarf.TagSubprogram:
+			if wantFunctions[name] {
+				ranges, err := d.Ranges(e)
+				if err != nil {
+					return runtimeEntries{}, fmt.Errorf("failed to read ranges of %s: %w", name, err)
+				}
+				if len(ranges) != 1 {
+					return runtimeEntries{}, fmt.Errorf("function %s has %d ranges", name, len(ranges))
+				}
+				re.functions[name] = ranges[0]
+			}
+			// The functions' parameters, variables and lexical blocks are
+			// not of interest.
+			r.SkipChildren()
+		}
+	}
+	return re, nil
+}
+
+// staticAddress returns the address of a variable whose location is a single
+// DW_OP_addr operation.
+func staticAddress(e *dwarf.Entry) (uint64, bool) {
+	const opAddr = 0x03
+	loc, _ := e.Val(dwarf.AttrLocation).([]byte)
+	if len(loc) != 9 || loc[0] != opAddr {
+		return 0, false
+	}
+	return binary.LittleEndian.Uint64(loc[1:]), true
+}
+
+// fieldOffsets resolves the offsets of fields of a struct type, recording the
+// first error encountered.
+type fieldOffsets struct {
+	re  *runtimeEntries
+	err error
+}
+
+// offset returns the offset of a field of the named struct type. Unless the
+// field is optional, an error is recorded if it does not exist.
+func (f *fieldOffsets) offset(typeName, field string, optional bool) uint32 {
+	st, ok := f.re.structs[typeName]
+	if !ok {
+		if f.err == nil {
+			f.err = fmt.Errorf("type %s not found", typeName)
+		}
+		return 0
+	}
+	for _, fld := range st.Field {
+		if fld.Name == field {
+			return uint32(fld.ByteOffset)
+		}
+	}
+	if !optional && f.err == nil {
+		f.err = fmt.Errorf("field %s.%s not found", typeName, field)
+	}
+	return 0
+}
+
+func (f *fieldOffsets) required(typeName, field string) uint32 {
+	return f.offset(typeName, field, false /* optional */)
+}
+
+// pointer returns the offset of the pointer held by a field of the named
+// struct type. Recent runtimes wrap some pointers in a maybeTraceablePtr, in
+// which case the offset of its vu field, the pointer's value, is added.
+func (f *fieldOffsets) pointer(typeName, field string) uint32 {
+	offset := f.required(typeName, field)
+	st, ok := f.re.structs[typeName]
+	if !ok {
+		return 0
+	}
+	for _, fld := range st.Field {
+		if fld.Name != field {
+			continue
+		}
+		if wrapper, ok := resolveTypedefs(fld.Type).(*dwarf.StructType); ok {
+			vu, ok := findField(wrapper, "vu")
+			if !ok && f.err == nil {
+				f.err = fmt.Errorf("field %s.%s is a %s without a vu field", typeName, field, wrapper.StructName)
+			}
+			offset += uint32(vu)
+		}
+	}
+	return offset
+}
+
+// findField returns the offset of the named field in st or in the structs
+// embedded in it.
+func findField(st *dwarf.StructType, name string) (offset int64, ok bool) {
+	for _, fld := range st.Field {
+		if fld.Name == name {
+			return fld.ByteOffset, true
+		}
+		if inner, isStruct := resolveTypedefs(fld.Type).(*dwarf.StructType); isStruct {
+			if offset, ok := findField(inner, name); ok {
+				return fld.ByteOffset + offset, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// compileLabelLayout fills in the layout of runtime/pprof.labelMap and of its
+// labels, if the executable has them as structs.
+func compileLabelLayout(re *runtimeEntries, cfg *snapshotpb.RuntimeConfig) error {
+	labelMap, ok := re.structs["runtime/pprof.labelMap"]
+	if !ok {
+		return nil
+	}
+	listOffset, label, ok := findLabelList(labelMap)
+	if !ok {
+		return fmt.Errorf("slice of labels not found in runtime/pprof.labelMap")
+	}
+	cfg.LabelMapListOffset = uint32(listOffset)
+	cfg.LabelByteLen = uint32(label.ByteSize)
+	for _, fld := range label.Field {
+		// The fields are exported since the labels moved to
+		// internal/runtime/pprof/label.
+		switch strings.ToLower(fld.Name) {
+		case "key":
+			cfg.LabelKeyOffset = uint32(fld.ByteOffset)
+		case "value":
+			cfg.LabelValueOffset = uint32(fld.ByteOffset)
+		}
+	}
+	if cfg.LabelValueOffset == 0 {
+		return fmt.Errorf("value of %s not found", label.StructName)
+	}
+	return nil
+}
+
+// findLabelList returns the offset in runtime/pprof.labelMap of its slice of
+// labels, which is nested in an embedded struct, and the type of the labels.
+// The labels are only referenced as the elements of the slice, so their type
+// is not found by name.
+func findLabelList(st *dwarf.StructType) (offset int64, label *dwarf.StructType, ok bool) {
+	for _, fld := range st.Field {
+		if label, ok := sliceElem(fld.Type).(*dwarf.StructType); ok {
+			return fld.ByteOffset, label, true
+		}
+		if inner, ok := resolveTypedefs(fld.Type).(*dwarf.StructType); ok {
+			if offset, label, ok := findLabelList(inner); ok {
+				return fld.ByteOffset + offset, label, true
+			}
+		}
+	}
+	return 0, nil, false
+}
+
+// sliceElem returns the element type of a Go slice type, which DWARF
+// describes as a struct with an array pointer, or nil if t is not a slice.
+func sliceElem(t dwarf.Type) dwarf.Type {
+	st, ok := resolveTypedefs(t).(*dwarf.StructType)
+	if !ok || !strings.HasPrefix(st.StructName, "[]") {
+		return nil
+	}
+	for _, fld := range st.Field {
+		if ptr, ok := fld.Type.(*dwarf.PtrType); ok && fld.Name == "array" {
+			return resolveTypedefs(ptr.Type)
+		}
+	}
+	return nil
+}
+
+func resolveTypedefs(t dwarf.Type) dwarf.Type {
+	for {
+		td, ok := t.(*dwarf.TypedefType)
+		if !ok {
+			return t
+		}
+		t = td.Type
+	}
+}
+
+// compileRuntimeConfig compiles the runtime config of the executable, and
+// returns it with the size of runtime.hchan.
+func compileRuntimeConfig(exe executable) (_ *snapshotpb.RuntimeConfig, hchanByteLen uint32, _ error) {
+	re, err := readRuntimeEntries(exe.dwarf)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read DWARF: %w", err)
+	}
+	for name := range wantVariables {
+		if _, ok := re.variables[name]; !ok {
+			return nil, 0, fmt.Errorf("variable %s not found", name)
+		}
+	}
+	for name := range wantFunctions {
+		if _, ok := re.functions[name]; !ok {
+			return nil, 0, fmt.Errorf("function %s not found", name)
+		}
+	}
+	bss, ok := exe.symbol("runtime.bss")
+	if !ok {
+		return nil, 0, fmt.Errorf("symbol runtime.bss not found")
+	}
+
+	f := fieldOffsets{re: &re}
+	gSched := f.required("runtime.g", "sched")
+	cfg := &snapshotpb.RuntimeConfig{
+		GSchedOffset:        gSched,
+		GGoBufPcOffset:      gSched + f.required("runtime.gobuf", "pc"),
+		GGoBufBpOffset:      gSched + f.required("runtime.gobuf", "bp"),
+		GSyscallPcOffset:    f.required("runtime.g", "syscallpc"),
+		GSyscallSpOffset:    f.required("runtime.g", "syscallsp"),
+		GSyscallBpOffset:    f.offset("runtime.g", "syscallbp", true /* optional */),
+		GGoidOffset:         f.required("runtime.g", "goid"),
+		GAtomicstatusOffset: f.required("runtime.g", "atomicstatus"),
+		GStktopspOffset:     f.required("runtime.g", "stktopsp"),
+		GWaitsinceOffset:    f.required("runtime.g", "waitsince"),
+		GWaitreasonOffset:   f.required("runtime.g", "waitreason"),
+		GLabelsOffset:       f.required("runtime.g", "labels"),
+		GGopcOffset:         f.required("runtime.g", "gopc"),
+		GParentGoidOffset:   f.offset("runtime.g", "parentGoid", true /* optional */),
+		GWaitingOffset:      f.required("runtime.g", "waiting"),
+
+		SudogElemOffset:     f.pointer("runtime.sudog", "elem"),
+		SudogCOffset:        f.pointer("runtime.sudog", "c"),
+		SudogWaitlinkOffset: f.pointer("runtime.sudog", "waitlink"),
+
+		MPreemptOffOffset: f.required("runtime.m", "preemptoff"),
+
+		VariableRuntimeDotFirstmoduledata: re.variables["runtime.firstmoduledata"],
+		VariableRuntimeDotAllgs:           re.variables["runtime.allgs"],
+
+		ModuledataTypesOffset:      f.required("runtime.moduledata", "types"),
+		ModuledataEtypesOffset:     f.required("runtime.moduledata", "etypes"),
+		ModuledataTextOffset:       f.required("runtime.moduledata", "text"),
+		ModuledataBssOffset:        f.required("runtime.moduledata", "bss"),
+		ModuledataNextOffset:       f.required("runtime.moduledata", "next"),
+		ModuledataPluginpathOffset: f.required("runtime.moduledata", "pluginpath"),
+		ModuledataModulenameOffset: f.required("runtime.moduledata", "modulename"),
+
+		VariableRuntimeDotMemstats: re.variables["runtime.memstats"],
+		MstatsLastGcUnixOffset:     f.required("runtime.mstats", "last_gc_unix"),
+		MstatsLastGcNanotimeOffset: f.required("runtime.mstats", "last_gc_nanotime"),
+		MstatsPauseTotalNsOffset:   f.required("runtime.mstats", "pause_total_ns"),
+		MstatsNumgcOffset:          f.required("runtime.mstats", "numgc"),
+		MstatsNumforcedgcOffset:    f.required("runtime.mstats", "numforcedgc"),
+		MstatsGcCpuFractionOffset:  f.required("runtime.mstats", "gc_cpu_fraction"),
+
+		GoRuntimeBssAddress: bss,
+
+		DereferenceStartPc:     re.functions[dereferenceFunc][0],
+		DereferenceEndPc:       re.functions[dereferenceFunc][1],
+		StopTheWorldStartAddr:  re.functions["runtime.stopTheWorld"][0],
+		StartTheWorldStartAddr: re.functions["runtime.startTheWorld"][0],
+	}
+	if err := compileLabelLayout(&re, cfg); err != nil {
+		return nil, 0, err
+	}
+	if f.err != nil {
+		return nil, 0, f.err
+	}
+	hchan, ok := re.structs["runtime.hchan"]
+	if !ok {
+		return nil, 0, fmt.Errorf("type runtime.hchan not found")
+	}
+	return cfg, uint32(hchan.ByteSize), nil
+}
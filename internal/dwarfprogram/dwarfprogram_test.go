@@ -0,0 +1,70 @@
+package dwarfprogram
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataExMachina-dev/side-eye-go/internal/stackmachine"
+	"github.com/DataExMachina-dev/side-eye-go/internal/stoptheworld"
+)
+
+// buildSelfSnapshot builds the selfsnapshot test program, with its DWARF,
+// which go test strips from test binaries.
+func buildSelfSnapshot(t *testing.T) string {
+	exe := filepath.Join(t.TempDir(), "selfsnapshot")
+	cmd := exec.Command("go", "build", "-o", exe, "./testdata/selfsnapshot")
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, "%s", out)
+	return exe
+}
+
+func TestCompile(t *testing.T) {
+	exe := buildSelfSnapshot(t)
+	p, err := Compile(exe)
+	require.NoError(t, err)
+	cfg := p.RuntimeConfig
+	require.NotZero(t, cfg.GGoBufPcOffset)
+	require.Greater(t, cfg.GGoBufPcOffset, cfg.GSchedOffset)
+	require.NotZero(t, cfg.GGoidOffset)
+	require.NotZero(t, cfg.VariableRuntimeDotAllgs)
+	require.NotZero(t, cfg.VariableRuntimeDotFirstmoduledata)
+	require.NotZero(t, cfg.VariableRuntimeDotMemstats)
+	require.NotZero(t, cfg.GoRuntimeBssAddress)
+	require.Less(t, cfg.DereferenceStartPc, cfg.DereferenceEndPc)
+	require.NotZero(t, cfg.StopTheWorldStartAddr)
+	require.NotZero(t, cfg.StartTheWorldStartAddr)
+	require.NotZero(t, cfg.GWaitingOffset)
+	require.NotZero(t, cfg.SudogWaitlinkOffset)
+	require.NotZero(t, p.TypeInfo[cfg.HchanType].GetByteLen())
+	require.NoError(t, stackmachine.Verify(p))
+	require.NotNil(t, p.PcClassifier)
+	require.NotNil(t, p.SubroutineClassifier)
+}
+
+func TestCompileWithoutDWARF(t *testing.T) {
+	exe := filepath.Join(t.TempDir(), "selfsnapshot")
+	cmd := exec.Command("go", "build", "-ldflags=-w", "-o", exe, "./testdata/selfsnapshot")
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, "%s", out)
+	_, err = Compile(exe)
+	require.ErrorIs(t, err, ErrNoDWARF)
+}
+
+// TestSelfSnapshot checks that a program compiled by this package can be
+// used to take a snapshot in which the goroutines are unwound correctly. It
+// exercises stoptheworld and allgs with the Go toolchain running the test, so
+// it can be used to validate new Go releases.
+func TestSelfSnapshot(t *testing.T) {
+	if err := stoptheworld.PlatformSupported(); err != nil {
+		t.Skipf("platform not supported: %v", err)
+	}
+	cmd := exec.Command(buildSelfSnapshot(t))
+	cmd.Env = os.Environ()
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, "%s", out)
+	t.Logf("%s", out)
+}
@@ -0,0 +1,320 @@
+// Command selfsnapshot takes a snapshot of itself with a program compiled from
+// its own DWARF, and checks that the snapshot contains a goroutine blocked in
+// a known function, with its pprof labels, its creator and the channel it is
+// blocked on, that a goroutine blocked on a sync.Mutex records its semaphore
+// where the runtime allows it, that the wait reasons of goroutines blocked in
+// various ways match the runtime's, that a goroutine blocked in a syscall is
+// unwound to its caller, and that the runtime's statistics are reported.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+	"unsafe"
+
+	"github.com/DataExMachina-dev/side-eye-go/internal/allgs"
+	"github.com/DataExMachina-dev/side-eye-go/internal/dwarfprogram"
+	"github.com/DataExMachina-dev/side-eye-go/internal/machinapb"
+	"github.com/DataExMachina-dev/side-eye-go/internal/snapshot"
+	"github.com/DataExMachina-dev/side-eye-go/snapshotdata"
+	"github.com/google/pprof/profile"
+)
+
+//go:noinline
+func parked(ch chan struct{}, started chan struct{}) {
+	close(started)
+	<-ch
+}
+
+//go:noinline
+func locked(mu *sync.Mutex) {
+	mu.Lock()
+	mu.Unlock()
+}
+
+//go:noinline
+func selecting(a, b chan struct{}) {
+	select {
+	case <-a:
+	case <-b:
+	}
+}
+
+//go:noinline
+func sleeping() {
+	time.Sleep(time.Hour)
+}
+
+// reading blocks in a read(2) of a pipe; the file descriptor is blocking, so
+// the goroutine stays in the syscall rather than park in the netpoller.
+//
+//go:noinline
+func reading(fd int) {
+	var buf [1]byte
+	_, _ = syscall.Read(fd, buf[:])
+}
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	ch, started := make(chan struct{}), make(chan struct{})
+	go pprof.Do(context.Background(), pprof.Labels("role", "parked"), func(context.Context) {
+		parked(ch, started)
+	})
+	<-started
+	defer close(ch)
+	var mu sync.Mutex
+	mu.Lock()
+	go locked(&mu)
+	defer mu.Unlock()
+	a, b := make(chan struct{}), make(chan struct{})
+	go selecting(a, b)
+	defer close(a)
+	go sleeping()
+	var pipe [2]int
+	if err := syscall.Pipe(pipe[:]); err != nil {
+		return err
+	}
+	go reading(pipe[0])
+	// Unblock the read before the process exits.
+	defer func() { _, _ = syscall.Write(pipe[1], []byte{0}) }()
+	if err := waitForGoroutine("main.reading", "syscall"); err != nil {
+		return err
+	}
+	for fn, waitReason := range map[string]string{
+		"main.locked":    "sync.Mutex.Lock",
+		"main.selecting": "select",
+		"main.sleeping":  "sleep",
+	} {
+		if err := waitForGoroutine(fn, waitReason); err != nil {
+			return err
+		}
+	}
+
+	p, err := dwarfprogram.CompileSelf()
+	if err != nil {
+		return err
+	}
+	runtime.GC()
+	res, err := snapshot.Snapshot(p, snapshot.Options{
+		FramingVersion: snapshotdata.CurrentFramingVersion,
+	})
+	if err != nil {
+		return err
+	}
+	if err := checkRuntimeStats(res.RuntimeStats); err != nil {
+		return err
+	}
+	s, err := snapshotdata.Decode(res.Data)
+	if err != nil {
+		return err
+	}
+	if s.Header.Statistics.NumGoroutines < 2 {
+		return fmt.Errorf("expected at least 2 goroutines, got %d", s.Header.Statistics.NumGoroutines)
+	}
+	if err := checkCreator(s, res.BssAddrShift); err != nil {
+		return err
+	}
+	if err := checkWaitingOn(s, *(*uint64)(unsafe.Pointer(&ch))); err != nil {
+		return err
+	}
+	if err := checkSyscall(s, res.BssAddrShift); err != nil {
+		return err
+	}
+	if err := checkSemaphoreWaiter(
+		s, res.BssAddrShift, uint64(uintptr(unsafe.Pointer(&mu))), uint64(unsafe.Sizeof(mu)),
+	); err != nil {
+		return err
+	}
+	prof := s.GoroutineProfile(res.BssAddrShift)
+	// The wait reason strings are stable across Go versions, even though
+	// their values are not.
+	for fn, waitReason := range map[string]string{
+		"main.parked":    "chan receive",
+		"main.selecting": "select",
+		"main.sleeping":  "sleep",
+		"main.locked":    "sync.Mutex.Lock",
+	} {
+		labels, err := profileLabels(prof, fn)
+		if err != nil {
+			return err
+		}
+		status := labels[snapshotdata.StatusLabel]
+		if len(status) != 1 || status[0] != allgs.Status(allgs.Status_Gwaiting).String() {
+			return fmt.Errorf("unexpected status of %s: %v", fn, status)
+		}
+		if got := labels[snapshotdata.WaitReasonLabel]; len(got) != 1 || got[0] != waitReason {
+			return fmt.Errorf("unexpected wait reason of %s: %v, expected %q", fn, got, waitReason)
+		}
+		if fn == "main.parked" {
+			role := labels["role"]
+			if len(role) != 1 || role[0] != "parked" {
+				return fmt.Errorf("unexpected role label of main.parked: %v", role)
+			}
+			fmt.Printf("found main.parked: %v\n", labels)
+		}
+	}
+	return nil
+}
+
+// profileLabels returns the labels of the sample of the goroutine profile
+// whose stack contains the function of the given name.
+func profileLabels(prof *profile.Profile, fn string) (map[string][]string, error) {
+	for _, sample := range prof.Sample {
+		for _, loc := range sample.Location {
+			for _, line := range loc.Line {
+				if line.Function.Name == fn {
+					return sample.Label, nil
+				}
+			}
+		}
+	}
+	return nil, fmt.Errorf("%s not found in the goroutine profile:\n%v", fn, prof)
+}
+
+// findGoroutine returns the goroutine whose stack contains the function of the
+// given name, including as an inlined call, or nil.
+func findGoroutine(s *snapshotdata.Snapshot, loadBias uint64, name string) *snapshotdata.Goroutine {
+	for i := range s.Goroutines {
+		pcs := make([]uintptr, len(s.Goroutines[i].Stack))
+		for j, pc := range s.Goroutines[i].Stack {
+			pcs[j] = uintptr(pc + loadBias)
+		}
+		frames := runtime.CallersFrames(pcs)
+		for {
+			f, more := frames.Next()
+			if f.Function == name {
+				return &s.Goroutines[i]
+			}
+			if !more {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// checkCreator checks that the goroutine running main.parked was created by
+// main.run, on the main goroutine.
+func checkCreator(s *snapshotdata.Snapshot, loadBias uint64) error {
+	g := findGoroutine(s, loadBias, "main.parked")
+	if g == nil {
+		return fmt.Errorf("main.parked not found in the snapshot")
+	}
+	if g.CreatorPc == 0 {
+		return fmt.Errorf("main.parked has no creator pc")
+	}
+	creator := runtime.FuncForPC(uintptr(g.CreatorPc + loadBias))
+	if creator == nil || creator.Name() != "main.run" {
+		return fmt.Errorf("main.parked was not created by main.run: %v", creator)
+	}
+	if g.ParentGoid != 1 {
+		return fmt.Errorf("unexpected parent goid of main.parked: %d", g.ParentGoid)
+	}
+	return nil
+}
+
+// waitForGoroutine waits for the goroutine running fn to park with the given
+// wait reason, e.g. rather than spin on a mutex.
+func waitForGoroutine(fn string, waitReason string) error {
+	buf := make([]byte, 1<<20)
+	for deadline := time.Now().Add(10 * time.Second); time.Now().Before(deadline); {
+		stacks := string(buf[:runtime.Stack(buf, true /* all */)])
+		for _, g := range strings.Split(stacks, "\n\n") {
+			if strings.Contains(g, "["+waitReason) && strings.Contains(g, fn+"(") {
+				return nil
+			}
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return fmt.Errorf("%s did not block with wait reason %q", fn, waitReason)
+}
+
+// checkSyscall checks that the goroutine running main.reading, which is blocked
+// in a syscall, was unwound from the context saved on entry to the syscall, up
+// to main.reading.
+func checkSyscall(s *snapshotdata.Snapshot, loadBias uint64) error {
+	g := findGoroutine(s, loadBias, "main.reading")
+	if g == nil {
+		return fmt.Errorf("main.reading not found in the snapshot")
+	}
+	if allgs.Status(g.Status) != allgs.Status_Gsyscall {
+		return fmt.Errorf("unexpected status of main.reading: %s", allgs.Status(g.Status))
+	}
+	if g.StackSource != snapshotdata.StackSourceSyscall {
+		return fmt.Errorf("main.reading was not unwound from its syscall context: %d", g.StackSource)
+	}
+	return nil
+}
+
+// checkSemaphoreWaiter checks that the goroutine running main.locked is
+// blocked on the semaphore of the mutex at muAddr, which is muLen bytes long.
+// Before go1.26, the runtime does not link the sudog of a semaphore waiter
+// from g.waiting, and nothing is recorded.
+func checkSemaphoreWaiter(s *snapshotdata.Snapshot, loadBias uint64, muAddr, muLen uint64) error {
+	g := findGoroutine(s, loadBias, "main.locked")
+	if g == nil {
+		return fmt.Errorf("main.locked not found in the snapshot")
+	}
+	if !semaphoreWaitersRecorded {
+		if len(g.WaitingOn) != 0 {
+			return fmt.Errorf("unexpected waiting on entries of main.locked: %v", g.WaitingOn)
+		}
+		return nil
+	}
+	if len(g.WaitingOn) != 1 {
+		return fmt.Errorf("expected main.locked to wait on 1 semaphore, got %v", g.WaitingOn)
+	}
+	if w := g.WaitingOn[0]; w.Chan != 0 || w.Elem < muAddr || w.Elem >= muAddr+muLen {
+		return fmt.Errorf("main.locked is not blocked on the mutex at %#x: %v", muAddr, w)
+	}
+	return nil
+}
+
+// checkWaitingOn checks that the goroutine running main.parked is blocked on
+// the channel at chanAddr, and that the channel was captured.
+func checkWaitingOn(s *snapshotdata.Snapshot, chanAddr uint64) error {
+	waiters := s.ChannelWaiters()[chanAddr]
+	if len(waiters) != 1 {
+		return fmt.Errorf("expected 1 goroutine blocked on the channel, got %v", waiters)
+	}
+	for _, e := range s.Pointees {
+		if e.Addr == chanAddr {
+			if e.DereferenceFailed {
+				return fmt.Errorf("failed to capture the channel")
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("channel not found in the snapshot's pointees")
+}
+
+// checkRuntimeStats checks the runtime statistics reported with the snapshot,
+// which follows a forced garbage collection.
+func checkRuntimeStats(stats *machinapb.RuntimeStats) error {
+	ms := stats.GetMemStats()
+	if ms.GetNumGc() == 0 || ms.GetNumForcedGc() == 0 || ms.GetLastGcUnixNs() == 0 {
+		return fmt.Errorf("unexpected memstats: %v", ms)
+	}
+	for _, m := range stats.GetMetrics() {
+		if m.Name == "/gc/cycles/forced:gc-cycles" {
+			if m.GetUint64Value() != uint64(ms.GetNumForcedGc()) {
+				return fmt.Errorf("forced gc cycles %d do not match memstats %v", m.GetUint64Value(), ms)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("forced gc cycles metric not found")
+}
@@ -0,0 +1,7 @@
+//go:build !go1.26
+
+package main
+
+// semaphoreWaitersRecorded is whether the goroutines blocked on a semaphore
+// have their sudog in g.waiting.
+const semaphoreWaitersRecorded = false
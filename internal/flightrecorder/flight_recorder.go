@@ -0,0 +1,52 @@
+// Package flightrecorder keeps the most recent execution trace of the process
+// in memory, so that it can be dumped after something interesting happened.
+//
+// Starting with go1.25, the recorder is the runtime's trace.FlightRecorder.
+// On older versions, the recorder consumes the execution trace through
+// trace.Start and keeps a ring buffer of the trace's generations; as only one
+// trace can be active at a time, no other execution trace can be started while
+// the recorder runs (see ExclusiveTracing).
+package flightrecorder
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// DefaultMinAge is the window of the recorder if Config.MinAge is zero.
+	DefaultMinAge = 10 * time.Second
+	// DefaultMaxBytes is the bound on the size of the window if
+	// Config.MaxBytes is zero.
+	DefaultMaxBytes = 16 << 20
+)
+
+// Config configures the window of execution trace kept by a Recorder.
+type Config struct {
+	// MinAge is the duration of the most recent execution trace that the
+	// recorder tries to keep. If zero, DefaultMinAge is used.
+	MinAge time.Duration
+	// MaxBytes bounds the size of the execution trace kept, and takes
+	// precedence over MinAge. If zero, DefaultMaxBytes is used.
+	MaxBytes uint64
+}
+
+func (cfg Config) withDefaults() Config {
+	if cfg.MinAge == 0 {
+		cfg.MinAge = DefaultMinAge
+	}
+	if cfg.MaxBytes == 0 {
+		cfg.MaxBytes = DefaultMaxBytes
+	}
+	return cfg
+}
+
+// Span is the time covered by the execution trace dumped by a Recorder.
+type Span struct {
+	Start, End time.Time
+}
+
+// ErrNoData is returned by Recorder.Dump if the recorder has not captured
+// any complete part of the execution trace yet, which happens before go1.25
+// during the first second or so after the recorder is started.
+var ErrNoData = errors.New("the flight recorder has not recorded any execution trace yet")
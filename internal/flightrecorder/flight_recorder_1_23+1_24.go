@@ -0,0 +1,49 @@
+//go:build !go1.25
+
+package flightrecorder
+
+import (
+	"fmt"
+	"io"
+	"runtime/trace"
+	"time"
+)
+
+// ExclusiveTracing is set if the recorder prevents other execution traces from
+// being started with trace.Start while it runs.
+const ExclusiveTracing = true
+
+// Recorder keeps the most recent execution trace of the process in memory.
+type Recorder struct {
+	cfg  Config
+	ring *generationRing
+}
+
+// Start starts recording the execution trace of the process. It fails if an
+// execution trace is already being captured.
+func Start(cfg Config) (*Recorder, error) {
+	cfg = cfg.withDefaults()
+	r := &Recorder{cfg: cfg, ring: newGenerationRing(cfg, time.Now)}
+	if err := trace.Start(r.ring); err != nil {
+		return nil, fmt.Errorf("failed to start the flight recorder: %w", err)
+	}
+	return r, nil
+}
+
+// Config returns the configuration of the recorder, with the defaults
+// applied.
+func (r *Recorder) Config() Config {
+	return r.cfg
+}
+
+// Dump writes the execution trace kept by the recorder to w, and returns the
+// time it covers. Only the generations of the trace that the runtime has
+// completed are written, so the last second or so before the call is missing.
+func (r *Recorder) Dump(w io.Writer) (Span, error) {
+	return r.ring.dump(w)
+}
+
+// Stop stops the recorder. The execution trace that it kept is discarded.
+func (r *Recorder) Stop() {
+	trace.Stop()
+}
@@ -0,0 +1,68 @@
+//go:build go1.25
+
+package flightrecorder
+
+import (
+	"fmt"
+	"io"
+	"runtime/trace"
+	"sync"
+	"time"
+)
+
+// ExclusiveTracing is set if the recorder prevents other execution traces from
+// being started with trace.Start while it runs.
+const ExclusiveTracing = false
+
+// Recorder keeps the most recent execution trace of the process in memory.
+type Recorder struct {
+	cfg Config
+	// mu serializes the calls to Dump, as the trace.FlightRecorder rejects
+	// overlapping calls to WriteTo.
+	mu sync.Mutex
+	fr *trace.FlightRecorder
+	// started is the time at which the recorder was started.
+	started time.Time
+}
+
+// Start starts recording the execution trace of the process.
+func Start(cfg Config) (*Recorder, error) {
+	cfg = cfg.withDefaults()
+	fr := trace.NewFlightRecorder(trace.FlightRecorderConfig{
+		MinAge:   cfg.MinAge,
+		MaxBytes: cfg.MaxBytes,
+	})
+	if err := fr.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start the flight recorder: %w", err)
+	}
+	return &Recorder{cfg: cfg, fr: fr, started: time.Now()}, nil
+}
+
+// Config returns the configuration of the recorder, with the defaults
+// applied.
+func (r *Recorder) Config() Config {
+	return r.cfg
+}
+
+// Dump writes the execution trace kept by the recorder to w, and returns the
+// time it covers. The trace buffers of the runtime are flushed first, so the
+// trace extends to the time of the call. The runtime does not report how much
+// of the past it kept, which is taken to be MinAge.
+func (r *Recorder) Dump(w io.Writer) (Span, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, err := r.fr.WriteTo(w); err != nil {
+		return Span{}, fmt.Errorf("failed to write the flight recorder's trace: %w", err)
+	}
+	end := time.Now()
+	start := end.Add(-r.cfg.MinAge)
+	if start.Before(r.started) {
+		start = r.started
+	}
+	return Span{Start: start, End: end}, nil
+}
+
+// Stop stops the recorder. The execution trace that it kept is discarded.
+func (r *Recorder) Stop() {
+	r.fr.Stop()
+}
@@ -0,0 +1,222 @@
+package flightrecorder
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// The framing of the execution trace produced by trace.Start since go1.22: a
+// header, followed by batches of events. Every batch belongs to a generation;
+// a generation can be parsed on its own once all of its batches have been
+// written, which the runtime does every second or so.
+const (
+	// traceHeaderLen is the length of the header, "go 1.xx trace\x00\x00\x00".
+	traceHeaderLen = 16
+	// evEventBatch starts a batch: [generation, M ID, timestamp, length].
+	evEventBatch = 1
+	// evExperimentalBatch starts a batch of an experiment: [experiment ID,
+	// generation, M ID, timestamp, length]. Added in go1.23.
+	evExperimentalBatch = 49
+	// evEndOfGeneration is a lone byte that ends a generation. Added in
+	// go1.26; until then, a generation ends with the first batch of the
+	// next one.
+	evEndOfGeneration = 52
+	// maxBatchLen bounds the length of the data of a batch.
+	maxBatchLen = 64 << 10
+)
+
+var traceHeaderPrefix = []byte("go 1.")
+
+// generation is a complete generation of the execution trace.
+type generation struct {
+	gen uint64
+	// start is the time at which the generation started, i.e. the end of the
+	// previous generation, or the time the ring was created.
+	start time.Time
+	// end is the time at which the generation was complete.
+	end time.Time
+	// data holds the batches of the generation, as written by the runtime.
+	data []byte
+}
+
+// generationRing is an io.Writer consuming the execution trace that keeps its
+// most recent complete generations, as configured.
+type generationRing struct {
+	cfg Config
+	now func() time.Time
+
+	mu struct {
+		sync.Mutex
+		header []byte
+		// pending holds the bytes written that don't form a complete batch
+		// yet.
+		pending []byte
+		// cur is the generation being written, if any.
+		cur *generation
+		// done holds the complete generations, oldest first.
+		done      []generation
+		doneBytes uint64
+		// lastEnd is the end of the last complete generation, or the time
+		// the ring was created.
+		lastEnd time.Time
+		// err is set if the trace could not be parsed, after which the
+		// trace is dropped.
+		err error
+	}
+}
+
+func newGenerationRing(cfg Config, now func() time.Time) *generationRing {
+	r := &generationRing{cfg: cfg, now: now}
+	r.mu.lastEnd = now()
+	return r
+}
+
+// Write implements io.Writer.
+func (r *generationRing) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.mu.err != nil {
+		return 0, r.mu.err
+	}
+	r.mu.pending = append(r.mu.pending, p...)
+	if err := r.parseLocked(); err != nil {
+		r.mu.err = fmt.Errorf("failed to parse the execution trace: %w", err)
+		r.mu.pending, r.mu.cur = nil, nil
+		return 0, r.mu.err
+	}
+	return len(p), nil
+}
+
+// parseLocked consumes the complete batches of r.mu.pending.
+func (r *generationRing) parseLocked() error {
+	data := r.mu.pending
+	if r.mu.header == nil {
+		if len(data) < traceHeaderLen {
+			return nil
+		}
+		if !bytes.HasPrefix(data, traceHeaderPrefix) {
+			return fmt.Errorf("unexpected header: %q", data[:traceHeaderLen])
+		}
+		r.mu.header = bytes.Clone(data[:traceHeaderLen])
+		data = data[traceHeaderLen:]
+	}
+	for len(data) > 0 {
+		if data[0] == evEndOfGeneration {
+			if r.mu.cur != nil {
+				r.mu.cur.data = append(r.mu.cur.data, data[0])
+				r.finishLocked()
+			}
+			data = data[1:]
+			continue
+		}
+		gen, n, err := readBatch(data)
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			break
+		}
+		if r.mu.cur != nil && r.mu.cur.gen != gen {
+			r.finishLocked()
+		}
+		if r.mu.cur == nil {
+			r.mu.cur = &generation{gen: gen, start: r.mu.lastEnd}
+		}
+		r.mu.cur.data = append(r.mu.cur.data, data[:n]...)
+		data = data[n:]
+	}
+	r.mu.pending = append(r.mu.pending[:0], data...)
+	return nil
+}
+
+// readBatch parses the batch at the start of data and returns its generation
+// and length. The length is zero if data does not hold the whole batch.
+func readBatch(data []byte) (gen uint64, n int, _ error) {
+	off := 1
+	switch data[0] {
+	case evEventBatch:
+	case evExperimentalBatch:
+		off++
+	default:
+		return 0, 0, fmt.Errorf("expected a batch, got event %d", data[0])
+	}
+	if len(data) < off {
+		return 0, 0, nil
+	}
+	// The generation, M ID, timestamp and length of the batch.
+	var header [4]uint64
+	for i := range header {
+		v, k := binary.Uvarint(data[off:])
+		if k == 0 {
+			return 0, 0, nil
+		}
+		if k < 0 {
+			return 0, 0, fmt.Errorf("invalid batch header")
+		}
+		header[i], off = v, off+k
+	}
+	batchLen := header[3]
+	if batchLen > maxBatchLen {
+		return 0, 0, fmt.Errorf("batch of %d bytes exceeds the maximum of %d", batchLen, maxBatchLen)
+	}
+	if uint64(len(data)-off) < batchLen {
+		return 0, 0, nil
+	}
+	return header[0], off + int(batchLen), nil
+}
+
+// finishLocked completes the current generation and drops the generations
+// that fall out of the configured window.
+func (r *generationRing) finishLocked() {
+	g := r.mu.cur
+	r.mu.cur = nil
+	g.end = r.now()
+	r.mu.lastEnd = g.end
+	r.mu.done = append(r.mu.done, *g)
+	r.mu.doneBytes += uint64(len(g.data))
+	minEnd := g.end.Add(-r.cfg.MinAge)
+	for len(r.mu.done) > 1 {
+		oldest := &r.mu.done[0]
+		if r.mu.doneBytes <= r.cfg.MaxBytes && !oldest.end.Before(minEnd) {
+			break
+		}
+		r.mu.doneBytes -= uint64(len(oldest.data))
+		*oldest = generation{}
+		r.mu.done = r.mu.done[1:]
+	}
+}
+
+// dump writes the header of the trace and the complete generations kept, which
+// together form a valid execution trace, and returns the time they cover.
+func (r *generationRing) dump(w io.Writer) (Span, error) {
+	r.mu.Lock()
+	if r.mu.err != nil {
+		err := r.mu.err
+		r.mu.Unlock()
+		return Span{}, err
+	}
+	if len(r.mu.done) == 0 {
+		r.mu.Unlock()
+		return Span{}, ErrNoData
+	}
+	span := Span{Start: r.mu.done[0].start, End: r.mu.done[len(r.mu.done)-1].end}
+	// The data of the generations is not modified once they are complete,
+	// so it can be written without holding the lock.
+	bufs := make([][]byte, 0, len(r.mu.done)+1)
+	bufs = append(bufs, r.mu.header)
+	for _, g := range r.mu.done {
+		bufs = append(bufs, g.data)
+	}
+	r.mu.Unlock()
+
+	for _, b := range bufs {
+		if _, err := w.Write(b); err != nil {
+			return Span{}, err
+		}
+	}
+	return span, nil
+}
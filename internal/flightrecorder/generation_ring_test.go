@@ -0,0 +1,158 @@
+package flightrecorder
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"runtime/trace"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// batch encodes a batch of generation gen with n bytes of data.
+func batch(gen uint64, n int) []byte {
+	b := []byte{evEventBatch}
+	b = binary.AppendUvarint(b, gen)
+	b = binary.AppendUvarint(b, 1 /* M ID */)
+	b = binary.AppendUvarint(b, 1000 /* timestamp */)
+	b = binary.AppendUvarint(b, uint64(n))
+	return append(b, make([]byte, n)...)
+}
+
+// readGenerations splits the trace written by a generationRing into its
+// header and the generation of every batch.
+func readGenerations(t *testing.T, data []byte) (header []byte, gens []uint64) {
+	t.Helper()
+	require.GreaterOrEqual(t, len(data), traceHeaderLen)
+	header, data = data[:traceHeaderLen], data[traceHeaderLen:]
+	for len(data) > 0 {
+		if data[0] == evEndOfGeneration {
+			data = data[1:]
+			continue
+		}
+		gen, n, err := readBatch(data)
+		require.NoError(t, err)
+		require.NotZero(t, n, "truncated batch")
+		gens = append(gens, gen)
+		data = data[n:]
+	}
+	return header, gens
+}
+
+func TestGenerationRing(t *testing.T) {
+	now := time.Unix(0, 0)
+	r := newGenerationRing(Config{MinAge: 3 * time.Second, MaxBytes: 1 << 20}, func() time.Time {
+		return now
+	})
+	var stream []byte
+	stream = append(stream, "go 1.23 trace\x00\x00\x00"...)
+	for gen := uint64(1); gen <= 6; gen++ {
+		stream = append(stream, batch(gen, 10)...)
+		stream = append(stream, batch(gen, 300)...)
+	}
+	stream = append(stream, batch(7, 10)...)
+
+	var buf bytes.Buffer
+	_, err := r.dump(&buf)
+	require.ErrorIs(t, err, ErrNoData)
+
+	// Write the trace in small pieces, so that batches are split across
+	// writes. Every generation is complete one second after the previous
+	// one.
+	var gen uint64
+	for len(stream) > 0 {
+		n := min(7, len(stream))
+		_, err := r.Write(stream[:n])
+		require.NoError(t, err)
+		stream = stream[n:]
+		r.mu.Lock()
+		if r.mu.cur != nil && r.mu.cur.gen != gen {
+			gen = r.mu.cur.gen
+			now = now.Add(time.Second)
+		}
+		r.mu.Unlock()
+	}
+
+	// Generation 7 is not complete. Generations 1 and 2 were complete more
+	// than 3s before generation 6. Generation k was complete at k seconds.
+	span, err := r.dump(&buf)
+	require.NoError(t, err)
+	require.Equal(t, Span{Start: time.Unix(2, 0), End: time.Unix(6, 0)}, span)
+	header, gens := readGenerations(t, buf.Bytes())
+	require.Equal(t, "go 1.23 trace\x00\x00\x00", string(header))
+	require.Equal(t, []uint64{3, 3, 4, 4, 5, 5, 6, 6}, gens)
+
+	// The end of generation 7 is signaled in-band.
+	_, err = r.Write([]byte{evEndOfGeneration})
+	require.NoError(t, err)
+	buf.Reset()
+	span, err = r.dump(&buf)
+	require.NoError(t, err)
+	require.Equal(t, Span{Start: time.Unix(3, 0), End: time.Unix(7, 0)}, span)
+	_, gens = readGenerations(t, buf.Bytes())
+	require.Equal(t, []uint64{4, 4, 5, 5, 6, 6, 7}, gens)
+}
+
+func TestGenerationRingMaxBytes(t *testing.T) {
+	r := newGenerationRing(Config{MinAge: time.Hour, MaxBytes: 1000}, time.Now)
+	_, err := r.Write([]byte("go 1.23 trace\x00\x00\x00"))
+	require.NoError(t, err)
+	for gen := uint64(1); gen <= 5; gen++ {
+		_, err := r.Write(batch(gen, 400))
+		require.NoError(t, err)
+	}
+	var buf bytes.Buffer
+	_, err = r.dump(&buf)
+	require.NoError(t, err)
+	_, gens := readGenerations(t, buf.Bytes())
+	require.Equal(t, []uint64{3, 4}, gens)
+
+	// A single generation is kept even if it exceeds the bound.
+	r = newGenerationRing(Config{MinAge: time.Hour, MaxBytes: 10}, time.Now)
+	_, err = r.Write(append([]byte("go 1.23 trace\x00\x00\x00"), append(batch(1, 400), batch(2, 1)...)...))
+	require.NoError(t, err)
+	buf.Reset()
+	_, err = r.dump(&buf)
+	require.NoError(t, err)
+	_, gens = readGenerations(t, buf.Bytes())
+	require.Equal(t, []uint64{1}, gens)
+}
+
+func TestGenerationRingInvalid(t *testing.T) {
+	r := newGenerationRing(Config{MinAge: time.Hour, MaxBytes: 1 << 20}, time.Now)
+	_, err := r.Write([]byte("go 1.23 trace\x00\x00\x00\x42"))
+	require.ErrorContains(t, err, "expected a batch, got event 66")
+	_, err = r.dump(&bytes.Buffer{})
+	require.Error(t, err)
+	require.False(t, errors.Is(err, ErrNoData))
+}
+
+// TestGenerationRingTrace feeds the execution trace of the current process to
+// a generationRing.
+func TestGenerationRingTrace(t *testing.T) {
+	r := newGenerationRing(Config{MinAge: time.Minute, MaxBytes: 64 << 20}, time.Now)
+	require.NoError(t, trace.Start(r))
+	stopped := false
+	defer func() {
+		if !stopped {
+			trace.Stop()
+		}
+	}()
+	var buf bytes.Buffer
+	require.Eventually(t, func() bool {
+		buf.Reset()
+		_, err := r.dump(&buf)
+		return err == nil
+	}, 10*time.Second, 10*time.Millisecond)
+	trace.Stop()
+	stopped = true
+
+	header, gens := readGenerations(t, buf.Bytes())
+	require.True(t, bytes.HasPrefix(header, traceHeaderPrefix), "header: %q", header)
+	require.NotEmpty(t, gens)
+	for i := 1; i < len(gens); i++ {
+		require.LessOrEqual(t, gens[i-1], gens[i])
+	}
+}
@@ -2,14 +2,63 @@
 // buffer. It is a binary protocol copied from framing.h.
 package framing
 
+import "unsafe"
+
+// The framing version identifies the layout of the snapshot data. The agent
+// writes the version requested by the client, which is echoed in the
+// SnapshotResponse, so that decoders of framing.h keep working until they ask
+// for a newer layout.
+const (
+	// Version0 is the layout of framing.h. The SnapshotHeader ends before
+	// Flags.
+	Version0 uint32 = 0
+	// Version1 extends the SnapshotHeader with Flags and FilteredGoroutines,
+	// and the GoroutineHeader with LabelsByteLen and the fields that follow
+	// it. Every goroutine's header is followed by its labels and its
+	// WaitingOn entries.
+	Version1 uint32 = 1
+	// CurrentVersion is the newest version this package can write.
+	CurrentVersion = Version1
+)
+
+// SnapshotHeaderByteLen returns the length of the SnapshotHeader in the given
+// framing version. Older versions write a prefix of the struct.
+func SnapshotHeaderByteLen(version uint32) uint32 {
+	if version == Version0 {
+		return uint32(unsafe.Offsetof(SnapshotHeader{}.Flags))
+	}
+	return uint32(unsafe.Sizeof(SnapshotHeader{}))
+}
+
 type SnapshotHeader struct {
 	DataByteLen       uint32
 	GoroutinesByteLen uint32
 	Statistics        Statistics
 	KTimeNS           uint64
 	LastGcUnix        uint64
+	// Flags and the fields that follow it are only written from Version1.
+	Flags SnapshotFlags
+	// FilteredGoroutines is the number of live goroutines that were not
+	// captured because they did not match the snapshot's goroutine filter.
+	FilteredGoroutines uint32
 }
 
+// SnapshotFlags is a bitmask of properties of a snapshot.
+type SnapshotFlags uint32
+
+const (
+	// SnapshotFlagTruncated is set if the snapshot data did not fit in the
+	// maximum buffer size and goroutines or pointees were dropped.
+	SnapshotFlagTruncated SnapshotFlags = 1 << iota
+	// SnapshotFlagPartial is set if the snapshot's maximum pause elapsed
+	// before all goroutines or pointees were captured.
+	SnapshotFlagPartial
+	// SnapshotFlagDropped is set if pointees were dropped because they did
+	// not fit in the memory that was set aside for chasing pointers before
+	// the world was stopped.
+	SnapshotFlagDropped
+)
+
 type Statistics struct {
 	StacksDurationNs  uint64
 	PointerDurationNs uint64
@@ -18,16 +67,77 @@ type Statistics struct {
 	NonLiveGoroutines uint32
 }
 
+// GoroutineHeaderByteLen returns the length of the GoroutineHeader in the
+// given framing version. Older versions write a prefix of the struct.
+func GoroutineHeaderByteLen(version uint32) uint32 {
+	if version == Version0 {
+		return uint32(unsafe.Offsetof(GoroutineHeader{}.LabelsByteLen))
+	}
+	return uint32(unsafe.Sizeof(GoroutineHeader{}))
+}
+
 type GoroutineHeader struct {
 	Goid           uint64
 	StackHash      uint64
 	Status         uint32
 	WaitReason     uint8
+	StackSource    StackSource
 	WaitSinceNanos int64
 	StackBytes     uint32
 	DataByteLen    uint32
+	// LabelsByteLen and the fields that follow it are only written from
+	// Version1.
+	//
+	// LabelsByteLen is the length of the goroutine's pprof labels, which
+	// follow the header, padded to a multiple of 8 bytes. Every label is
+	// encoded as LabelHeader followed by the key and the value.
+	LabelsByteLen uint32
+	// NumWaitingOn is the number of WaitingOn entries that follow the
+	// labels.
+	NumWaitingOn uint32
+	// CreatorPc is the pc of the go statement that created the goroutine,
+	// relative to the same base as the pcs of the stack, or 0 if unknown.
+	CreatorPc uint64
+	// ParentGoid is the goid of the goroutine that created the goroutine, or
+	// 0 if unknown.
+	ParentGoid uint64
+}
+
+// LabelHeader precedes the key and the value of a pprof label.
+type LabelHeader struct {
+	KeyLen   uint32
+	ValueLen uint32
 }
 
+// WaitingOn describes a sudog that a waiting goroutine is blocked on, in the
+// goroutine's g.waiting list.
+type WaitingOn struct {
+	// Chan is the address of the channel's hchan, or 0 if the goroutine is
+	// blocked on a semaphore.
+	Chan uint64
+	// Elem is the address of the element being sent or received on the
+	// channel, or the address of the semaphore.
+	Elem uint64
+}
+
+// StackSource describes where the context used to unwind a goroutine's stack
+// came from.
+type StackSource uint8
+
+const (
+	// StackSourceSched means the stack was unwound from the context saved in
+	// g.sched when the goroutine was descheduled or preempted.
+	StackSourceSched StackSource = iota
+	// StackSourceSyscall means the goroutine was in a syscall, and the stack
+	// was unwound from g.syscallpc and g.syscallbp.
+	StackSourceSyscall
+	// StackSourceNone means the goroutine was running, and g.sched held no
+	// context to unwind it from, which is the case unless it was preempted.
+	// Its stack is empty, and its StackHash and StackBytes are zero. Such
+	// goroutines are only written from Version1.
+	StackSourceNone
+)
+
 type QueueEntry struct {
 	Type uint32
 	Len  uint32
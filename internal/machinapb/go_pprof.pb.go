@@ -31,19 +31,64 @@ const (
 	CaptureContents_EXECUTION_TRACE CaptureContents = 1
 	// Capture both the CPU profile and the execution trace.
 	CaptureContents_EXECUTION_TRACE_AND_CPU_PROFILE CaptureContents = 2
+	// Capture a runtime/pprof profile, in the pprof format unless noted
+	// otherwise. The heap, allocs, threadcreate and goroutine profiles are
+	// captured when the request is received. The mutex and block profiles
+	// cover the requested duration, for which their sampling rates are raised
+	// unless the process already samples them as often; without a duration,
+	// they cover the lifetime of the process.
+	CaptureContents_HEAP_PROFILE   CaptureContents = 3
+	CaptureContents_ALLOCS_PROFILE CaptureContents = 4
+	CaptureContents_MUTEX_PROFILE  CaptureContents = 5
+	// The runtime does not expose the block profile rate, so the block profile
+	// is only captured over a duration in processes that declared their rate
+	// with sideeye.WithBlockProfileRate, to which it is then restored. In other
+	// processes, such a capture fails with FAILED_PRECONDITION; the profile
+	// can still be captured without a duration.
+	CaptureContents_BLOCK_PROFILE        CaptureContents = 6
+	CaptureContents_THREADCREATE_PROFILE CaptureContents = 7
+	CaptureContents_GOROUTINE_PROFILE    CaptureContents = 8
+	// The goroutine profile as text, with goroutines aggregated by stack
+	// (debug=1).
+	CaptureContents_GOROUTINE_PROFILE_TEXT CaptureContents = 9
+	// The stacks of all goroutines as text, in the format of an unrecovered
+	// panic (debug=2).
+	CaptureContents_GOROUTINE_STACKS_TEXT CaptureContents = 10
+	// Dump the execution trace of the recent past kept by the flight
+	// recorder, without waiting. The flight recorder must have been enabled
+	// when the process was initialized.
+	CaptureContents_FLIGHT_RECORDER_TRACE CaptureContents = 11
 )
 
 // Enum value maps for CaptureContents.
 var (
 	CaptureContents_name = map[int32]string{
-		0: "INVALID",
-		1: "EXECUTION_TRACE",
-		2: "EXECUTION_TRACE_AND_CPU_PROFILE",
+		0:  "INVALID",
+		1:  "EXECUTION_TRACE",
+		2:  "EXECUTION_TRACE_AND_CPU_PROFILE",
+		3:  "HEAP_PROFILE",
+		4:  "ALLOCS_PROFILE",
+		5:  "MUTEX_PROFILE",
+		6:  "BLOCK_PROFILE",
+		7:  "THREADCREATE_PROFILE",
+		8:  "GOROUTINE_PROFILE",
+		9:  "GOROUTINE_PROFILE_TEXT",
+		10: "GOROUTINE_STACKS_TEXT",
+		11: "FLIGHT_RECORDER_TRACE",
 	}
 	CaptureContents_value = map[string]int32{
 		"INVALID":                         0,
 		"EXECUTION_TRACE":                 1,
 		"EXECUTION_TRACE_AND_CPU_PROFILE": 2,
+		"HEAP_PROFILE":                    3,
+		"ALLOCS_PROFILE":                  4,
+		"MUTEX_PROFILE":                   5,
+		"BLOCK_PROFILE":                   6,
+		"THREADCREATE_PROFILE":            7,
+		"GOROUTINE_PROFILE":               8,
+		"GOROUTINE_PROFILE_TEXT":          9,
+		"GOROUTINE_STACKS_TEXT":           10,
+		"FLIGHT_RECORDER_TRACE":           11,
 	}
 )
 
@@ -84,13 +129,20 @@ type CaptureRequest struct {
 	unknownFields protoimpl.UnknownFields
 
 	ProcessFingerprint string `protobuf:"bytes,1,opt,name=process_fingerprint,json=processFingerprint,proto3" json:"process_fingerprint,omitempty"`
-	// The number of seconds to capture the execution trace and CPU profile for.
+	// The number of seconds to capture the execution trace and CPU profile, or
+	// the mutex and block profiles, for. Concurrent captures of the same
+	// profile are queued and run one after the other.
 	Seconds uint32 `protobuf:"varint,2,opt,name=seconds,proto3" json:"seconds,omitempty"`
 	// The base URL of the pprof server. Note that this should not include
 	// the /debug/pprof prefix.
 	PprofAddress string `protobuf:"bytes,3,opt,name=pprof_address,json=pprofAddress,proto3" json:"pprof_address,omitempty"`
 	// The contents to capture.
 	Contents CaptureContents `protobuf:"varint,4,opt,name=contents,proto3,enum=go_pprof.CaptureContents" json:"contents,omitempty"`
+	// If the CPU profiler or the execution tracer are already in use, for
+	// example by the program itself, the number of seconds to wait for them to
+	// be released before failing. Without it, the capture fails right away
+	// with FAILED_PRECONDITION.
+	InUseWaitSeconds uint32 `protobuf:"varint,5,opt,name=in_use_wait_seconds,json=inUseWaitSeconds,proto3" json:"in_use_wait_seconds,omitempty"`
 }
 
 func (x *CaptureRequest) Reset() {
@@ -153,6 +205,13 @@ func (x *CaptureRequest) GetContents() CaptureContents {
 	return CaptureContents_INVALID
 }
 
+func (x *CaptureRequest) GetInUseWaitSeconds() uint32 {
+	if x != nil {
+		return x.InUseWaitSeconds
+	}
+	return 0
+}
+
 type CaptureResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -166,6 +225,9 @@ type CaptureResponse struct {
 	//	*CaptureResponse_CpuProfileStart_
 	//	*CaptureResponse_CpuProfileChunk
 	//	*CaptureResponse_CpuProfileComplete_
+	//	*CaptureResponse_ProfileStart_
+	//	*CaptureResponse_ProfileChunk
+	//	*CaptureResponse_ProfileComplete_
 	Message isCaptureResponse_Message `protobuf_oneof:"message"`
 }
 
@@ -250,6 +312,27 @@ func (x *CaptureResponse) GetCpuProfileComplete() *CaptureResponse_CpuProfileCom
 	return nil
 }
 
+func (x *CaptureResponse) GetProfileStart() *CaptureResponse_ProfileStart {
+	if x, ok := x.GetMessage().(*CaptureResponse_ProfileStart_); ok {
+		return x.ProfileStart
+	}
+	return nil
+}
+
+func (x *CaptureResponse) GetProfileChunk() *chunkpb.Chunk {
+	if x, ok := x.GetMessage().(*CaptureResponse_ProfileChunk); ok {
+		return x.ProfileChunk
+	}
+	return nil
+}
+
+func (x *CaptureResponse) GetProfileComplete() *CaptureResponse_ProfileComplete {
+	if x, ok := x.GetMessage().(*CaptureResponse_ProfileComplete_); ok {
+		return x.ProfileComplete
+	}
+	return nil
+}
+
 type isCaptureResponse_Message interface {
 	isCaptureResponse_Message()
 }
@@ -278,6 +361,18 @@ type CaptureResponse_CpuProfileComplete_ struct {
 	CpuProfileComplete *CaptureResponse_CpuProfileComplete `protobuf:"bytes,6,opt,name=cpu_profile_complete,json=cpuProfileComplete,proto3,oneof"`
 }
 
+type CaptureResponse_ProfileStart_ struct {
+	ProfileStart *CaptureResponse_ProfileStart `protobuf:"bytes,7,opt,name=profile_start,json=profileStart,proto3,oneof"`
+}
+
+type CaptureResponse_ProfileChunk struct {
+	ProfileChunk *chunkpb.Chunk `protobuf:"bytes,8,opt,name=profile_chunk,json=profileChunk,proto3,oneof"`
+}
+
+type CaptureResponse_ProfileComplete_ struct {
+	ProfileComplete *CaptureResponse_ProfileComplete `protobuf:"bytes,9,opt,name=profile_complete,json=profileComplete,proto3,oneof"`
+}
+
 func (*CaptureResponse_ExecutionTraceStart_) isCaptureResponse_Message() {}
 
 func (*CaptureResponse_ExecutionTraceChunk) isCaptureResponse_Message() {}
@@ -290,6 +385,12 @@ func (*CaptureResponse_CpuProfileChunk) isCaptureResponse_Message() {}
 
 func (*CaptureResponse_CpuProfileComplete_) isCaptureResponse_Message() {}
 
+func (*CaptureResponse_ProfileStart_) isCaptureResponse_Message() {}
+
+func (*CaptureResponse_ProfileChunk) isCaptureResponse_Message() {}
+
+func (*CaptureResponse_ProfileComplete_) isCaptureResponse_Message() {}
+
 type CaptureResponse_ExecutionTraceStart struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -305,7 +406,10 @@ type CaptureResponse_ExecutionTraceStart struct {
 	// of the monotonic clock around when the approximate_boot_time clock
 	// reading was taken.
 	TraceStartMonotonic uint64 `protobuf:"varint,3,opt,name=trace_start_monotonic,json=traceStartMonotonic,proto3" json:"trace_start_monotonic,omitempty"`
-	// The duration of the execution trace in seconds.
+	// The duration of the execution trace in seconds. For a dump of the
+	// flight recorder, this is the duration of the recent past that the
+	// flight recorder is configured to keep, which ends around
+	// trace_start_monotonic.
 	DurationSeconds uint32 `protobuf:"varint,2,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"`
 }
 
@@ -490,6 +594,104 @@ func (*CaptureResponse_CpuProfileComplete) Descriptor() ([]byte, []int) {
 	return file_go_pprof_proto_rawDescGZIP(), []int{1, 3}
 }
 
+// Indicates that a runtime/pprof profile has started for the process.
+type CaptureResponse_ProfileStart struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The profile being captured.
+	Contents CaptureContents `protobuf:"varint,1,opt,name=contents,proto3,enum=go_pprof.CaptureContents" json:"contents,omitempty"`
+	// The duration that the profile covers in seconds, or zero if the
+	// profile is captured at once or covers the lifetime of the process.
+	DurationSeconds uint32 `protobuf:"varint,2,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"`
+}
+
+func (x *CaptureResponse_ProfileStart) Reset() {
+	*x = CaptureResponse_ProfileStart{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_go_pprof_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CaptureResponse_ProfileStart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaptureResponse_ProfileStart) ProtoMessage() {}
+
+func (x *CaptureResponse_ProfileStart) ProtoReflect() protoreflect.Message {
+	mi := &file_go_pprof_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaptureResponse_ProfileStart.ProtoReflect.Descriptor instead.
+func (*CaptureResponse_ProfileStart) Descriptor() ([]byte, []int) {
+	return file_go_pprof_proto_rawDescGZIP(), []int{1, 4}
+}
+
+func (x *CaptureResponse_ProfileStart) GetContents() CaptureContents {
+	if x != nil {
+		return x.Contents
+	}
+	return CaptureContents_INVALID
+}
+
+func (x *CaptureResponse_ProfileStart) GetDurationSeconds() uint32 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+// Indicates that a runtime/pprof profile has completed for the process.
+type CaptureResponse_ProfileComplete struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *CaptureResponse_ProfileComplete) Reset() {
+	*x = CaptureResponse_ProfileComplete{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_go_pprof_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CaptureResponse_ProfileComplete) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaptureResponse_ProfileComplete) ProtoMessage() {}
+
+func (x *CaptureResponse_ProfileComplete) ProtoReflect() protoreflect.Message {
+	mi := &file_go_pprof_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaptureResponse_ProfileComplete.ProtoReflect.Descriptor instead.
+func (*CaptureResponse_ProfileComplete) Descriptor() ([]byte, []int) {
+	return file_go_pprof_proto_rawDescGZIP(), []int{1, 5}
+}
+
 var File_go_pprof_proto protoreflect.FileDescriptor
 
 var file_go_pprof_proto_rawDesc = []byte{
@@ -497,7 +699,7 @@ var file_go_pprof_proto_rawDesc = []byte{
 	0x12, 0x08, 0x67, 0x6f, 0x5f, 0x70, 0x70, 0x72, 0x6f, 0x66, 0x1a, 0x0b, 0x63, 0x68, 0x75, 0x6e,
 	0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
-	0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xb7, 0x01, 0x0a, 0x0e, 0x43, 0x61, 0x70,
+	0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xe6, 0x01, 0x0a, 0x0e, 0x43, 0x61, 0x70,
 	0x74, 0x75, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2f, 0x0a, 0x13, 0x70,
 	0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69,
 	0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73,
@@ -509,66 +711,104 @@ var file_go_pprof_proto_rawDesc = []byte{
 	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e,
 	0x67, 0x6f, 0x5f, 0x70, 0x70, 0x72, 0x6f, 0x66, 0x2e, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65,
 	0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x08, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
-	0x74, 0x73, 0x22, 0xdf, 0x06, 0x0a, 0x0f, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x63, 0x0a, 0x15, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74,
-	0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x72, 0x61, 0x63, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x67, 0x6f, 0x5f, 0x70, 0x70, 0x72, 0x6f, 0x66,
-	0x2e, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x2e, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x53,
-	0x74, 0x61, 0x72, 0x74, 0x48, 0x00, 0x52, 0x13, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f,
-	0x6e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x42, 0x0a, 0x15, 0x65,
-	0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x72, 0x61, 0x63, 0x65, 0x5f, 0x63,
-	0x68, 0x75, 0x6e, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x63, 0x68, 0x75,
-	0x6e, 0x6b, 0x2e, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x48, 0x00, 0x52, 0x13, 0x65, 0x78, 0x65, 0x63,
-	0x75, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12,
-	0x6c, 0x0a, 0x18, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x72, 0x61,
-	0x63, 0x65, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x30, 0x2e, 0x67, 0x6f, 0x5f, 0x70, 0x70, 0x72, 0x6f, 0x66, 0x2e, 0x43, 0x61, 0x70,
-	0x74, 0x75, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x45, 0x78, 0x65,
-	0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x6d, 0x70, 0x6c,
-	0x65, 0x74, 0x65, 0x48, 0x00, 0x52, 0x16, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e,
-	0x54, 0x72, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x57, 0x0a,
-	0x11, 0x63, 0x70, 0x75, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x73, 0x74, 0x61,
-	0x72, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x67, 0x6f, 0x5f, 0x70, 0x70,
-	0x72, 0x6f, 0x66, 0x2e, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x2e, 0x43, 0x70, 0x75, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x53, 0x74,
-	0x61, 0x72, 0x74, 0x48, 0x00, 0x52, 0x0f, 0x63, 0x70, 0x75, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c,
-	0x65, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x3a, 0x0a, 0x11, 0x63, 0x70, 0x75, 0x5f, 0x70, 0x72,
-	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x0c, 0x2e, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x2e, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x48,
-	0x00, 0x52, 0x0f, 0x63, 0x70, 0x75, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x43, 0x68, 0x75,
-	0x6e, 0x6b, 0x12, 0x60, 0x0a, 0x14, 0x63, 0x70, 0x75, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c,
-	0x65, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x2c, 0x2e, 0x67, 0x6f, 0x5f, 0x70, 0x70, 0x72, 0x6f, 0x66, 0x2e, 0x43, 0x61, 0x70, 0x74,
-	0x75, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x43, 0x70, 0x75, 0x50,
-	0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x48, 0x00,
-	0x52, 0x12, 0x63, 0x70, 0x75, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x43, 0x6f, 0x6d, 0x70,
-	0x6c, 0x65, 0x74, 0x65, 0x1a, 0xc4, 0x01, 0x0a, 0x13, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69,
-	0x6f, 0x6e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x4e, 0x0a, 0x15,
-	0x61, 0x70, 0x70, 0x72, 0x6f, 0x78, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x5f, 0x62, 0x6f, 0x6f, 0x74,
-	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
-	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x13, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x78, 0x69,
-	0x6d, 0x61, 0x74, 0x65, 0x42, 0x6f, 0x6f, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x32, 0x0a, 0x15,
-	0x74, 0x72, 0x61, 0x63, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x6d, 0x6f, 0x6e, 0x6f,
-	0x74, 0x6f, 0x6e, 0x69, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x13, 0x74, 0x72, 0x61,
-	0x63, 0x65, 0x53, 0x74, 0x61, 0x72, 0x74, 0x4d, 0x6f, 0x6e, 0x6f, 0x74, 0x6f, 0x6e, 0x69, 0x63,
-	0x12, 0x29, 0x0a, 0x10, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x63,
-	0x6f, 0x6e, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x64, 0x75, 0x72, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x1a, 0x18, 0x0a, 0x16, 0x45,
-	0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x6d,
-	0x70, 0x6c, 0x65, 0x74, 0x65, 0x1a, 0x3c, 0x0a, 0x0f, 0x43, 0x70, 0x75, 0x50, 0x72, 0x6f, 0x66,
-	0x69, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x29, 0x0a, 0x10, 0x64, 0x75, 0x72, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0d, 0x52, 0x0f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x63, 0x6f,
-	0x6e, 0x64, 0x73, 0x1a, 0x14, 0x0a, 0x12, 0x43, 0x70, 0x75, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c,
+	0x74, 0x73, 0x12, 0x2d, 0x0a, 0x13, 0x69, 0x6e, 0x5f, 0x75, 0x73, 0x65, 0x5f, 0x77, 0x61, 0x69,
+	0x74, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x10, 0x69, 0x6e, 0x55, 0x73, 0x65, 0x57, 0x61, 0x69, 0x74, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64,
+	0x73, 0x22, 0xc0, 0x09, 0x0a, 0x0f, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x63, 0x0a, 0x15, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x74, 0x72, 0x61, 0x63, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x67, 0x6f, 0x5f, 0x70, 0x70, 0x72, 0x6f, 0x66, 0x2e,
+	0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e,
+	0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x53, 0x74,
+	0x61, 0x72, 0x74, 0x48, 0x00, 0x52, 0x13, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e,
+	0x54, 0x72, 0x61, 0x63, 0x65, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x42, 0x0a, 0x15, 0x65, 0x78,
+	0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x72, 0x61, 0x63, 0x65, 0x5f, 0x63, 0x68,
+	0x75, 0x6e, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x63, 0x68, 0x75, 0x6e,
+	0x6b, 0x2e, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x48, 0x00, 0x52, 0x13, 0x65, 0x78, 0x65, 0x63, 0x75,
+	0x74, 0x69, 0x6f, 0x6e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x6c,
+	0x0a, 0x18, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x72, 0x61, 0x63,
+	0x65, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x30, 0x2e, 0x67, 0x6f, 0x5f, 0x70, 0x70, 0x72, 0x6f, 0x66, 0x2e, 0x43, 0x61, 0x70, 0x74,
+	0x75, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x45, 0x78, 0x65, 0x63,
+	0x75, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65,
+	0x74, 0x65, 0x48, 0x00, 0x52, 0x16, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x54,
+	0x72, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x57, 0x0a, 0x11,
+	0x63, 0x70, 0x75, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x67, 0x6f, 0x5f, 0x70, 0x70, 0x72,
+	0x6f, 0x66, 0x2e, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x2e, 0x43, 0x70, 0x75, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x53, 0x74, 0x61,
+	0x72, 0x74, 0x48, 0x00, 0x52, 0x0f, 0x63, 0x70, 0x75, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65,
+	0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x3a, 0x0a, 0x11, 0x63, 0x70, 0x75, 0x5f, 0x70, 0x72, 0x6f,
+	0x66, 0x69, 0x6c, 0x65, 0x5f, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0c, 0x2e, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x2e, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x48, 0x00,
+	0x52, 0x0f, 0x63, 0x70, 0x75, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x43, 0x68, 0x75, 0x6e,
+	0x6b, 0x12, 0x60, 0x0a, 0x14, 0x63, 0x70, 0x75, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65,
+	0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x2c, 0x2e, 0x67, 0x6f, 0x5f, 0x70, 0x70, 0x72, 0x6f, 0x66, 0x2e, 0x43, 0x61, 0x70, 0x74, 0x75,
+	0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x43, 0x70, 0x75, 0x50, 0x72,
+	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x48, 0x00, 0x52,
+	0x12, 0x63, 0x70, 0x75, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x43, 0x6f, 0x6d, 0x70, 0x6c,
+	0x65, 0x74, 0x65, 0x12, 0x4d, 0x0a, 0x0d, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x67, 0x6f, 0x5f,
+	0x70, 0x70, 0x72, 0x6f, 0x66, 0x2e, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x53, 0x74, 0x61,
+	0x72, 0x74, 0x48, 0x00, 0x52, 0x0c, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x53, 0x74, 0x61,
+	0x72, 0x74, 0x12, 0x33, 0x0a, 0x0d, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x63, 0x68,
+	0x75, 0x6e, 0x6b, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x63, 0x68, 0x75, 0x6e,
+	0x6b, 0x2e, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x48, 0x00, 0x52, 0x0c, 0x70, 0x72, 0x6f, 0x66, 0x69,
+	0x6c, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x56, 0x0a, 0x10, 0x70, 0x72, 0x6f, 0x66, 0x69,
+	0x6c, 0x65, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x29, 0x2e, 0x67, 0x6f, 0x5f, 0x70, 0x70, 0x72, 0x6f, 0x66, 0x2e, 0x43, 0x61, 0x70,
+	0x74, 0x75, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x50, 0x72, 0x6f,
+	0x66, 0x69, 0x6c, 0x65, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x48, 0x00, 0x52, 0x0f,
+	0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x1a,
+	0xc4, 0x01, 0x0a, 0x13, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x72, 0x61,
+	0x63, 0x65, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x4e, 0x0a, 0x15, 0x61, 0x70, 0x70, 0x72, 0x6f,
+	0x78, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x5f, 0x62, 0x6f, 0x6f, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x13, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x78, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x42,
+	0x6f, 0x6f, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x32, 0x0a, 0x15, 0x74, 0x72, 0x61, 0x63, 0x65,
+	0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x6d, 0x6f, 0x6e, 0x6f, 0x74, 0x6f, 0x6e, 0x69, 0x63,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x13, 0x74, 0x72, 0x61, 0x63, 0x65, 0x53, 0x74, 0x61,
+	0x72, 0x74, 0x4d, 0x6f, 0x6e, 0x6f, 0x74, 0x6f, 0x6e, 0x69, 0x63, 0x12, 0x29, 0x0a, 0x10, 0x64,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53,
+	0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x1a, 0x18, 0x0a, 0x16, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74,
+	0x69, 0x6f, 0x6e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65,
+	0x1a, 0x3c, 0x0a, 0x0f, 0x43, 0x70, 0x75, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x53, 0x74,
+	0x61, 0x72, 0x74, 0x12, 0x29, 0x0a, 0x10, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x64,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x1a, 0x14,
+	0x0a, 0x12, 0x43, 0x70, 0x75, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x43, 0x6f, 0x6d, 0x70,
+	0x6c, 0x65, 0x74, 0x65, 0x1a, 0x70, 0x0a, 0x0c, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x53,
+	0x74, 0x61, 0x72, 0x74, 0x12, 0x35, 0x0a, 0x08, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x5f, 0x70, 0x70, 0x72, 0x6f,
+	0x66, 0x2e, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
+	0x73, 0x52, 0x08, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x64,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53,
+	0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x1a, 0x11, 0x0a, 0x0f, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c,
 	0x65, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x42, 0x09, 0x0a, 0x07, 0x6d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x2a, 0x58, 0x0a, 0x0f, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x43,
-	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x0b, 0x0a, 0x07, 0x49, 0x4e, 0x56, 0x41, 0x4c,
-	0x49, 0x44, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f, 0x45, 0x58, 0x45, 0x43, 0x55, 0x54, 0x49, 0x4f,
-	0x4e, 0x5f, 0x54, 0x52, 0x41, 0x43, 0x45, 0x10, 0x01, 0x12, 0x23, 0x0a, 0x1f, 0x45, 0x58, 0x45,
-	0x43, 0x55, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x54, 0x52, 0x41, 0x43, 0x45, 0x5f, 0x41, 0x4e, 0x44,
-	0x5f, 0x43, 0x50, 0x55, 0x5f, 0x50, 0x52, 0x4f, 0x46, 0x49, 0x4c, 0x45, 0x10, 0x02, 0x32, 0x4d,
+	0x73, 0x61, 0x67, 0x65, 0x2a, 0xa7, 0x02, 0x0a, 0x0f, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65,
+	0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x0b, 0x0a, 0x07, 0x49, 0x4e, 0x56, 0x41,
+	0x4c, 0x49, 0x44, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f, 0x45, 0x58, 0x45, 0x43, 0x55, 0x54, 0x49,
+	0x4f, 0x4e, 0x5f, 0x54, 0x52, 0x41, 0x43, 0x45, 0x10, 0x01, 0x12, 0x23, 0x0a, 0x1f, 0x45, 0x58,
+	0x45, 0x43, 0x55, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x54, 0x52, 0x41, 0x43, 0x45, 0x5f, 0x41, 0x4e,
+	0x44, 0x5f, 0x43, 0x50, 0x55, 0x5f, 0x50, 0x52, 0x4f, 0x46, 0x49, 0x4c, 0x45, 0x10, 0x02, 0x12,
+	0x10, 0x0a, 0x0c, 0x48, 0x45, 0x41, 0x50, 0x5f, 0x50, 0x52, 0x4f, 0x46, 0x49, 0x4c, 0x45, 0x10,
+	0x03, 0x12, 0x12, 0x0a, 0x0e, 0x41, 0x4c, 0x4c, 0x4f, 0x43, 0x53, 0x5f, 0x50, 0x52, 0x4f, 0x46,
+	0x49, 0x4c, 0x45, 0x10, 0x04, 0x12, 0x11, 0x0a, 0x0d, 0x4d, 0x55, 0x54, 0x45, 0x58, 0x5f, 0x50,
+	0x52, 0x4f, 0x46, 0x49, 0x4c, 0x45, 0x10, 0x05, 0x12, 0x11, 0x0a, 0x0d, 0x42, 0x4c, 0x4f, 0x43,
+	0x4b, 0x5f, 0x50, 0x52, 0x4f, 0x46, 0x49, 0x4c, 0x45, 0x10, 0x06, 0x12, 0x18, 0x0a, 0x14, 0x54,
+	0x48, 0x52, 0x45, 0x41, 0x44, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x5f, 0x50, 0x52, 0x4f, 0x46,
+	0x49, 0x4c, 0x45, 0x10, 0x07, 0x12, 0x15, 0x0a, 0x11, 0x47, 0x4f, 0x52, 0x4f, 0x55, 0x54, 0x49,
+	0x4e, 0x45, 0x5f, 0x50, 0x52, 0x4f, 0x46, 0x49, 0x4c, 0x45, 0x10, 0x08, 0x12, 0x1a, 0x0a, 0x16,
+	0x47, 0x4f, 0x52, 0x4f, 0x55, 0x54, 0x49, 0x4e, 0x45, 0x5f, 0x50, 0x52, 0x4f, 0x46, 0x49, 0x4c,
+	0x45, 0x5f, 0x54, 0x45, 0x58, 0x54, 0x10, 0x09, 0x12, 0x19, 0x0a, 0x15, 0x47, 0x4f, 0x52, 0x4f,
+	0x55, 0x54, 0x49, 0x4e, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x43, 0x4b, 0x53, 0x5f, 0x54, 0x45, 0x58,
+	0x54, 0x10, 0x0a, 0x12, 0x19, 0x0a, 0x15, 0x46, 0x4c, 0x49, 0x47, 0x48, 0x54, 0x5f, 0x52, 0x45,
+	0x43, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f, 0x54, 0x52, 0x41, 0x43, 0x45, 0x10, 0x0b, 0x32, 0x4d,
 	0x0a, 0x07, 0x47, 0x6f, 0x50, 0x70, 0x72, 0x6f, 0x66, 0x12, 0x42, 0x0a, 0x07, 0x43, 0x61, 0x70,
 	0x74, 0x75, 0x72, 0x65, 0x12, 0x18, 0x2e, 0x67, 0x6f, 0x5f, 0x70, 0x70, 0x72, 0x6f, 0x66, 0x2e,
 	0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19,
@@ -590,7 +830,7 @@ func file_go_pprof_proto_rawDescGZIP() []byte {
 }
 
 var file_go_pprof_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_go_pprof_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_go_pprof_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_go_pprof_proto_goTypes = []interface{}{
 	(CaptureContents)(0),                           // 0: go_pprof.CaptureContents
 	(*CaptureRequest)(nil),                         // 1: go_pprof.CaptureRequest
@@ -599,25 +839,31 @@ var file_go_pprof_proto_goTypes = []interface{}{
 	(*CaptureResponse_ExecutionTraceComplete)(nil), // 4: go_pprof.CaptureResponse.ExecutionTraceComplete
 	(*CaptureResponse_CpuProfileStart)(nil),        // 5: go_pprof.CaptureResponse.CpuProfileStart
 	(*CaptureResponse_CpuProfileComplete)(nil),     // 6: go_pprof.CaptureResponse.CpuProfileComplete
-	(*chunkpb.Chunk)(nil),                          // 7: chunk.Chunk
-	(*timestamppb.Timestamp)(nil),                  // 8: google.protobuf.Timestamp
+	(*CaptureResponse_ProfileStart)(nil),           // 7: go_pprof.CaptureResponse.ProfileStart
+	(*CaptureResponse_ProfileComplete)(nil),        // 8: go_pprof.CaptureResponse.ProfileComplete
+	(*chunkpb.Chunk)(nil),                          // 9: chunk.Chunk
+	(*timestamppb.Timestamp)(nil),                  // 10: google.protobuf.Timestamp
 }
 var file_go_pprof_proto_depIdxs = []int32{
-	0, // 0: go_pprof.CaptureRequest.contents:type_name -> go_pprof.CaptureContents
-	3, // 1: go_pprof.CaptureResponse.execution_trace_start:type_name -> go_pprof.CaptureResponse.ExecutionTraceStart
-	7, // 2: go_pprof.CaptureResponse.execution_trace_chunk:type_name -> chunk.Chunk
-	4, // 3: go_pprof.CaptureResponse.execution_trace_complete:type_name -> go_pprof.CaptureResponse.ExecutionTraceComplete
-	5, // 4: go_pprof.CaptureResponse.cpu_profile_start:type_name -> go_pprof.CaptureResponse.CpuProfileStart
-	7, // 5: go_pprof.CaptureResponse.cpu_profile_chunk:type_name -> chunk.Chunk
-	6, // 6: go_pprof.CaptureResponse.cpu_profile_complete:type_name -> go_pprof.CaptureResponse.CpuProfileComplete
-	8, // 7: go_pprof.CaptureResponse.ExecutionTraceStart.approximate_boot_time:type_name -> google.protobuf.Timestamp
-	1, // 8: go_pprof.GoPprof.Capture:input_type -> go_pprof.CaptureRequest
-	2, // 9: go_pprof.GoPprof.Capture:output_type -> go_pprof.CaptureResponse
-	9, // [9:10] is the sub-list for method output_type
-	8, // [8:9] is the sub-list for method input_type
-	8, // [8:8] is the sub-list for extension type_name
-	8, // [8:8] is the sub-list for extension extendee
-	0, // [0:8] is the sub-list for field type_name
+	0,  // 0: go_pprof.CaptureRequest.contents:type_name -> go_pprof.CaptureContents
+	3,  // 1: go_pprof.CaptureResponse.execution_trace_start:type_name -> go_pprof.CaptureResponse.ExecutionTraceStart
+	9,  // 2: go_pprof.CaptureResponse.execution_trace_chunk:type_name -> chunk.Chunk
+	4,  // 3: go_pprof.CaptureResponse.execution_trace_complete:type_name -> go_pprof.CaptureResponse.ExecutionTraceComplete
+	5,  // 4: go_pprof.CaptureResponse.cpu_profile_start:type_name -> go_pprof.CaptureResponse.CpuProfileStart
+	9,  // 5: go_pprof.CaptureResponse.cpu_profile_chunk:type_name -> chunk.Chunk
+	6,  // 6: go_pprof.CaptureResponse.cpu_profile_complete:type_name -> go_pprof.CaptureResponse.CpuProfileComplete
+	7,  // 7: go_pprof.CaptureResponse.profile_start:type_name -> go_pprof.CaptureResponse.ProfileStart
+	9,  // 8: go_pprof.CaptureResponse.profile_chunk:type_name -> chunk.Chunk
+	8,  // 9: go_pprof.CaptureResponse.profile_complete:type_name -> go_pprof.CaptureResponse.ProfileComplete
+	10, // 10: go_pprof.CaptureResponse.ExecutionTraceStart.approximate_boot_time:type_name -> google.protobuf.Timestamp
+	0,  // 11: go_pprof.CaptureResponse.ProfileStart.contents:type_name -> go_pprof.CaptureContents
+	1,  // 12: go_pprof.GoPprof.Capture:input_type -> go_pprof.CaptureRequest
+	2,  // 13: go_pprof.GoPprof.Capture:output_type -> go_pprof.CaptureResponse
+	13, // [13:14] is the sub-list for method output_type
+	12, // [12:13] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
 }
 
 func init() { file_go_pprof_proto_init() }
@@ -698,6 +944,30 @@ func file_go_pprof_proto_init() {
 				return nil
 			}
 		}
+		file_go_pprof_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CaptureResponse_ProfileStart); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_go_pprof_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CaptureResponse_ProfileComplete); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_go_pprof_proto_msgTypes[1].OneofWrappers = []interface{}{
 		(*CaptureResponse_ExecutionTraceStart_)(nil),
@@ -706,6 +976,9 @@ func file_go_pprof_proto_init() {
 		(*CaptureResponse_CpuProfileStart_)(nil),
 		(*CaptureResponse_CpuProfileChunk)(nil),
 		(*CaptureResponse_CpuProfileComplete_)(nil),
+		(*CaptureResponse_ProfileStart_)(nil),
+		(*CaptureResponse_ProfileChunk)(nil),
+		(*CaptureResponse_ProfileComplete_)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -713,7 +986,7 @@ func file_go_pprof_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_go_pprof_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   6,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
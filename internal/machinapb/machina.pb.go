@@ -71,7 +71,67 @@ func (x ArrowIpcMessage_Kind) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ArrowIpcMessage_Kind.Descriptor instead.
 func (ArrowIpcMessage_Kind) EnumDescriptor() ([]byte, []int) {
-	return file_machina_proto_rawDescGZIP(), []int{6, 0}
+	return file_machina_proto_rawDescGZIP(), []int{7, 0}
+}
+
+type StackMachineTrace_Event_Kind int32
+
+const (
+	StackMachineTrace_Event_UNKNOWN StackMachineTrace_Event_Kind = 0
+	// An operation was executed.
+	StackMachineTrace_Event_OP StackMachineTrace_Event_Kind = 1
+	// Memory at addr could not be read.
+	StackMachineTrace_Event_DEREFERENCE_FAILED StackMachineTrace_Event_Kind = 2
+	// The pointee at addr, of the given type, was not enqueued because it
+	// is nil or was already enqueued.
+	StackMachineTrace_Event_ENQUEUE_SKIPPED StackMachineTrace_Event_Kind = 3
+	// The go runtime type id in addr does not map to a type id.
+	StackMachineTrace_Event_TYPE_RESOLUTION_MISS StackMachineTrace_Event_Kind = 4
+)
+
+// Enum value maps for StackMachineTrace_Event_Kind.
+var (
+	StackMachineTrace_Event_Kind_name = map[int32]string{
+		0: "UNKNOWN",
+		1: "OP",
+		2: "DEREFERENCE_FAILED",
+		3: "ENQUEUE_SKIPPED",
+		4: "TYPE_RESOLUTION_MISS",
+	}
+	StackMachineTrace_Event_Kind_value = map[string]int32{
+		"UNKNOWN":              0,
+		"OP":                   1,
+		"DEREFERENCE_FAILED":   2,
+		"ENQUEUE_SKIPPED":      3,
+		"TYPE_RESOLUTION_MISS": 4,
+	}
+)
+
+func (x StackMachineTrace_Event_Kind) Enum() *StackMachineTrace_Event_Kind {
+	p := new(StackMachineTrace_Event_Kind)
+	*p = x
+	return p
+}
+
+func (x StackMachineTrace_Event_Kind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StackMachineTrace_Event_Kind) Descriptor() protoreflect.EnumDescriptor {
+	return file_machina_proto_enumTypes[1].Descriptor()
+}
+
+func (StackMachineTrace_Event_Kind) Type() protoreflect.EnumType {
+	return &file_machina_proto_enumTypes[1]
+}
+
+func (x StackMachineTrace_Event_Kind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StackMachineTrace_Event_Kind.Descriptor instead.
+func (StackMachineTrace_Event_Kind) EnumDescriptor() ([]byte, []int) {
+	return file_machina_proto_rawDescGZIP(), []int{13, 0, 0}
 }
 
 type WatchProcessesRequest struct {
@@ -195,8 +255,14 @@ type GetExecutableRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The path to the executable.
+	// The hash of the executable, as reported in Process.binary_hash. If set,
+	// the request fails with FAILED_PRECONDITION if the executable of the
+	// process has a different hash.
 	Hash string `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	// The offset in the executable at which to start streaming, to resume a
+	// transfer that was interrupted. The hash should be set along with it, so
+	// that the parts of a different executable are not stitched together.
+	Offset uint64 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
 }
 
 func (x *GetExecutableRequest) Reset() {
@@ -238,6 +304,13 @@ func (x *GetExecutableRequest) GetHash() string {
 	return ""
 }
 
+func (x *GetExecutableRequest) GetOffset() uint64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
 // The SnapshotRequest drives the snapshot process.
 type SnapshotRequest struct {
 	state         protoimpl.MessageState
@@ -320,6 +393,86 @@ func (*SnapshotRequest_Setup_) isSnapshotRequest_Request() {}
 
 func (*SnapshotRequest_Snapshot_) isSnapshotRequest_Request() {}
 
+// GoroutineFilter selects the goroutines to capture in a snapshot. Each of the
+// criteria that is set must be satisfied for a goroutine to be captured.
+type GoroutineFilter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// If non-empty, a goroutine must have a frame whose pc falls in one of the
+	// ranges.
+	PcRanges []*GoroutineFilter_PcRange `protobuf:"bytes,1,rep,name=pc_ranges,json=pcRanges,proto3" json:"pc_ranges,omitempty"`
+	// If non-empty, a goroutine's id must be one of these.
+	Goids []uint64 `protobuf:"varint,2,rep,packed,name=goids,proto3" json:"goids,omitempty"`
+	// If non-zero, a bitmask of the goroutine statuses to capture; bit i
+	// corresponds to the runtime's goroutine status i (e.g. 1 << 4 for
+	// _Gwaiting).
+	StatusMask uint32 `protobuf:"varint,3,opt,name=status_mask,json=statusMask,proto3" json:"status_mask,omitempty"`
+	// If non-empty, a goroutine must match all of the labels.
+	Labels []*GoroutineFilter_LabelMatch `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels,omitempty"`
+}
+
+func (x *GoroutineFilter) Reset() {
+	*x = GoroutineFilter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_machina_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GoroutineFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GoroutineFilter) ProtoMessage() {}
+
+func (x *GoroutineFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_machina_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GoroutineFilter.ProtoReflect.Descriptor instead.
+func (*GoroutineFilter) Descriptor() ([]byte, []int) {
+	return file_machina_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GoroutineFilter) GetPcRanges() []*GoroutineFilter_PcRange {
+	if x != nil {
+		return x.PcRanges
+	}
+	return nil
+}
+
+func (x *GoroutineFilter) GetGoids() []uint64 {
+	if x != nil {
+		return x.Goids
+	}
+	return nil
+}
+
+func (x *GoroutineFilter) GetStatusMask() uint32 {
+	if x != nil {
+		return x.StatusMask
+	}
+	return 0
+}
+
+func (x *GoroutineFilter) GetLabels() []*GoroutineFilter_LabelMatch {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
 type EventsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -336,7 +489,7 @@ type EventsRequest struct {
 func (x *EventsRequest) Reset() {
 	*x = EventsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_machina_proto_msgTypes[4]
+		mi := &file_machina_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -349,7 +502,7 @@ func (x *EventsRequest) String() string {
 func (*EventsRequest) ProtoMessage() {}
 
 func (x *EventsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_machina_proto_msgTypes[4]
+	mi := &file_machina_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -362,7 +515,7 @@ func (x *EventsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EventsRequest.ProtoReflect.Descriptor instead.
 func (*EventsRequest) Descriptor() ([]byte, []int) {
-	return file_machina_proto_rawDescGZIP(), []int{4}
+	return file_machina_proto_rawDescGZIP(), []int{5}
 }
 
 func (m *EventsRequest) GetRequest() isEventsRequest_Request {
@@ -429,7 +582,7 @@ type ArrowEncodedData struct {
 func (x *ArrowEncodedData) Reset() {
 	*x = ArrowEncodedData{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_machina_proto_msgTypes[5]
+		mi := &file_machina_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -442,7 +595,7 @@ func (x *ArrowEncodedData) String() string {
 func (*ArrowEncodedData) ProtoMessage() {}
 
 func (x *ArrowEncodedData) ProtoReflect() protoreflect.Message {
-	mi := &file_machina_proto_msgTypes[5]
+	mi := &file_machina_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -455,7 +608,7 @@ func (x *ArrowEncodedData) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ArrowEncodedData.ProtoReflect.Descriptor instead.
 func (*ArrowEncodedData) Descriptor() ([]byte, []int) {
-	return file_machina_proto_rawDescGZIP(), []int{5}
+	return file_machina_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ArrowEncodedData) GetIpcMessage() []byte {
@@ -484,7 +637,7 @@ type ArrowIpcMessage struct {
 func (x *ArrowIpcMessage) Reset() {
 	*x = ArrowIpcMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_machina_proto_msgTypes[6]
+		mi := &file_machina_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -497,7 +650,7 @@ func (x *ArrowIpcMessage) String() string {
 func (*ArrowIpcMessage) ProtoMessage() {}
 
 func (x *ArrowIpcMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_machina_proto_msgTypes[6]
+	mi := &file_machina_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -510,7 +663,7 @@ func (x *ArrowIpcMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ArrowIpcMessage.ProtoReflect.Descriptor instead.
 func (*ArrowIpcMessage) Descriptor() ([]byte, []int) {
-	return file_machina_proto_rawDescGZIP(), []int{6}
+	return file_machina_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ArrowIpcMessage) GetKind() ArrowIpcMessage_Kind {
@@ -541,7 +694,7 @@ type ArrowIpcStream struct {
 func (x *ArrowIpcStream) Reset() {
 	*x = ArrowIpcStream{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_machina_proto_msgTypes[7]
+		mi := &file_machina_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -554,7 +707,7 @@ func (x *ArrowIpcStream) String() string {
 func (*ArrowIpcStream) ProtoMessage() {}
 
 func (x *ArrowIpcStream) ProtoReflect() protoreflect.Message {
-	mi := &file_machina_proto_msgTypes[7]
+	mi := &file_machina_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -567,7 +720,7 @@ func (x *ArrowIpcStream) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ArrowIpcStream.ProtoReflect.Descriptor instead.
 func (*ArrowIpcStream) Descriptor() ([]byte, []int) {
-	return file_machina_proto_rawDescGZIP(), []int{7}
+	return file_machina_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ArrowIpcStream) GetId() uint32 {
@@ -595,7 +748,7 @@ type ArrowIpcStreams struct {
 func (x *ArrowIpcStreams) Reset() {
 	*x = ArrowIpcStreams{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_machina_proto_msgTypes[8]
+		mi := &file_machina_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -608,7 +761,7 @@ func (x *ArrowIpcStreams) String() string {
 func (*ArrowIpcStreams) ProtoMessage() {}
 
 func (x *ArrowIpcStreams) ProtoReflect() protoreflect.Message {
-	mi := &file_machina_proto_msgTypes[8]
+	mi := &file_machina_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -621,7 +774,7 @@ func (x *ArrowIpcStreams) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ArrowIpcStreams.ProtoReflect.Descriptor instead.
 func (*ArrowIpcStreams) Descriptor() ([]byte, []int) {
-	return file_machina_proto_rawDescGZIP(), []int{8}
+	return file_machina_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ArrowIpcStreams) GetStreams() []*ArrowIpcStream {
@@ -649,7 +802,7 @@ type EventsResponse struct {
 func (x *EventsResponse) Reset() {
 	*x = EventsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_machina_proto_msgTypes[9]
+		mi := &file_machina_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -662,7 +815,7 @@ func (x *EventsResponse) String() string {
 func (*EventsResponse) ProtoMessage() {}
 
 func (x *EventsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_machina_proto_msgTypes[9]
+	mi := &file_machina_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -675,7 +828,7 @@ func (x *EventsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EventsResponse.ProtoReflect.Descriptor instead.
 func (*EventsResponse) Descriptor() ([]byte, []int) {
-	return file_machina_proto_rawDescGZIP(), []int{9}
+	return file_machina_proto_rawDescGZIP(), []int{10}
 }
 
 func (m *EventsResponse) GetResponse() isEventsResponse_Response {
@@ -759,6 +912,29 @@ type SnapshotResponse struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
+	// The snapshot data, in the layout given by framing_version. The data is a
+	// SnapshotHeader followed by the goroutines, each a GoroutineHeader
+	// followed by its data, and then by the pointees.
+	//
+	// Version 0 is the layout of framing.h: a 56-byte SnapshotHeader and a
+	// 40-byte GoroutineHeader, which is followed by the goroutine's stack and
+	// frames. The source of the context the stack was unwound from is stored
+	// in the padding byte at offset 21 of the GoroutineHeader.
+	//
+	// Version 1 appends to the SnapshotHeader, making it 64 bytes:
+	//   uint32 flags;                // at offset 56
+	//   uint32 filtered_goroutines;  // at offset 60
+	// and to the GoroutineHeader, making it 64 bytes:
+	//   uint32 labels_byte_len;      // at offset 40
+	//   uint32 num_waiting_on;       // at offset 44
+	//   uint64 creator_pc;           // at offset 48
+	//   uint64 parent_goid;          // at offset 56
+	// Every goroutine's header is then followed by its pprof labels, padded to
+	// a multiple of 8 bytes, and by num_waiting_on 16-byte entries of the
+	// channels or the semaphore it is blocked on, before its stack.
+	// Goroutines blocked on a semaphore, as in sync.Mutex, sync.Cond or
+	// sync.WaitGroup, only have an entry from go1.26: before that, the runtime
+	// does not link their sudog from g.waiting.
 	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
 	// The timestamp of the snapshot. This is a best-effort timestamp that
 	// should closely match the time at which the snapshot was taken.
@@ -773,12 +949,48 @@ type SnapshotResponse struct {
 	// / address according to debug information, due to address space layout
 	// / randomization (ASLR).
 	BssAddrShift uint64 `protobuf:"varint,5,opt,name=bss_addr_shift,json=bssAddrShift,proto3" json:"bss_addr_shift,omitempty"`
+	// The total length of the snapshot data. If the client requested
+	// framing_version 1 or newer, large snapshots are streamed as a sequence of
+	// SnapshotResponse messages; the first message carries all the metadata
+	// fields, and the data fields of all the messages are concatenated to form
+	// the snapshot data. Otherwise, the snapshot is sent in a single message.
+	DataByteLen uint64 `protobuf:"varint,6,opt,name=data_byte_len,json=dataByteLen,proto3" json:"data_byte_len,omitempty"`
+	// Set if the snapshot data did not fit in its maximum size, so that the
+	// goroutines or pointees that did not fit were left out. Unlike the flags
+	// of the SnapshotHeader, this is reported for every framing_version.
+	Truncated bool `protobuf:"varint,11,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	// Set if the snapshot's maximum pause elapsed before it was complete, so
+	// that goroutines or pointees were left out. Like truncated, this is
+	// reported for every framing_version.
+	Partial bool `protobuf:"varint,12,opt,name=partial,proto3" json:"partial,omitempty"`
+	// Set if pointees were dropped because the memory set aside for them was
+	// exhausted; they are counted in skipped_pointees. Like truncated, this is
+	// reported for every framing_version.
+	Dropped bool `protobuf:"varint,14,opt,name=dropped,proto3" json:"dropped,omitempty"`
+	// The number of live goroutines that were left out of the snapshot by
+	// SnapshotRequest.Snapshot.goroutine_filter. Like truncated, this is
+	// reported for every framing_version.
+	FilteredGoroutines uint32 `protobuf:"varint,13,opt,name=filtered_goroutines,json=filteredGoroutines,proto3" json:"filtered_goroutines,omitempty"`
+	// Statistics about the pointees that were not captured, keyed by type id.
+	// Pointees are skipped when they are deeper than their type's maximum
+	// depth, when their root exhausted its byte budget, or when the snapshot
+	// buffer filled up. A pointee that is skipped can still be captured when
+	// it is reached again at a shallower depth or from another root; it is then
+	// counted here as well.
+	SkippedPointees map[uint32]*SkippedPointees `protobuf:"bytes,7,rep,name=skipped_pointees,json=skippedPointees,proto3" json:"skipped_pointees,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The trace of the stack machine, if it was requested.
+	Trace *StackMachineTrace `protobuf:"bytes,8,opt,name=trace,proto3" json:"trace,omitempty"`
+	// The state of the Go runtime at the time of the snapshot.
+	RuntimeStats *RuntimeStats `protobuf:"bytes,9,opt,name=runtime_stats,json=runtimeStats,proto3" json:"runtime_stats,omitempty"`
+	// The layout of data, as negotiated with
+	// SnapshotRequest.Snapshot.framing_version.
+	FramingVersion uint32 `protobuf:"varint,10,opt,name=framing_version,json=framingVersion,proto3" json:"framing_version,omitempty"`
 }
 
 func (x *SnapshotResponse) Reset() {
 	*x = SnapshotResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_machina_proto_msgTypes[10]
+		mi := &file_machina_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -791,7 +1003,7 @@ func (x *SnapshotResponse) String() string {
 func (*SnapshotResponse) ProtoMessage() {}
 
 func (x *SnapshotResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_machina_proto_msgTypes[10]
+	mi := &file_machina_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -804,7 +1016,7 @@ func (x *SnapshotResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SnapshotResponse.ProtoReflect.Descriptor instead.
 func (*SnapshotResponse) Descriptor() ([]byte, []int) {
-	return file_machina_proto_rawDescGZIP(), []int{10}
+	return file_machina_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *SnapshotResponse) GetData() []byte {
@@ -842,29 +1054,100 @@ func (x *SnapshotResponse) GetBssAddrShift() uint64 {
 	return 0
 }
 
-type MachinaInfoRequest struct {
+func (x *SnapshotResponse) GetDataByteLen() uint64 {
+	if x != nil {
+		return x.DataByteLen
+	}
+	return 0
+}
+
+func (x *SnapshotResponse) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+func (x *SnapshotResponse) GetPartial() bool {
+	if x != nil {
+		return x.Partial
+	}
+	return false
+}
+
+func (x *SnapshotResponse) GetDropped() bool {
+	if x != nil {
+		return x.Dropped
+	}
+	return false
+}
+
+func (x *SnapshotResponse) GetFilteredGoroutines() uint32 {
+	if x != nil {
+		return x.FilteredGoroutines
+	}
+	return 0
+}
+
+func (x *SnapshotResponse) GetSkippedPointees() map[uint32]*SkippedPointees {
+	if x != nil {
+		return x.SkippedPointees
+	}
+	return nil
+}
+
+func (x *SnapshotResponse) GetTrace() *StackMachineTrace {
+	if x != nil {
+		return x.Trace
+	}
+	return nil
+}
+
+func (x *SnapshotResponse) GetRuntimeStats() *RuntimeStats {
+	if x != nil {
+		return x.RuntimeStats
+	}
+	return nil
+}
+
+func (x *SnapshotResponse) GetFramingVersion() uint32 {
+	if x != nil {
+		return x.FramingVersion
+	}
+	return 0
+}
+
+// RuntimeStats describe the state of the Go runtime of a process.
+type RuntimeStats struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	// A sample of a selection of the runtime/metrics supported by the process'
+	// Go version, taken right before the world was stopped: the heap sizes,
+	// the GC cycles, the goroutine count, GOMAXPROCS, the scheduler latencies
+	// and the total mutex wait time.
+	Metrics  []*RuntimeStats_Metric `protobuf:"bytes,1,rep,name=metrics,proto3" json:"metrics,omitempty"`
+	MemStats *RuntimeStats_MemStats `protobuf:"bytes,2,opt,name=mem_stats,json=memStats,proto3" json:"mem_stats,omitempty"`
 }
 
-func (x *MachinaInfoRequest) Reset() {
-	*x = MachinaInfoRequest{}
+func (x *RuntimeStats) Reset() {
+	*x = RuntimeStats{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_machina_proto_msgTypes[11]
+		mi := &file_machina_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *MachinaInfoRequest) String() string {
+func (x *RuntimeStats) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MachinaInfoRequest) ProtoMessage() {}
+func (*RuntimeStats) ProtoMessage() {}
 
-func (x *MachinaInfoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_machina_proto_msgTypes[11]
+func (x *RuntimeStats) ProtoReflect() protoreflect.Message {
+	mi := &file_machina_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -875,55 +1158,65 @@ func (x *MachinaInfoRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MachinaInfoRequest.ProtoReflect.Descriptor instead.
-func (*MachinaInfoRequest) Descriptor() ([]byte, []int) {
-	return file_machina_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use RuntimeStats.ProtoReflect.Descriptor instead.
+func (*RuntimeStats) Descriptor() ([]byte, []int) {
+	return file_machina_proto_rawDescGZIP(), []int{12}
 }
 
-type MachinaInfoResponse struct {
+func (x *RuntimeStats) GetMetrics() []*RuntimeStats_Metric {
+	if x != nil {
+		return x.Metrics
+	}
+	return nil
+}
+
+func (x *RuntimeStats) GetMemStats() *RuntimeStats_MemStats {
+	if x != nil {
+		return x.MemStats
+	}
+	return nil
+}
+
+// StackMachineTrace records the execution of the snapshot program by the stack
+// machine, to debug programs that fail to capture some of the data.
+type StackMachineTrace struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// A unique identifier for this machina.
-	Fingerprint string `protobuf:"bytes,1,opt,name=fingerprint,proto3" json:"fingerprint,omitempty"`
-	// The hostname of the machina.
-	Hostname string `protobuf:"bytes,3,opt,name=hostname,proto3" json:"hostname,omitempty"`
-	// The agent version.
-	Version string `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
-	// The version of the kernel in "{major}.{minor}.{patch}" format.
-	KernelVersion string `protobuf:"bytes,8,opt,name=kernel_version,json=kernelVersion,proto3" json:"kernel_version,omitempty"`
-	// The token of the tenant to which this machina belongs.
-	//
-	// TODO: Define any structure for this token. For now it's opaque and will
-	// correspond to the token used to scope requests to a tenant.
-	TenantToken string `protobuf:"bytes,5,opt,name=tenant_token,json=tenantToken,proto3" json:"tenant_token,omitempty"`
-	// The environment flag passed to the machina.
-	Environment string `protobuf:"bytes,6,opt,name=environment,proto3" json:"environment,omitempty"`
-	// The IP addresses of the machina.
-	IpAddresses []string `protobuf:"bytes,7,rep,name=ip_addresses,json=ipAddresses,proto3" json:"ip_addresses,omitempty"`
-	// is_library indicates that this agent is a library, monitoring its own
-	// process.
-	IsLibrary bool `protobuf:"varint,9,opt,name=is_library,json=isLibrary,proto3" json:"is_library,omitempty"`
-}
-
-func (x *MachinaInfoResponse) Reset() {
-	*x = MachinaInfoResponse{}
+	// The events, in the order in which they happened. The log is bounded,
+	// with separate bounds for the OP events and for the other events, so that
+	// the latter are recorded even once the operations fill their share;
+	// events past the bounds are counted in dropped_events.
+	Events        []*StackMachineTrace_Event `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	DroppedEvents uint64                     `protobuf:"varint,2,opt,name=dropped_events,json=droppedEvents,proto3" json:"dropped_events,omitempty"`
+	// The number of times every opcode was executed.
+	OpCounts map[uint32]uint64 `protobuf:"bytes,3,rep,name=op_counts,json=opCounts,proto3" json:"op_counts,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// The statistics of every program that ran, keyed by its entry pc.
+	Programs map[uint32]*StackMachineTrace_Program `protobuf:"bytes,4,rep,name=programs,proto3" json:"programs,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The statistics of every operation that ran, keyed by its pc. The time
+	// spent in the operations of a program adds up to about the duration of
+	// the program.
+	Ops map[uint32]*StackMachineTrace_Op `protobuf:"bytes,5,rep,name=ops,proto3" json:"ops,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *StackMachineTrace) Reset() {
+	*x = StackMachineTrace{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_machina_proto_msgTypes[12]
+		mi := &file_machina_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *MachinaInfoResponse) String() string {
+func (x *StackMachineTrace) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MachinaInfoResponse) ProtoMessage() {}
+func (*StackMachineTrace) ProtoMessage() {}
 
-func (x *MachinaInfoResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_machina_proto_msgTypes[12]
+func (x *StackMachineTrace) ProtoReflect() protoreflect.Message {
+	mi := &file_machina_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -934,54 +1227,243 @@ func (x *MachinaInfoResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MachinaInfoResponse.ProtoReflect.Descriptor instead.
-func (*MachinaInfoResponse) Descriptor() ([]byte, []int) {
-	return file_machina_proto_rawDescGZIP(), []int{12}
-}
-
-func (x *MachinaInfoResponse) GetFingerprint() string {
-	if x != nil {
-		return x.Fingerprint
-	}
-	return ""
+// Deprecated: Use StackMachineTrace.ProtoReflect.Descriptor instead.
+func (*StackMachineTrace) Descriptor() ([]byte, []int) {
+	return file_machina_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *MachinaInfoResponse) GetHostname() string {
+func (x *StackMachineTrace) GetEvents() []*StackMachineTrace_Event {
 	if x != nil {
-		return x.Hostname
+		return x.Events
 	}
-	return ""
+	return nil
 }
 
-func (x *MachinaInfoResponse) GetVersion() string {
+func (x *StackMachineTrace) GetDroppedEvents() uint64 {
 	if x != nil {
-		return x.Version
+		return x.DroppedEvents
 	}
-	return ""
+	return 0
 }
 
-func (x *MachinaInfoResponse) GetKernelVersion() string {
+func (x *StackMachineTrace) GetOpCounts() map[uint32]uint64 {
 	if x != nil {
-		return x.KernelVersion
+		return x.OpCounts
 	}
-	return ""
+	return nil
 }
 
-func (x *MachinaInfoResponse) GetTenantToken() string {
+func (x *StackMachineTrace) GetPrograms() map[uint32]*StackMachineTrace_Program {
 	if x != nil {
-		return x.TenantToken
+		return x.Programs
 	}
-	return ""
+	return nil
 }
 
-func (x *MachinaInfoResponse) GetEnvironment() string {
+func (x *StackMachineTrace) GetOps() map[uint32]*StackMachineTrace_Op {
 	if x != nil {
-		return x.Environment
+		return x.Ops
 	}
-	return ""
+	return nil
 }
 
-func (x *MachinaInfoResponse) GetIpAddresses() []string {
+type SkippedPointees struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The number of pointees that were skipped.
+	Count uint32 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	// The total size of the pointees that were skipped.
+	ByteLen uint64 `protobuf:"varint,2,opt,name=byte_len,json=byteLen,proto3" json:"byte_len,omitempty"`
+}
+
+func (x *SkippedPointees) Reset() {
+	*x = SkippedPointees{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_machina_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SkippedPointees) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SkippedPointees) ProtoMessage() {}
+
+func (x *SkippedPointees) ProtoReflect() protoreflect.Message {
+	mi := &file_machina_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SkippedPointees.ProtoReflect.Descriptor instead.
+func (*SkippedPointees) Descriptor() ([]byte, []int) {
+	return file_machina_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SkippedPointees) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *SkippedPointees) GetByteLen() uint64 {
+	if x != nil {
+		return x.ByteLen
+	}
+	return 0
+}
+
+type MachinaInfoRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *MachinaInfoRequest) Reset() {
+	*x = MachinaInfoRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_machina_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MachinaInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MachinaInfoRequest) ProtoMessage() {}
+
+func (x *MachinaInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_machina_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MachinaInfoRequest.ProtoReflect.Descriptor instead.
+func (*MachinaInfoRequest) Descriptor() ([]byte, []int) {
+	return file_machina_proto_rawDescGZIP(), []int{15}
+}
+
+type MachinaInfoResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// A unique identifier for this machina.
+	Fingerprint string `protobuf:"bytes,1,opt,name=fingerprint,proto3" json:"fingerprint,omitempty"`
+	// The hostname of the machina.
+	Hostname string `protobuf:"bytes,3,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	// The agent version.
+	Version string `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	// The version of the kernel in "{major}.{minor}.{patch}" format.
+	KernelVersion string `protobuf:"bytes,8,opt,name=kernel_version,json=kernelVersion,proto3" json:"kernel_version,omitempty"`
+	// The token of the tenant to which this machina belongs.
+	//
+	// TODO: Define any structure for this token. For now it's opaque and will
+	// correspond to the token used to scope requests to a tenant.
+	TenantToken string `protobuf:"bytes,5,opt,name=tenant_token,json=tenantToken,proto3" json:"tenant_token,omitempty"`
+	// The environment flag passed to the machina.
+	Environment string `protobuf:"bytes,6,opt,name=environment,proto3" json:"environment,omitempty"`
+	// The IP addresses of the machina.
+	IpAddresses []string `protobuf:"bytes,7,rep,name=ip_addresses,json=ipAddresses,proto3" json:"ip_addresses,omitempty"`
+	// is_library indicates that this agent is a library, monitoring its own
+	// process.
+	IsLibrary bool `protobuf:"varint,9,opt,name=is_library,json=isLibrary,proto3" json:"is_library,omitempty"`
+}
+
+func (x *MachinaInfoResponse) Reset() {
+	*x = MachinaInfoResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_machina_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MachinaInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MachinaInfoResponse) ProtoMessage() {}
+
+func (x *MachinaInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_machina_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MachinaInfoResponse.ProtoReflect.Descriptor instead.
+func (*MachinaInfoResponse) Descriptor() ([]byte, []int) {
+	return file_machina_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *MachinaInfoResponse) GetFingerprint() string {
+	if x != nil {
+		return x.Fingerprint
+	}
+	return ""
+}
+
+func (x *MachinaInfoResponse) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *MachinaInfoResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *MachinaInfoResponse) GetKernelVersion() string {
+	if x != nil {
+		return x.KernelVersion
+	}
+	return ""
+}
+
+func (x *MachinaInfoResponse) GetTenantToken() string {
+	if x != nil {
+		return x.TenantToken
+	}
+	return ""
+}
+
+func (x *MachinaInfoResponse) GetEnvironment() string {
+	if x != nil {
+		return x.Environment
+	}
+	return ""
+}
+
+func (x *MachinaInfoResponse) GetIpAddresses() []string {
 	if x != nil {
 		return x.IpAddresses
 	}
@@ -992,38 +1474,508 @@ func (x *MachinaInfoResponse) GetIsLibrary() bool {
 	if x != nil {
 		return x.IsLibrary
 	}
-	return false
+	return false
+}
+
+// Setup is the first message from the client in the Snapshot protocol.
+type SnapshotRequest_Setup struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The key associated with the artifacts needed for this snapshot.
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// The fingerprint of the process to snapshot.
+	ProcessFingerprint string `protobuf:"bytes,2,opt,name=process_fingerprint,json=processFingerprint,proto3" json:"process_fingerprint,omitempty"`
+}
+
+func (x *SnapshotRequest_Setup) Reset() {
+	*x = SnapshotRequest_Setup{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_machina_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SnapshotRequest_Setup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotRequest_Setup) ProtoMessage() {}
+
+func (x *SnapshotRequest_Setup) ProtoReflect() protoreflect.Message {
+	mi := &file_machina_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotRequest_Setup.ProtoReflect.Descriptor instead.
+func (*SnapshotRequest_Setup) Descriptor() ([]byte, []int) {
+	return file_machina_proto_rawDescGZIP(), []int{3, 0}
+}
+
+func (x *SnapshotRequest_Setup) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SnapshotRequest_Setup) GetProcessFingerprint() string {
+	if x != nil {
+		return x.ProcessFingerprint
+	}
+	return ""
+}
+
+// Snapshot is the second message from the client in the Snapshot protocol.
+// It trggers the snapshot process.
+type SnapshotRequest_Snapshot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// If set, only the goroutines matching the filter are captured.
+	GoroutineFilter *GoroutineFilter `protobuf:"bytes,1,opt,name=goroutine_filter,json=goroutineFilter,proto3" json:"goroutine_filter,omitempty"`
+	// If set, bounds the time for which the process is paused. The snapshot
+	// is marked as partial if the capture is cut short. Overrides the
+	// process's configured maximum pause.
+	MaxPauseNs *uint64 `protobuf:"varint,2,opt,name=max_pause_ns,json=maxPauseNs,proto3,oneof" json:"max_pause_ns,omitempty"`
+	// If set, bounds the part of the pause spent capturing goroutine stacks.
+	// Defaults to half of the maximum pause.
+	MaxStackPauseNs *uint64 `protobuf:"varint,3,opt,name=max_stack_pause_ns,json=maxStackPauseNs,proto3,oneof" json:"max_stack_pause_ns,omitempty"`
+	// If set, the execution of the stack machine is traced, and the trace is
+	// returned in SnapshotResponse.trace.
+	Trace bool `protobuf:"varint,4,opt,name=trace,proto3" json:"trace,omitempty"`
+	// The newest layout of the snapshot data that the client can decode. The
+	// agent writes the newest version it supports that is not newer, and
+	// reports it in SnapshotResponse.framing_version. Version 0, the
+	// default, is the layout of framing.h. Clients that request version 1 or
+	// newer must also reassemble snapshots that are split across several
+	// SnapshotResponse messages.
+	FramingVersion uint32 `protobuf:"varint,5,opt,name=framing_version,json=framingVersion,proto3" json:"framing_version,omitempty"`
+}
+
+func (x *SnapshotRequest_Snapshot) Reset() {
+	*x = SnapshotRequest_Snapshot{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_machina_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SnapshotRequest_Snapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotRequest_Snapshot) ProtoMessage() {}
+
+func (x *SnapshotRequest_Snapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_machina_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotRequest_Snapshot.ProtoReflect.Descriptor instead.
+func (*SnapshotRequest_Snapshot) Descriptor() ([]byte, []int) {
+	return file_machina_proto_rawDescGZIP(), []int{3, 1}
+}
+
+func (x *SnapshotRequest_Snapshot) GetGoroutineFilter() *GoroutineFilter {
+	if x != nil {
+		return x.GoroutineFilter
+	}
+	return nil
+}
+
+func (x *SnapshotRequest_Snapshot) GetMaxPauseNs() uint64 {
+	if x != nil && x.MaxPauseNs != nil {
+		return *x.MaxPauseNs
+	}
+	return 0
+}
+
+func (x *SnapshotRequest_Snapshot) GetMaxStackPauseNs() uint64 {
+	if x != nil && x.MaxStackPauseNs != nil {
+		return *x.MaxStackPauseNs
+	}
+	return 0
+}
+
+func (x *SnapshotRequest_Snapshot) GetTrace() bool {
+	if x != nil {
+		return x.Trace
+	}
+	return false
+}
+
+func (x *SnapshotRequest_Snapshot) GetFramingVersion() uint32 {
+	if x != nil {
+		return x.FramingVersion
+	}
+	return 0
+}
+
+// PcRange is a range of program counters [start, end), expressed as
+// virtual addresses in the object file (i.e. before ASLR is applied).
+type GoroutineFilter_PcRange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Start uint64 `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	End   uint64 `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
+}
+
+func (x *GoroutineFilter_PcRange) Reset() {
+	*x = GoroutineFilter_PcRange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_machina_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GoroutineFilter_PcRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GoroutineFilter_PcRange) ProtoMessage() {}
+
+func (x *GoroutineFilter_PcRange) ProtoReflect() protoreflect.Message {
+	mi := &file_machina_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GoroutineFilter_PcRange.ProtoReflect.Descriptor instead.
+func (*GoroutineFilter_PcRange) Descriptor() ([]byte, []int) {
+	return file_machina_proto_rawDescGZIP(), []int{4, 0}
+}
+
+func (x *GoroutineFilter_PcRange) GetStart() uint64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *GoroutineFilter_PcRange) GetEnd() uint64 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+// LabelMatch matches goroutines with the given pprof label. If value is
+// empty, any goroutine with the key matches.
+type GoroutineFilter_LabelMatch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key   string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *GoroutineFilter_LabelMatch) Reset() {
+	*x = GoroutineFilter_LabelMatch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_machina_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GoroutineFilter_LabelMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GoroutineFilter_LabelMatch) ProtoMessage() {}
+
+func (x *GoroutineFilter_LabelMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_machina_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GoroutineFilter_LabelMatch.ProtoReflect.Descriptor instead.
+func (*GoroutineFilter_LabelMatch) Descriptor() ([]byte, []int) {
+	return file_machina_proto_rawDescGZIP(), []int{4, 1}
+}
+
+func (x *GoroutineFilter_LabelMatch) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *GoroutineFilter_LabelMatch) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type EventsRequest_Setup struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The key associated with the artifacts needed for this snapshot.
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// The fingerprint of the process to probe.
+	ProcessFingerprint string `protobuf:"bytes,2,opt,name=process_fingerprint,json=processFingerprint,proto3" json:"process_fingerprint,omitempty"`
+}
+
+func (x *EventsRequest_Setup) Reset() {
+	*x = EventsRequest_Setup{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_machina_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EventsRequest_Setup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventsRequest_Setup) ProtoMessage() {}
+
+func (x *EventsRequest_Setup) ProtoReflect() protoreflect.Message {
+	mi := &file_machina_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventsRequest_Setup.ProtoReflect.Descriptor instead.
+func (*EventsRequest_Setup) Descriptor() ([]byte, []int) {
+	return file_machina_proto_rawDescGZIP(), []int{5, 0}
+}
+
+func (x *EventsRequest_Setup) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *EventsRequest_Setup) GetProcessFingerprint() string {
+	if x != nil {
+		return x.ProcessFingerprint
+	}
+	return ""
+}
+
+type EventsRequest_Stream struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The number of events to stream. 0 means no limit.
+	MaxCount uint32 `protobuf:"varint,1,opt,name=max_count,json=maxCount,proto3" json:"max_count,omitempty"`
+	// The duration to stream events for. This is used as an upper bound on the
+	// duration to run the probe. In the common case, the client should send a
+	// finish message to terminate the probe before this duration elapses. This
+	// value is used to defend against unresponsive clients.
+	DurationNs *uint64 `protobuf:"varint,2,opt,name=duration_ns,json=durationNs,proto3,oneof" json:"duration_ns,omitempty"`
+}
+
+func (x *EventsRequest_Stream) Reset() {
+	*x = EventsRequest_Stream{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_machina_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EventsRequest_Stream) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventsRequest_Stream) ProtoMessage() {}
+
+func (x *EventsRequest_Stream) ProtoReflect() protoreflect.Message {
+	mi := &file_machina_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventsRequest_Stream.ProtoReflect.Descriptor instead.
+func (*EventsRequest_Stream) Descriptor() ([]byte, []int) {
+	return file_machina_proto_rawDescGZIP(), []int{5, 1}
+}
+
+func (x *EventsRequest_Stream) GetMaxCount() uint32 {
+	if x != nil {
+		return x.MaxCount
+	}
+	return 0
+}
+
+func (x *EventsRequest_Stream) GetDurationNs() uint64 {
+	if x != nil && x.DurationNs != nil {
+		return *x.DurationNs
+	}
+	return 0
+}
+
+type EventsRequest_Finish struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *EventsRequest_Finish) Reset() {
+	*x = EventsRequest_Finish{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_machina_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EventsRequest_Finish) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventsRequest_Finish) ProtoMessage() {}
+
+func (x *EventsRequest_Finish) ProtoReflect() protoreflect.Message {
+	mi := &file_machina_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventsRequest_Finish.ProtoReflect.Descriptor instead.
+func (*EventsRequest_Finish) Descriptor() ([]byte, []int) {
+	return file_machina_proto_rawDescGZIP(), []int{5, 2}
+}
+
+type EventsResponse_Event struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *EventsResponse_Event) Reset() {
+	*x = EventsResponse_Event{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_machina_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EventsResponse_Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventsResponse_Event) ProtoMessage() {}
+
+func (x *EventsResponse_Event) ProtoReflect() protoreflect.Message {
+	mi := &file_machina_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventsResponse_Event.ProtoReflect.Descriptor instead.
+func (*EventsResponse_Event) Descriptor() ([]byte, []int) {
+	return file_machina_proto_rawDescGZIP(), []int{10, 0}
+}
+
+func (x *EventsResponse_Event) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
 }
 
-// Setup is the first message from the client in the Snapshot protocol.
-type SnapshotRequest_Setup struct {
+// ApproximateBootTime is sent after the probes have been loaded.
+// It is needed because bpf only has access to CLOCK_MONOTONIC time
+// (at least until version 6.1 when bpf_ktime_get_tai_ns was introduced).
+// This value is used to adjust the timestamp in the event to a wall clock
+// timestamp. It will be approximate, but should be close enough.
+type EventsResponse_ApproximateBootTime struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The key associated with the artifacts needed for this snapshot.
-	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	// The fingerprint of the process to snapshot.
-	ProcessFingerprint string `protobuf:"bytes,2,opt,name=process_fingerprint,json=processFingerprint,proto3" json:"process_fingerprint,omitempty"`
+	ApproximateBootTimeNs uint64 `protobuf:"varint,1,opt,name=approximate_boot_time_ns,json=approximateBootTimeNs,proto3" json:"approximate_boot_time_ns,omitempty"`
 }
 
-func (x *SnapshotRequest_Setup) Reset() {
-	*x = SnapshotRequest_Setup{}
+func (x *EventsResponse_ApproximateBootTime) Reset() {
+	*x = EventsResponse_ApproximateBootTime{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_machina_proto_msgTypes[13]
+		mi := &file_machina_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SnapshotRequest_Setup) String() string {
+func (x *EventsResponse_ApproximateBootTime) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SnapshotRequest_Setup) ProtoMessage() {}
+func (*EventsResponse_ApproximateBootTime) ProtoMessage() {}
 
-func (x *SnapshotRequest_Setup) ProtoReflect() protoreflect.Message {
-	mi := &file_machina_proto_msgTypes[13]
+func (x *EventsResponse_ApproximateBootTime) ProtoReflect() protoreflect.Message {
+	mi := &file_machina_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1034,50 +1986,44 @@ func (x *SnapshotRequest_Setup) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SnapshotRequest_Setup.ProtoReflect.Descriptor instead.
-func (*SnapshotRequest_Setup) Descriptor() ([]byte, []int) {
-	return file_machina_proto_rawDescGZIP(), []int{3, 0}
-}
-
-func (x *SnapshotRequest_Setup) GetKey() string {
-	if x != nil {
-		return x.Key
-	}
-	return ""
+// Deprecated: Use EventsResponse_ApproximateBootTime.ProtoReflect.Descriptor instead.
+func (*EventsResponse_ApproximateBootTime) Descriptor() ([]byte, []int) {
+	return file_machina_proto_rawDescGZIP(), []int{10, 1}
 }
 
-func (x *SnapshotRequest_Setup) GetProcessFingerprint() string {
+func (x *EventsResponse_ApproximateBootTime) GetApproximateBootTimeNs() uint64 {
 	if x != nil {
-		return x.ProcessFingerprint
+		return x.ApproximateBootTimeNs
 	}
-	return ""
+	return 0
 }
 
-// Snapshot is the second message from the client in the Snapshot protocol.
-// It trggers the snapshot process.
-type SnapshotRequest_Snapshot struct {
+type EventsResponse_Attached struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	// A timestamp that precedes the first event.
+	AttachedTimestampMonotonicNs uint64 `protobuf:"varint,1,opt,name=attached_timestamp_monotonic_ns,json=attachedTimestampMonotonicNs,proto3" json:"attached_timestamp_monotonic_ns,omitempty"`
 }
 
-func (x *SnapshotRequest_Snapshot) Reset() {
-	*x = SnapshotRequest_Snapshot{}
+func (x *EventsResponse_Attached) Reset() {
+	*x = EventsResponse_Attached{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_machina_proto_msgTypes[14]
+		mi := &file_machina_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SnapshotRequest_Snapshot) String() string {
+func (x *EventsResponse_Attached) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SnapshotRequest_Snapshot) ProtoMessage() {}
+func (*EventsResponse_Attached) ProtoMessage() {}
 
-func (x *SnapshotRequest_Snapshot) ProtoReflect() protoreflect.Message {
-	mi := &file_machina_proto_msgTypes[14]
+func (x *EventsResponse_Attached) ProtoReflect() protoreflect.Message {
+	mi := &file_machina_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1088,39 +2034,44 @@ func (x *SnapshotRequest_Snapshot) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SnapshotRequest_Snapshot.ProtoReflect.Descriptor instead.
-func (*SnapshotRequest_Snapshot) Descriptor() ([]byte, []int) {
-	return file_machina_proto_rawDescGZIP(), []int{3, 1}
+// Deprecated: Use EventsResponse_Attached.ProtoReflect.Descriptor instead.
+func (*EventsResponse_Attached) Descriptor() ([]byte, []int) {
+	return file_machina_proto_rawDescGZIP(), []int{10, 2}
 }
 
-type EventsRequest_Setup struct {
+func (x *EventsResponse_Attached) GetAttachedTimestampMonotonicNs() uint64 {
+	if x != nil {
+		return x.AttachedTimestampMonotonicNs
+	}
+	return 0
+}
+
+type EventsResponse_SummaryStatistics struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The key associated with the artifacts needed for this snapshot.
-	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	// The fingerprint of the process to probe.
-	ProcessFingerprint string `protobuf:"bytes,2,opt,name=process_fingerprint,json=processFingerprint,proto3" json:"process_fingerprint,omitempty"`
+	EventsDroppedInEbpf  uint32 `protobuf:"varint,2,opt,name=events_dropped_in_ebpf,json=eventsDroppedInEbpf,proto3" json:"events_dropped_in_ebpf,omitempty"`
+	EventsRejectedInEbpf uint32 `protobuf:"varint,3,opt,name=events_rejected_in_ebpf,json=eventsRejectedInEbpf,proto3" json:"events_rejected_in_ebpf,omitempty"`
 }
 
-func (x *EventsRequest_Setup) Reset() {
-	*x = EventsRequest_Setup{}
+func (x *EventsResponse_SummaryStatistics) Reset() {
+	*x = EventsResponse_SummaryStatistics{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_machina_proto_msgTypes[15]
+		mi := &file_machina_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *EventsRequest_Setup) String() string {
+func (x *EventsResponse_SummaryStatistics) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EventsRequest_Setup) ProtoMessage() {}
+func (*EventsResponse_SummaryStatistics) ProtoMessage() {}
 
-func (x *EventsRequest_Setup) ProtoReflect() protoreflect.Message {
-	mi := &file_machina_proto_msgTypes[15]
+func (x *EventsResponse_SummaryStatistics) ProtoReflect() protoreflect.Message {
+	mi := &file_machina_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1131,56 +2082,52 @@ func (x *EventsRequest_Setup) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EventsRequest_Setup.ProtoReflect.Descriptor instead.
-func (*EventsRequest_Setup) Descriptor() ([]byte, []int) {
-	return file_machina_proto_rawDescGZIP(), []int{4, 0}
+// Deprecated: Use EventsResponse_SummaryStatistics.ProtoReflect.Descriptor instead.
+func (*EventsResponse_SummaryStatistics) Descriptor() ([]byte, []int) {
+	return file_machina_proto_rawDescGZIP(), []int{10, 3}
 }
 
-func (x *EventsRequest_Setup) GetKey() string {
+func (x *EventsResponse_SummaryStatistics) GetEventsDroppedInEbpf() uint32 {
 	if x != nil {
-		return x.Key
+		return x.EventsDroppedInEbpf
 	}
-	return ""
+	return 0
 }
 
-func (x *EventsRequest_Setup) GetProcessFingerprint() string {
+func (x *EventsResponse_SummaryStatistics) GetEventsRejectedInEbpf() uint32 {
 	if x != nil {
-		return x.ProcessFingerprint
+		return x.EventsRejectedInEbpf
 	}
-	return ""
+	return 0
 }
 
-type EventsRequest_Stream struct {
+type EventsResponse_Detached struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The number of events to stream. 0 means no limit.
-	MaxCount uint32 `protobuf:"varint,1,opt,name=max_count,json=maxCount,proto3" json:"max_count,omitempty"`
-	// The duration to stream events for. This is used as an upper bound on the
-	// duration to run the probe. In the common case, the client should send a
-	// finish message to terminate the probe before this duration elapses. This
-	// value is used to defend against unresponsive clients.
-	DurationNs *uint64 `protobuf:"varint,2,opt,name=duration_ns,json=durationNs,proto3,oneof" json:"duration_ns,omitempty"`
+	SummaryStatistics *EventsResponse_SummaryStatistics `protobuf:"bytes,1,opt,name=summary_statistics,json=summaryStatistics,proto3" json:"summary_statistics,omitempty"`
+	// The timestamp of the detached event.
+	DetachedTimestampMonotonicNs uint64 `protobuf:"varint,2,opt,name=detached_timestamp_monotonic_ns,json=detachedTimestampMonotonicNs,proto3" json:"detached_timestamp_monotonic_ns,omitempty"`
 }
 
-func (x *EventsRequest_Stream) Reset() {
-	*x = EventsRequest_Stream{}
+func (x *EventsResponse_Detached) Reset() {
+	*x = EventsResponse_Detached{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_machina_proto_msgTypes[16]
+		mi := &file_machina_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *EventsRequest_Stream) String() string {
+func (x *EventsResponse_Detached) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EventsRequest_Stream) ProtoMessage() {}
+func (*EventsResponse_Detached) ProtoMessage() {}
 
-func (x *EventsRequest_Stream) ProtoReflect() protoreflect.Message {
-	mi := &file_machina_proto_msgTypes[16]
+func (x *EventsResponse_Detached) ProtoReflect() protoreflect.Message {
+	mi := &file_machina_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1191,48 +2138,53 @@ func (x *EventsRequest_Stream) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EventsRequest_Stream.ProtoReflect.Descriptor instead.
-func (*EventsRequest_Stream) Descriptor() ([]byte, []int) {
-	return file_machina_proto_rawDescGZIP(), []int{4, 1}
+// Deprecated: Use EventsResponse_Detached.ProtoReflect.Descriptor instead.
+func (*EventsResponse_Detached) Descriptor() ([]byte, []int) {
+	return file_machina_proto_rawDescGZIP(), []int{10, 4}
 }
 
-func (x *EventsRequest_Stream) GetMaxCount() uint32 {
+func (x *EventsResponse_Detached) GetSummaryStatistics() *EventsResponse_SummaryStatistics {
 	if x != nil {
-		return x.MaxCount
+		return x.SummaryStatistics
 	}
-	return 0
+	return nil
 }
 
-func (x *EventsRequest_Stream) GetDurationNs() uint64 {
-	if x != nil && x.DurationNs != nil {
-		return *x.DurationNs
+func (x *EventsResponse_Detached) GetDetachedTimestampMonotonicNs() uint64 {
+	if x != nil {
+		return x.DetachedTimestampMonotonicNs
 	}
 	return 0
 }
 
-type EventsRequest_Finish struct {
+type RuntimeStats_Float64Histogram struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	// The counts of the buckets. There is one less count than boundaries.
+	Counts []uint64 `protobuf:"varint,1,rep,packed,name=counts,proto3" json:"counts,omitempty"`
+	// The boundaries of the buckets, which may be infinite.
+	Buckets []float64 `protobuf:"fixed64,2,rep,packed,name=buckets,proto3" json:"buckets,omitempty"`
 }
 
-func (x *EventsRequest_Finish) Reset() {
-	*x = EventsRequest_Finish{}
+func (x *RuntimeStats_Float64Histogram) Reset() {
+	*x = RuntimeStats_Float64Histogram{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_machina_proto_msgTypes[17]
+		mi := &file_machina_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *EventsRequest_Finish) String() string {
+func (x *RuntimeStats_Float64Histogram) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EventsRequest_Finish) ProtoMessage() {}
+func (*RuntimeStats_Float64Histogram) ProtoMessage() {}
 
-func (x *EventsRequest_Finish) ProtoReflect() protoreflect.Message {
-	mi := &file_machina_proto_msgTypes[17]
+func (x *RuntimeStats_Float64Histogram) ProtoReflect() protoreflect.Message {
+	mi := &file_machina_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1243,36 +2195,58 @@ func (x *EventsRequest_Finish) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EventsRequest_Finish.ProtoReflect.Descriptor instead.
-func (*EventsRequest_Finish) Descriptor() ([]byte, []int) {
-	return file_machina_proto_rawDescGZIP(), []int{4, 2}
+// Deprecated: Use RuntimeStats_Float64Histogram.ProtoReflect.Descriptor instead.
+func (*RuntimeStats_Float64Histogram) Descriptor() ([]byte, []int) {
+	return file_machina_proto_rawDescGZIP(), []int{12, 0}
 }
 
-type EventsResponse_Event struct {
+func (x *RuntimeStats_Float64Histogram) GetCounts() []uint64 {
+	if x != nil {
+		return x.Counts
+	}
+	return nil
+}
+
+func (x *RuntimeStats_Float64Histogram) GetBuckets() []float64 {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+type RuntimeStats_Metric struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	// The name of the metric, as in runtime/metrics, e.g.
+	// "/sched/goroutines:goroutines".
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Types that are assignable to Value:
+	//
+	//	*RuntimeStats_Metric_Uint64Value
+	//	*RuntimeStats_Metric_Float64Value
+	//	*RuntimeStats_Metric_Float64Histogram
+	Value isRuntimeStats_Metric_Value `protobuf_oneof:"value"`
 }
 
-func (x *EventsResponse_Event) Reset() {
-	*x = EventsResponse_Event{}
+func (x *RuntimeStats_Metric) Reset() {
+	*x = RuntimeStats_Metric{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_machina_proto_msgTypes[18]
+		mi := &file_machina_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *EventsResponse_Event) String() string {
+func (x *RuntimeStats_Metric) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EventsResponse_Event) ProtoMessage() {}
+func (*RuntimeStats_Metric) ProtoMessage() {}
 
-func (x *EventsResponse_Event) ProtoReflect() protoreflect.Message {
-	mi := &file_machina_proto_msgTypes[18]
+func (x *RuntimeStats_Metric) ProtoReflect() protoreflect.Message {
+	mi := &file_machina_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1283,48 +2257,103 @@ func (x *EventsResponse_Event) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EventsResponse_Event.ProtoReflect.Descriptor instead.
-func (*EventsResponse_Event) Descriptor() ([]byte, []int) {
-	return file_machina_proto_rawDescGZIP(), []int{9, 0}
+// Deprecated: Use RuntimeStats_Metric.ProtoReflect.Descriptor instead.
+func (*RuntimeStats_Metric) Descriptor() ([]byte, []int) {
+	return file_machina_proto_rawDescGZIP(), []int{12, 1}
 }
 
-func (x *EventsResponse_Event) GetData() []byte {
+func (x *RuntimeStats_Metric) GetName() string {
 	if x != nil {
-		return x.Data
+		return x.Name
+	}
+	return ""
+}
+
+func (m *RuntimeStats_Metric) GetValue() isRuntimeStats_Metric_Value {
+	if m != nil {
+		return m.Value
 	}
 	return nil
 }
 
-// ApproximateBootTime is sent after the probes have been loaded.
-// It is needed because bpf only has access to CLOCK_MONOTONIC time
-// (at least until version 6.1 when bpf_ktime_get_tai_ns was introduced).
-// This value is used to adjust the timestamp in the event to a wall clock
-// timestamp. It will be approximate, but should be close enough.
-type EventsResponse_ApproximateBootTime struct {
+func (x *RuntimeStats_Metric) GetUint64Value() uint64 {
+	if x, ok := x.GetValue().(*RuntimeStats_Metric_Uint64Value); ok {
+		return x.Uint64Value
+	}
+	return 0
+}
+
+func (x *RuntimeStats_Metric) GetFloat64Value() float64 {
+	if x, ok := x.GetValue().(*RuntimeStats_Metric_Float64Value); ok {
+		return x.Float64Value
+	}
+	return 0
+}
+
+func (x *RuntimeStats_Metric) GetFloat64Histogram() *RuntimeStats_Float64Histogram {
+	if x, ok := x.GetValue().(*RuntimeStats_Metric_Float64Histogram); ok {
+		return x.Float64Histogram
+	}
+	return nil
+}
+
+type isRuntimeStats_Metric_Value interface {
+	isRuntimeStats_Metric_Value()
+}
+
+type RuntimeStats_Metric_Uint64Value struct {
+	Uint64Value uint64 `protobuf:"varint,2,opt,name=uint64_value,json=uint64Value,proto3,oneof"`
+}
+
+type RuntimeStats_Metric_Float64Value struct {
+	Float64Value float64 `protobuf:"fixed64,3,opt,name=float64_value,json=float64Value,proto3,oneof"`
+}
+
+type RuntimeStats_Metric_Float64Histogram struct {
+	Float64Histogram *RuntimeStats_Float64Histogram `protobuf:"bytes,4,opt,name=float64_histogram,json=float64Histogram,proto3,oneof"`
+}
+
+func (*RuntimeStats_Metric_Uint64Value) isRuntimeStats_Metric_Value() {}
+
+func (*RuntimeStats_Metric_Float64Value) isRuntimeStats_Metric_Value() {}
+
+func (*RuntimeStats_Metric_Float64Histogram) isRuntimeStats_Metric_Value() {}
+
+// Statistics of the garbage collector from runtime.memstats, read with the
+// world stopped. They are zero if the runtime config does not provide the
+// offsets of the fields.
+type RuntimeStats_MemStats struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ApproximateBootTimeNs uint64 `protobuf:"varint,1,opt,name=approximate_boot_time_ns,json=approximateBootTimeNs,proto3" json:"approximate_boot_time_ns,omitempty"`
+	// The time of the last garbage collection, in nanoseconds since the
+	// epoch and on the runtime's monotonic clock respectively.
+	LastGcUnixNs   uint64  `protobuf:"varint,1,opt,name=last_gc_unix_ns,json=lastGcUnixNs,proto3" json:"last_gc_unix_ns,omitempty"`
+	LastGcNanotime uint64  `protobuf:"varint,2,opt,name=last_gc_nanotime,json=lastGcNanotime,proto3" json:"last_gc_nanotime,omitempty"`
+	PauseTotalNs   uint64  `protobuf:"varint,3,opt,name=pause_total_ns,json=pauseTotalNs,proto3" json:"pause_total_ns,omitempty"`
+	NumGc          uint32  `protobuf:"varint,4,opt,name=num_gc,json=numGc,proto3" json:"num_gc,omitempty"`
+	NumForcedGc    uint32  `protobuf:"varint,5,opt,name=num_forced_gc,json=numForcedGc,proto3" json:"num_forced_gc,omitempty"`
+	GcCpuFraction  float64 `protobuf:"fixed64,6,opt,name=gc_cpu_fraction,json=gcCpuFraction,proto3" json:"gc_cpu_fraction,omitempty"`
 }
 
-func (x *EventsResponse_ApproximateBootTime) Reset() {
-	*x = EventsResponse_ApproximateBootTime{}
+func (x *RuntimeStats_MemStats) Reset() {
+	*x = RuntimeStats_MemStats{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_machina_proto_msgTypes[19]
+		mi := &file_machina_proto_msgTypes[32]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *EventsResponse_ApproximateBootTime) String() string {
+func (x *RuntimeStats_MemStats) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EventsResponse_ApproximateBootTime) ProtoMessage() {}
+func (*RuntimeStats_MemStats) ProtoMessage() {}
 
-func (x *EventsResponse_ApproximateBootTime) ProtoReflect() protoreflect.Message {
-	mi := &file_machina_proto_msgTypes[19]
+func (x *RuntimeStats_MemStats) ProtoReflect() protoreflect.Message {
+	mi := &file_machina_proto_msgTypes[32]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1335,44 +2364,88 @@ func (x *EventsResponse_ApproximateBootTime) ProtoReflect() protoreflect.Message
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EventsResponse_ApproximateBootTime.ProtoReflect.Descriptor instead.
-func (*EventsResponse_ApproximateBootTime) Descriptor() ([]byte, []int) {
-	return file_machina_proto_rawDescGZIP(), []int{9, 1}
+// Deprecated: Use RuntimeStats_MemStats.ProtoReflect.Descriptor instead.
+func (*RuntimeStats_MemStats) Descriptor() ([]byte, []int) {
+	return file_machina_proto_rawDescGZIP(), []int{12, 2}
+}
+
+func (x *RuntimeStats_MemStats) GetLastGcUnixNs() uint64 {
+	if x != nil {
+		return x.LastGcUnixNs
+	}
+	return 0
+}
+
+func (x *RuntimeStats_MemStats) GetLastGcNanotime() uint64 {
+	if x != nil {
+		return x.LastGcNanotime
+	}
+	return 0
+}
+
+func (x *RuntimeStats_MemStats) GetPauseTotalNs() uint64 {
+	if x != nil {
+		return x.PauseTotalNs
+	}
+	return 0
+}
+
+func (x *RuntimeStats_MemStats) GetNumGc() uint32 {
+	if x != nil {
+		return x.NumGc
+	}
+	return 0
+}
+
+func (x *RuntimeStats_MemStats) GetNumForcedGc() uint32 {
+	if x != nil {
+		return x.NumForcedGc
+	}
+	return 0
 }
 
-func (x *EventsResponse_ApproximateBootTime) GetApproximateBootTimeNs() uint64 {
+func (x *RuntimeStats_MemStats) GetGcCpuFraction() float64 {
 	if x != nil {
-		return x.ApproximateBootTimeNs
+		return x.GcCpuFraction
 	}
 	return 0
 }
 
-type EventsResponse_Attached struct {
+type StackMachineTrace_Event struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// A timestamp that precedes the first event.
-	AttachedTimestampMonotonicNs uint64 `protobuf:"varint,1,opt,name=attached_timestamp_monotonic_ns,json=attachedTimestampMonotonicNs,proto3" json:"attached_timestamp_monotonic_ns,omitempty"`
-}
-
-func (x *EventsResponse_Attached) Reset() {
-	*x = EventsResponse_Attached{}
+	Kind StackMachineTrace_Event_Kind `protobuf:"varint,1,opt,name=kind,proto3,enum=machina.StackMachineTrace_Event_Kind" json:"kind,omitempty"`
+	// The entry pc of the program, and the pc and the opcode of the operation
+	// that was executing. They are 0 for events outside of the execution of a
+	// program, such as the failed dereference of a pointee that is serialized
+	// before its program runs.
+	ProgPc  uint32 `protobuf:"varint,2,opt,name=prog_pc,json=progPc,proto3" json:"prog_pc,omitempty"`
+	Pc      uint32 `protobuf:"varint,3,opt,name=pc,proto3" json:"pc,omitempty"`
+	OpCode  uint32 `protobuf:"varint,4,opt,name=op_code,json=opCode,proto3" json:"op_code,omitempty"`
+	Addr    uint64 `protobuf:"varint,5,opt,name=addr,proto3" json:"addr,omitempty"`
+	Type    uint32 `protobuf:"varint,6,opt,name=type,proto3" json:"type,omitempty"`
+	ByteLen uint32 `protobuf:"varint,7,opt,name=byte_len,json=byteLen,proto3" json:"byte_len,omitempty"`
+}
+
+func (x *StackMachineTrace_Event) Reset() {
+	*x = StackMachineTrace_Event{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_machina_proto_msgTypes[20]
+		mi := &file_machina_proto_msgTypes[33]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *EventsResponse_Attached) String() string {
+func (x *StackMachineTrace_Event) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EventsResponse_Attached) ProtoMessage() {}
+func (*StackMachineTrace_Event) ProtoMessage() {}
 
-func (x *EventsResponse_Attached) ProtoReflect() protoreflect.Message {
-	mi := &file_machina_proto_msgTypes[20]
+func (x *StackMachineTrace_Event) ProtoReflect() protoreflect.Message {
+	mi := &file_machina_proto_msgTypes[33]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1383,44 +2456,90 @@ func (x *EventsResponse_Attached) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EventsResponse_Attached.ProtoReflect.Descriptor instead.
-func (*EventsResponse_Attached) Descriptor() ([]byte, []int) {
-	return file_machina_proto_rawDescGZIP(), []int{9, 2}
+// Deprecated: Use StackMachineTrace_Event.ProtoReflect.Descriptor instead.
+func (*StackMachineTrace_Event) Descriptor() ([]byte, []int) {
+	return file_machina_proto_rawDescGZIP(), []int{13, 0}
 }
 
-func (x *EventsResponse_Attached) GetAttachedTimestampMonotonicNs() uint64 {
+func (x *StackMachineTrace_Event) GetKind() StackMachineTrace_Event_Kind {
 	if x != nil {
-		return x.AttachedTimestampMonotonicNs
+		return x.Kind
+	}
+	return StackMachineTrace_Event_UNKNOWN
+}
+
+func (x *StackMachineTrace_Event) GetProgPc() uint32 {
+	if x != nil {
+		return x.ProgPc
 	}
 	return 0
 }
 
-type EventsResponse_SummaryStatistics struct {
+func (x *StackMachineTrace_Event) GetPc() uint32 {
+	if x != nil {
+		return x.Pc
+	}
+	return 0
+}
+
+func (x *StackMachineTrace_Event) GetOpCode() uint32 {
+	if x != nil {
+		return x.OpCode
+	}
+	return 0
+}
+
+func (x *StackMachineTrace_Event) GetAddr() uint64 {
+	if x != nil {
+		return x.Addr
+	}
+	return 0
+}
+
+func (x *StackMachineTrace_Event) GetType() uint32 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *StackMachineTrace_Event) GetByteLen() uint32 {
+	if x != nil {
+		return x.ByteLen
+	}
+	return 0
+}
+
+type StackMachineTrace_Program struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	EventsDroppedInEbpf  uint32 `protobuf:"varint,2,opt,name=events_dropped_in_ebpf,json=eventsDroppedInEbpf,proto3" json:"events_dropped_in_ebpf,omitempty"`
-	EventsRejectedInEbpf uint32 `protobuf:"varint,3,opt,name=events_rejected_in_ebpf,json=eventsRejectedInEbpf,proto3" json:"events_rejected_in_ebpf,omitempty"`
+	// The number of times the program ran.
+	Runs uint64 `protobuf:"varint,1,opt,name=runs,proto3" json:"runs,omitempty"`
+	// The number of runs that failed.
+	Failures uint64 `protobuf:"varint,2,opt,name=failures,proto3" json:"failures,omitempty"`
+	// The total time spent running the program.
+	DurationNs uint64 `protobuf:"varint,3,opt,name=duration_ns,json=durationNs,proto3" json:"duration_ns,omitempty"`
 }
 
-func (x *EventsResponse_SummaryStatistics) Reset() {
-	*x = EventsResponse_SummaryStatistics{}
+func (x *StackMachineTrace_Program) Reset() {
+	*x = StackMachineTrace_Program{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_machina_proto_msgTypes[21]
+		mi := &file_machina_proto_msgTypes[35]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *EventsResponse_SummaryStatistics) String() string {
+func (x *StackMachineTrace_Program) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EventsResponse_SummaryStatistics) ProtoMessage() {}
+func (*StackMachineTrace_Program) ProtoMessage() {}
 
-func (x *EventsResponse_SummaryStatistics) ProtoReflect() protoreflect.Message {
-	mi := &file_machina_proto_msgTypes[21]
+func (x *StackMachineTrace_Program) ProtoReflect() protoreflect.Message {
+	mi := &file_machina_proto_msgTypes[35]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1431,52 +2550,65 @@ func (x *EventsResponse_SummaryStatistics) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EventsResponse_SummaryStatistics.ProtoReflect.Descriptor instead.
-func (*EventsResponse_SummaryStatistics) Descriptor() ([]byte, []int) {
-	return file_machina_proto_rawDescGZIP(), []int{9, 3}
+// Deprecated: Use StackMachineTrace_Program.ProtoReflect.Descriptor instead.
+func (*StackMachineTrace_Program) Descriptor() ([]byte, []int) {
+	return file_machina_proto_rawDescGZIP(), []int{13, 2}
 }
 
-func (x *EventsResponse_SummaryStatistics) GetEventsDroppedInEbpf() uint32 {
+func (x *StackMachineTrace_Program) GetRuns() uint64 {
 	if x != nil {
-		return x.EventsDroppedInEbpf
+		return x.Runs
 	}
 	return 0
 }
 
-func (x *EventsResponse_SummaryStatistics) GetEventsRejectedInEbpf() uint32 {
+func (x *StackMachineTrace_Program) GetFailures() uint64 {
 	if x != nil {
-		return x.EventsRejectedInEbpf
+		return x.Failures
 	}
 	return 0
 }
 
-type EventsResponse_Detached struct {
+func (x *StackMachineTrace_Program) GetDurationNs() uint64 {
+	if x != nil {
+		return x.DurationNs
+	}
+	return 0
+}
+
+type StackMachineTrace_Op struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SummaryStatistics *EventsResponse_SummaryStatistics `protobuf:"bytes,1,opt,name=summary_statistics,json=summaryStatistics,proto3" json:"summary_statistics,omitempty"`
-	// The timestamp of the detached event.
-	DetachedTimestampMonotonicNs uint64 `protobuf:"varint,2,opt,name=detached_timestamp_monotonic_ns,json=detachedTimestampMonotonicNs,proto3" json:"detached_timestamp_monotonic_ns,omitempty"`
+	// The opcode of the operation.
+	OpCode uint32 `protobuf:"varint,1,opt,name=op_code,json=opCode,proto3" json:"op_code,omitempty"`
+	// The number of times the operation ran.
+	Runs uint64 `protobuf:"varint,2,opt,name=runs,proto3" json:"runs,omitempty"`
+	// The total time spent running the operation. An operation is timed
+	// from its start to the start of the next operation, or to the end of
+	// its program, so this includes the overhead of the stack machine and of
+	// the trace itself.
+	DurationNs uint64 `protobuf:"varint,3,opt,name=duration_ns,json=durationNs,proto3" json:"duration_ns,omitempty"`
 }
 
-func (x *EventsResponse_Detached) Reset() {
-	*x = EventsResponse_Detached{}
+func (x *StackMachineTrace_Op) Reset() {
+	*x = StackMachineTrace_Op{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_machina_proto_msgTypes[22]
+		mi := &file_machina_proto_msgTypes[37]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *EventsResponse_Detached) String() string {
+func (x *StackMachineTrace_Op) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EventsResponse_Detached) ProtoMessage() {}
+func (*StackMachineTrace_Op) ProtoMessage() {}
 
-func (x *EventsResponse_Detached) ProtoReflect() protoreflect.Message {
-	mi := &file_machina_proto_msgTypes[22]
+func (x *StackMachineTrace_Op) ProtoReflect() protoreflect.Message {
+	mi := &file_machina_proto_msgTypes[37]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1487,21 +2619,28 @@ func (x *EventsResponse_Detached) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EventsResponse_Detached.ProtoReflect.Descriptor instead.
-func (*EventsResponse_Detached) Descriptor() ([]byte, []int) {
-	return file_machina_proto_rawDescGZIP(), []int{9, 4}
+// Deprecated: Use StackMachineTrace_Op.ProtoReflect.Descriptor instead.
+func (*StackMachineTrace_Op) Descriptor() ([]byte, []int) {
+	return file_machina_proto_rawDescGZIP(), []int{13, 4}
 }
 
-func (x *EventsResponse_Detached) GetSummaryStatistics() *EventsResponse_SummaryStatistics {
+func (x *StackMachineTrace_Op) GetOpCode() uint32 {
 	if x != nil {
-		return x.SummaryStatistics
+		return x.OpCode
 	}
-	return nil
+	return 0
 }
 
-func (x *EventsResponse_Detached) GetDetachedTimestampMonotonicNs() uint64 {
+func (x *StackMachineTrace_Op) GetRuns() uint64 {
 	if x != nil {
-		return x.DetachedTimestampMonotonicNs
+		return x.Runs
+	}
+	return 0
+}
+
+func (x *StackMachineTrace_Op) GetDurationNs() uint64 {
+	if x != nil {
+		return x.DurationNs
 	}
 	return 0
 }
@@ -1526,191 +2665,369 @@ var file_machina_proto_rawDesc = []byte{
 	0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x72, 0x65,
 	0x6d, 0x6f, 0x76, 0x65, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x69, 0x6e, 0x63, 0x6f, 0x6d, 0x70, 0x6c,
 	0x65, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x69, 0x6e, 0x63, 0x6f, 0x6d,
-	0x70, 0x6c, 0x65, 0x74, 0x65, 0x22, 0x2a, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x45, 0x78, 0x65, 0x63,
+	0x70, 0x6c, 0x65, 0x74, 0x65, 0x22, 0x42, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x45, 0x78, 0x65, 0x63,
 	0x75, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a,
 	0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73,
-	0x68, 0x22, 0xed, 0x01, 0x0a, 0x0f, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x36, 0x0a, 0x05, 0x73, 0x65, 0x74, 0x75, 0x70, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x61, 0x2e, 0x53,
-	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x53,
-	0x65, 0x74, 0x75, 0x70, 0x48, 0x00, 0x52, 0x05, 0x73, 0x65, 0x74, 0x75, 0x70, 0x12, 0x3f, 0x0a,
-	0x08, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x21, 0x2e, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x61, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68,
-	0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68,
-	0x6f, 0x74, 0x48, 0x00, 0x52, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x1a, 0x4a,
-	0x0a, 0x05, 0x53, 0x65, 0x74, 0x75, 0x70, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2f, 0x0a, 0x13, 0x70, 0x72, 0x6f,
-	0x63, 0x65, 0x73, 0x73, 0x5f, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x46,
-	0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x1a, 0x0a, 0x0a, 0x08, 0x53, 0x6e,
-	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x42, 0x09, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x22, 0xf5, 0x02, 0x0a, 0x0d, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x34, 0x0a, 0x05, 0x73, 0x65, 0x74, 0x75, 0x70, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x61, 0x2e, 0x45, 0x76, 0x65,
-	0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x65, 0x74, 0x75, 0x70,
-	0x48, 0x00, 0x52, 0x05, 0x73, 0x65, 0x74, 0x75, 0x70, 0x12, 0x37, 0x0a, 0x06, 0x73, 0x74, 0x72,
-	0x65, 0x61, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6d, 0x61, 0x63, 0x68,
-	0x69, 0x6e, 0x61, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x48, 0x00, 0x52, 0x06, 0x73, 0x74, 0x72, 0x65,
-	0x61, 0x6d, 0x12, 0x37, 0x0a, 0x06, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x61, 0x2e, 0x45, 0x76, 0x65,
-	0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x46, 0x69, 0x6e, 0x69, 0x73,
-	0x68, 0x48, 0x00, 0x52, 0x06, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x1a, 0x4a, 0x0a, 0x05, 0x53,
-	0x65, 0x74, 0x75, 0x70, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2f, 0x0a, 0x13, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73,
-	0x73, 0x5f, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x12, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x46, 0x69, 0x6e, 0x67,
-	0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x1a, 0x5b, 0x0a, 0x06, 0x53, 0x74, 0x72, 0x65, 0x61,
-	0x6d, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x24,
-	0x0a, 0x0b, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x73, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x04, 0x48, 0x00, 0x52, 0x0a, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4e,
-	0x73, 0x88, 0x01, 0x01, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x5f, 0x6e, 0x73, 0x1a, 0x08, 0x0a, 0x06, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x42, 0x09,
-	0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x52, 0x0a, 0x10, 0x41, 0x72, 0x72,
-	0x6f, 0x77, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1f, 0x0a,
-	0x0b, 0x69, 0x70, 0x63, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x0a, 0x69, 0x70, 0x63, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1d,
-	0x0a, 0x0a, 0x61, 0x72, 0x72, 0x6f, 0x77, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x09, 0x61, 0x72, 0x72, 0x6f, 0x77, 0x44, 0x61, 0x74, 0x61, 0x22, 0xe3, 0x01,
-	0x0a, 0x0f, 0x41, 0x72, 0x72, 0x6f, 0x77, 0x49, 0x70, 0x63, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x12, 0x31, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32,
-	0x1d, 0x2e, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x61, 0x2e, 0x41, 0x72, 0x72, 0x6f, 0x77, 0x49,
-	0x70, 0x63, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x04,
-	0x6b, 0x69, 0x6e, 0x64, 0x12, 0x3c, 0x0a, 0x0c, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x64, 0x5f,
-	0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6d, 0x61, 0x63,
-	0x68, 0x69, 0x6e, 0x61, 0x2e, 0x41, 0x72, 0x72, 0x6f, 0x77, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65,
-	0x64, 0x44, 0x61, 0x74, 0x61, 0x52, 0x0b, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x64, 0x44, 0x61,
-	0x74, 0x61, 0x22, 0x5f, 0x0a, 0x04, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e,
-	0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x22, 0x0a, 0x1e, 0x53, 0x43, 0x48, 0x45, 0x4d,
-	0x41, 0x5f, 0x57, 0x49, 0x54, 0x48, 0x5f, 0x44, 0x49, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x41, 0x52,
-	0x59, 0x5f, 0x54, 0x52, 0x41, 0x43, 0x4b, 0x45, 0x52, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x44,
-	0x49, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x41, 0x52, 0x59, 0x5f, 0x42, 0x41, 0x54, 0x43, 0x48, 0x10,
-	0x02, 0x12, 0x10, 0x0a, 0x0c, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x5f, 0x42, 0x41, 0x54, 0x43,
-	0x48, 0x10, 0x03, 0x22, 0x56, 0x0a, 0x0e, 0x41, 0x72, 0x72, 0x6f, 0x77, 0x49, 0x70, 0x63, 0x53,
-	0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0d, 0x52, 0x02, 0x69, 0x64, 0x12, 0x34, 0x0a, 0x08, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e,
-	0x61, 0x2e, 0x41, 0x72, 0x72, 0x6f, 0x77, 0x49, 0x70, 0x63, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x52, 0x08, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x22, 0x44, 0x0a, 0x0f, 0x41,
-	0x72, 0x72, 0x6f, 0x77, 0x49, 0x70, 0x63, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x12, 0x31,
-	0x0a, 0x07, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x17, 0x2e, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x61, 0x2e, 0x41, 0x72, 0x72, 0x6f, 0x77, 0x49,
-	0x70, 0x63, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x07, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d,
-	0x73, 0x22, 0xed, 0x06, 0x0a, 0x0e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x61, 0x2e, 0x45, 0x76,
-	0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x45, 0x76, 0x65,
-	0x6e, 0x74, 0x48, 0x00, 0x52, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x61, 0x0a, 0x15, 0x61,
-	0x70, 0x70, 0x72, 0x6f, 0x78, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x5f, 0x62, 0x6f, 0x6f, 0x74, 0x5f,
-	0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x6d, 0x61, 0x63,
-	0x68, 0x69, 0x6e, 0x61, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x2e, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x78, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x42,
-	0x6f, 0x6f, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x48, 0x00, 0x52, 0x13, 0x61, 0x70, 0x70, 0x72, 0x6f,
-	0x78, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x42, 0x6f, 0x6f, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x3e,
-	0x0a, 0x08, 0x61, 0x74, 0x74, 0x61, 0x63, 0x68, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x20, 0x2e, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x61, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74,
-	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68,
-	0x65, 0x64, 0x48, 0x00, 0x52, 0x08, 0x61, 0x74, 0x74, 0x61, 0x63, 0x68, 0x65, 0x64, 0x12, 0x3e,
-	0x0a, 0x08, 0x64, 0x65, 0x74, 0x61, 0x63, 0x68, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x20, 0x2e, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x61, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74,
-	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x44, 0x65, 0x74, 0x61, 0x63, 0x68,
-	0x65, 0x64, 0x48, 0x00, 0x52, 0x08, 0x64, 0x65, 0x74, 0x61, 0x63, 0x68, 0x65, 0x64, 0x12, 0x3f,
-	0x0a, 0x0d, 0x61, 0x72, 0x72, 0x6f, 0x77, 0x5f, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x61, 0x2e,
-	0x41, 0x72, 0x72, 0x6f, 0x77, 0x49, 0x70, 0x63, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x48,
-	0x00, 0x52, 0x0c, 0x61, 0x72, 0x72, 0x6f, 0x77, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x1a,
-	0x1b, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x1a, 0x4e, 0x0a, 0x13,
-	0x41, 0x70, 0x70, 0x72, 0x6f, 0x78, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x42, 0x6f, 0x6f, 0x74, 0x54,
-	0x69, 0x6d, 0x65, 0x12, 0x37, 0x0a, 0x18, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x78, 0x69, 0x6d, 0x61,
-	0x74, 0x65, 0x5f, 0x62, 0x6f, 0x6f, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x6e, 0x73, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x15, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x78, 0x69, 0x6d, 0x61,
-	0x74, 0x65, 0x42, 0x6f, 0x6f, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x4e, 0x73, 0x1a, 0x51, 0x0a, 0x08,
-	0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x65, 0x64, 0x12, 0x45, 0x0a, 0x1f, 0x61, 0x74, 0x74, 0x61,
-	0x63, 0x68, 0x65, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x5f, 0x6d,
-	0x6f, 0x6e, 0x6f, 0x74, 0x6f, 0x6e, 0x69, 0x63, 0x5f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x04, 0x52, 0x1c, 0x61, 0x74, 0x74, 0x61, 0x63, 0x68, 0x65, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x73,
-	0x74, 0x61, 0x6d, 0x70, 0x4d, 0x6f, 0x6e, 0x6f, 0x74, 0x6f, 0x6e, 0x69, 0x63, 0x4e, 0x73, 0x1a,
-	0x85, 0x01, 0x0a, 0x11, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x53, 0x74, 0x61, 0x74, 0x69,
-	0x73, 0x74, 0x69, 0x63, 0x73, 0x12, 0x33, 0x0a, 0x16, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x5f,
-	0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x5f, 0x69, 0x6e, 0x5f, 0x65, 0x62, 0x70, 0x66, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x13, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x44, 0x72, 0x6f,
-	0x70, 0x70, 0x65, 0x64, 0x49, 0x6e, 0x45, 0x62, 0x70, 0x66, 0x12, 0x35, 0x0a, 0x17, 0x65, 0x76,
-	0x65, 0x6e, 0x74, 0x73, 0x5f, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x69, 0x6e,
-	0x5f, 0x65, 0x62, 0x70, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x14, 0x65, 0x76, 0x65,
-	0x6e, 0x74, 0x73, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x49, 0x6e, 0x45, 0x62, 0x70,
-	0x66, 0x4a, 0x04, 0x08, 0x01, 0x10, 0x02, 0x1a, 0xab, 0x01, 0x0a, 0x08, 0x44, 0x65, 0x74, 0x61,
-	0x63, 0x68, 0x65, 0x64, 0x12, 0x58, 0x0a, 0x12, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x5f,
-	0x73, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x29, 0x2e, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x61, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74,
-	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72,
-	0x79, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x52, 0x11, 0x73, 0x75, 0x6d,
-	0x6d, 0x61, 0x72, 0x79, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x12, 0x45,
-	0x0a, 0x1f, 0x64, 0x65, 0x74, 0x61, 0x63, 0x68, 0x65, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73,
-	0x74, 0x61, 0x6d, 0x70, 0x5f, 0x6d, 0x6f, 0x6e, 0x6f, 0x74, 0x6f, 0x6e, 0x69, 0x63, 0x5f, 0x6e,
-	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x1c, 0x64, 0x65, 0x74, 0x61, 0x63, 0x68, 0x65,
-	0x64, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x4d, 0x6f, 0x6e, 0x6f, 0x74, 0x6f,
-	0x6e, 0x69, 0x63, 0x4e, 0x73, 0x42, 0x0a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x82, 0x02, 0x0a, 0x10, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69,
-	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x68, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x22, 0xf3, 0x03, 0x0a, 0x0f, 0x53, 0x6e,
+	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x36, 0x0a,
+	0x05, 0x73, 0x65, 0x74, 0x75, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6d,
+	0x61, 0x63, 0x68, 0x69, 0x6e, 0x61, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x65, 0x74, 0x75, 0x70, 0x48, 0x00, 0x52, 0x05,
+	0x73, 0x65, 0x74, 0x75, 0x70, 0x12, 0x3f, 0x0a, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e,
+	0x61, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x48, 0x00, 0x52, 0x08, 0x73, 0x6e,
+	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x1a, 0x4a, 0x0a, 0x05, 0x53, 0x65, 0x74, 0x75, 0x70, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x2f, 0x0a, 0x13, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x66, 0x69, 0x6e,
+	0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12,
+	0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x46, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69,
+	0x6e, 0x74, 0x1a, 0x8f, 0x02, 0x0a, 0x08, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12,
+	0x43, 0x0a, 0x10, 0x67, 0x6f, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x65, 0x5f, 0x66, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6d, 0x61, 0x63, 0x68,
+	0x69, 0x6e, 0x61, 0x2e, 0x47, 0x6f, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x65, 0x46, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x52, 0x0f, 0x67, 0x6f, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x65, 0x46, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x12, 0x25, 0x0a, 0x0c, 0x6d, 0x61, 0x78, 0x5f, 0x70, 0x61, 0x75, 0x73,
+	0x65, 0x5f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x48, 0x00, 0x52, 0x0a, 0x6d, 0x61,
+	0x78, 0x50, 0x61, 0x75, 0x73, 0x65, 0x4e, 0x73, 0x88, 0x01, 0x01, 0x12, 0x30, 0x0a, 0x12, 0x6d,
+	0x61, 0x78, 0x5f, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x5f, 0x70, 0x61, 0x75, 0x73, 0x65, 0x5f, 0x6e,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x48, 0x01, 0x52, 0x0f, 0x6d, 0x61, 0x78, 0x53, 0x74,
+	0x61, 0x63, 0x6b, 0x50, 0x61, 0x75, 0x73, 0x65, 0x4e, 0x73, 0x88, 0x01, 0x01, 0x12, 0x14, 0x0a,
+	0x05, 0x74, 0x72, 0x61, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x74, 0x72,
+	0x61, 0x63, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x66, 0x72, 0x61, 0x6d, 0x69, 0x6e, 0x67, 0x5f, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x66, 0x72,
+	0x61, 0x6d, 0x69, 0x6e, 0x67, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x42, 0x0f, 0x0a, 0x0d,
+	0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x70, 0x61, 0x75, 0x73, 0x65, 0x5f, 0x6e, 0x73, 0x42, 0x15, 0x0a,
+	0x13, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x5f, 0x70, 0x61, 0x75, 0x73,
+	0x65, 0x5f, 0x6e, 0x73, 0x42, 0x09, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22,
+	0xad, 0x02, 0x0a, 0x0f, 0x47, 0x6f, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x65, 0x46, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x12, 0x3d, 0x0a, 0x09, 0x70, 0x63, 0x5f, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x61,
+	0x2e, 0x47, 0x6f, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x2e, 0x50, 0x63, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x08, 0x70, 0x63, 0x52, 0x61, 0x6e, 0x67,
+	0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x6f, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x04, 0x52, 0x05, 0x67, 0x6f, 0x69, 0x64, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x4d, 0x61, 0x73, 0x6b, 0x12, 0x3b, 0x0a, 0x06, 0x6c, 0x61, 0x62,
+	0x65, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6d, 0x61, 0x63, 0x68,
+	0x69, 0x6e, 0x61, 0x2e, 0x47, 0x6f, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x65, 0x46, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x06,
+	0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x1a, 0x31, 0x0a, 0x07, 0x50, 0x63, 0x52, 0x61, 0x6e, 0x67,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x1a, 0x34, 0x0a, 0x0a, 0x4c, 0x61, 0x62,
+	0x65, 0x6c, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22,
+	0xf5, 0x02, 0x0a, 0x0d, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x34, 0x0a, 0x05, 0x73, 0x65, 0x74, 0x75, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1c, 0x2e, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x61, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x65, 0x74, 0x75, 0x70, 0x48, 0x00,
+	0x52, 0x05, 0x73, 0x65, 0x74, 0x75, 0x70, 0x12, 0x37, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e,
+	0x61, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e,
+	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x48, 0x00, 0x52, 0x06, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x12, 0x37, 0x0a, 0x06, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1d, 0x2e, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x61, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x48,
+	0x00, 0x52, 0x06, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x1a, 0x4a, 0x0a, 0x05, 0x53, 0x65, 0x74,
+	0x75, 0x70, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x2f, 0x0a, 0x13, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x5f,
+	0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x12, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x46, 0x69, 0x6e, 0x67, 0x65, 0x72,
+	0x70, 0x72, 0x69, 0x6e, 0x74, 0x1a, 0x5b, 0x0a, 0x06, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12,
+	0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x24, 0x0a, 0x0b,
+	0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x04, 0x48, 0x00, 0x52, 0x0a, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x73, 0x88,
+	0x01, 0x01, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x6e, 0x73, 0x1a, 0x08, 0x0a, 0x06, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x42, 0x09, 0x0a, 0x07,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x52, 0x0a, 0x10, 0x41, 0x72, 0x72, 0x6f, 0x77,
+	0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1f, 0x0a, 0x0b, 0x69,
+	0x70, 0x63, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x0a, 0x69, 0x70, 0x63, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1d, 0x0a, 0x0a,
+	0x61, 0x72, 0x72, 0x6f, 0x77, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x09, 0x61, 0x72, 0x72, 0x6f, 0x77, 0x44, 0x61, 0x74, 0x61, 0x22, 0xe3, 0x01, 0x0a, 0x0f,
+	0x41, 0x72, 0x72, 0x6f, 0x77, 0x49, 0x70, 0x63, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
+	0x31, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e,
+	0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x61, 0x2e, 0x41, 0x72, 0x72, 0x6f, 0x77, 0x49, 0x70, 0x63,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x04, 0x6b, 0x69,
+	0x6e, 0x64, 0x12, 0x3c, 0x0a, 0x0c, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x64, 0x5f, 0x64, 0x61,
+	0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6d, 0x61, 0x63, 0x68, 0x69,
+	0x6e, 0x61, 0x2e, 0x41, 0x72, 0x72, 0x6f, 0x77, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x64, 0x44,
+	0x61, 0x74, 0x61, 0x52, 0x0b, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61,
+	0x22, 0x5f, 0x0a, 0x04, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e,
+	0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x22, 0x0a, 0x1e, 0x53, 0x43, 0x48, 0x45, 0x4d, 0x41, 0x5f,
+	0x57, 0x49, 0x54, 0x48, 0x5f, 0x44, 0x49, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x41, 0x52, 0x59, 0x5f,
+	0x54, 0x52, 0x41, 0x43, 0x4b, 0x45, 0x52, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x44, 0x49, 0x43,
+	0x54, 0x49, 0x4f, 0x4e, 0x41, 0x52, 0x59, 0x5f, 0x42, 0x41, 0x54, 0x43, 0x48, 0x10, 0x02, 0x12,
+	0x10, 0x0a, 0x0c, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x5f, 0x42, 0x41, 0x54, 0x43, 0x48, 0x10,
+	0x03, 0x22, 0x56, 0x0a, 0x0e, 0x41, 0x72, 0x72, 0x6f, 0x77, 0x49, 0x70, 0x63, 0x53, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x34, 0x0a, 0x08, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x61, 0x2e,
+	0x41, 0x72, 0x72, 0x6f, 0x77, 0x49, 0x70, 0x63, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52,
+	0x08, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x22, 0x44, 0x0a, 0x0f, 0x41, 0x72, 0x72,
+	0x6f, 0x77, 0x49, 0x70, 0x63, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x12, 0x31, 0x0a, 0x07,
+	0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e,
+	0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x61, 0x2e, 0x41, 0x72, 0x72, 0x6f, 0x77, 0x49, 0x70, 0x63,
+	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x07, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x22,
+	0xed, 0x06, 0x0a, 0x0e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x35, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1d, 0x2e, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x61, 0x2e, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x48, 0x00, 0x52, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x61, 0x0a, 0x15, 0x61, 0x70, 0x70,
+	0x72, 0x6f, 0x78, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x5f, 0x62, 0x6f, 0x6f, 0x74, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x6d, 0x61, 0x63, 0x68, 0x69,
+	0x6e, 0x61, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x2e, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x78, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x42, 0x6f, 0x6f,
+	0x74, 0x54, 0x69, 0x6d, 0x65, 0x48, 0x00, 0x52, 0x13, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x78, 0x69,
+	0x6d, 0x61, 0x74, 0x65, 0x42, 0x6f, 0x6f, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x3e, 0x0a, 0x08,
+	0x61, 0x74, 0x74, 0x61, 0x63, 0x68, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20,
+	0x2e, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x61, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x65, 0x64,
+	0x48, 0x00, 0x52, 0x08, 0x61, 0x74, 0x74, 0x61, 0x63, 0x68, 0x65, 0x64, 0x12, 0x3e, 0x0a, 0x08,
+	0x64, 0x65, 0x74, 0x61, 0x63, 0x68, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20,
+	0x2e, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x61, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x44, 0x65, 0x74, 0x61, 0x63, 0x68, 0x65, 0x64,
+	0x48, 0x00, 0x52, 0x08, 0x64, 0x65, 0x74, 0x61, 0x63, 0x68, 0x65, 0x64, 0x12, 0x3f, 0x0a, 0x0d,
+	0x61, 0x72, 0x72, 0x6f, 0x77, 0x5f, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x61, 0x2e, 0x41, 0x72,
+	0x72, 0x6f, 0x77, 0x49, 0x70, 0x63, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x48, 0x00, 0x52,
+	0x0c, 0x61, 0x72, 0x72, 0x6f, 0x77, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x1a, 0x1b, 0x0a,
+	0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x1a, 0x4e, 0x0a, 0x13, 0x41, 0x70,
+	0x70, 0x72, 0x6f, 0x78, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x42, 0x6f, 0x6f, 0x74, 0x54, 0x69, 0x6d,
+	0x65, 0x12, 0x37, 0x0a, 0x18, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x78, 0x69, 0x6d, 0x61, 0x74, 0x65,
+	0x5f, 0x62, 0x6f, 0x6f, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x6e, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x15, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x78, 0x69, 0x6d, 0x61, 0x74, 0x65,
+	0x42, 0x6f, 0x6f, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x4e, 0x73, 0x1a, 0x51, 0x0a, 0x08, 0x41, 0x74,
+	0x74, 0x61, 0x63, 0x68, 0x65, 0x64, 0x12, 0x45, 0x0a, 0x1f, 0x61, 0x74, 0x74, 0x61, 0x63, 0x68,
+	0x65, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x5f, 0x6d, 0x6f, 0x6e,
+	0x6f, 0x74, 0x6f, 0x6e, 0x69, 0x63, 0x5f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x1c, 0x61, 0x74, 0x74, 0x61, 0x63, 0x68, 0x65, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x4d, 0x6f, 0x6e, 0x6f, 0x74, 0x6f, 0x6e, 0x69, 0x63, 0x4e, 0x73, 0x1a, 0x85, 0x01,
+	0x0a, 0x11, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74,
+	0x69, 0x63, 0x73, 0x12, 0x33, 0x0a, 0x16, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x5f, 0x64, 0x72,
+	0x6f, 0x70, 0x70, 0x65, 0x64, 0x5f, 0x69, 0x6e, 0x5f, 0x65, 0x62, 0x70, 0x66, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x13, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x44, 0x72, 0x6f, 0x70, 0x70,
+	0x65, 0x64, 0x49, 0x6e, 0x45, 0x62, 0x70, 0x66, 0x12, 0x35, 0x0a, 0x17, 0x65, 0x76, 0x65, 0x6e,
+	0x74, 0x73, 0x5f, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x69, 0x6e, 0x5f, 0x65,
+	0x62, 0x70, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x14, 0x65, 0x76, 0x65, 0x6e, 0x74,
+	0x73, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x49, 0x6e, 0x45, 0x62, 0x70, 0x66, 0x4a,
+	0x04, 0x08, 0x01, 0x10, 0x02, 0x1a, 0xab, 0x01, 0x0a, 0x08, 0x44, 0x65, 0x74, 0x61, 0x63, 0x68,
+	0x65, 0x64, 0x12, 0x58, 0x0a, 0x12, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x5f, 0x73, 0x74,
+	0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29,
+	0x2e, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x61, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x53,
+	0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x52, 0x11, 0x73, 0x75, 0x6d, 0x6d, 0x61,
+	0x72, 0x79, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x12, 0x45, 0x0a, 0x1f,
+	0x64, 0x65, 0x74, 0x61, 0x63, 0x68, 0x65, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x5f, 0x6d, 0x6f, 0x6e, 0x6f, 0x74, 0x6f, 0x6e, 0x69, 0x63, 0x5f, 0x6e, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x1c, 0x64, 0x65, 0x74, 0x61, 0x63, 0x68, 0x65, 0x64, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x4d, 0x6f, 0x6e, 0x6f, 0x74, 0x6f, 0x6e, 0x69,
+	0x63, 0x4e, 0x73, 0x42, 0x0a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0xf9, 0x05, 0x0a, 0x10, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x12, 0x2a, 0x0a, 0x11, 0x70, 0x61, 0x75, 0x73, 0x65, 0x5f, 0x64, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x70,
+	0x61, 0x75, 0x73, 0x65, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x73, 0x12, 0x4e,
+	0x0a, 0x15, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x78, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x5f, 0x62, 0x6f,
+	0x6f, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
 	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73,
-	0x74, 0x61, 0x6d, 0x70, 0x12, 0x2a, 0x0a, 0x11, 0x70, 0x61, 0x75, 0x73, 0x65, 0x5f, 0x64, 0x75,
-	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52,
-	0x0f, 0x70, 0x61, 0x75, 0x73, 0x65, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x73,
-	0x12, 0x4e, 0x0a, 0x15, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x78, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x5f,
-	0x62, 0x6f, 0x6f, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x13, 0x61, 0x70, 0x70,
-	0x72, 0x6f, 0x78, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x42, 0x6f, 0x6f, 0x74, 0x54, 0x69, 0x6d, 0x65,
-	0x12, 0x24, 0x0a, 0x0e, 0x62, 0x73, 0x73, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x5f, 0x73, 0x68, 0x69,
-	0x66, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x62, 0x73, 0x73, 0x41, 0x64, 0x64,
-	0x72, 0x53, 0x68, 0x69, 0x66, 0x74, 0x22, 0x14, 0x0a, 0x12, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e,
-	0x61, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x9b, 0x02, 0x0a,
-	0x13, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x61, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72,
-	0x69, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x66, 0x69, 0x6e, 0x67, 0x65,
-	0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61,
-	0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61,
-	0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x0e,
-	0x6b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x08,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x56, 0x65, 0x72, 0x73,
-	0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x5f, 0x74, 0x6f,
-	0x6b, 0x65, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x74, 0x65, 0x6e, 0x61, 0x6e,
-	0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f,
-	0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x65, 0x6e, 0x76,
-	0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x69, 0x70, 0x5f, 0x61,
-	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b,
-	0x69, 0x70, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x69,
-	0x73, 0x5f, 0x6c, 0x69, 0x62, 0x72, 0x61, 0x72, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x09, 0x69, 0x73, 0x4c, 0x69, 0x62, 0x72, 0x61, 0x72, 0x79, 0x32, 0xe8, 0x02, 0x0a, 0x07, 0x4d,
-	0x61, 0x63, 0x68, 0x69, 0x6e, 0x61, 0x12, 0x45, 0x0a, 0x0e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x50,
-	0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x1e, 0x2e, 0x6d, 0x61, 0x63, 0x68, 0x69,
-	0x6e, 0x61, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0f, 0x2e, 0x6d, 0x61, 0x63, 0x68, 0x69,
-	0x6e, 0x61, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x40, 0x0a,
-	0x0d, 0x47, 0x65, 0x74, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x1d,
-	0x2e, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x61, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x78, 0x65, 0x63,
-	0x75, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0c, 0x2e,
-	0x63, 0x68, 0x75, 0x6e, 0x6b, 0x2e, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x22, 0x00, 0x30, 0x01, 0x12,
-	0x45, 0x0a, 0x08, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x18, 0x2e, 0x6d, 0x61,
-	0x63, 0x68, 0x69, 0x6e, 0x61, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x61, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x13, 0x61, 0x70, 0x70, 0x72, 0x6f,
+	0x78, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x42, 0x6f, 0x6f, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x24,
+	0x0a, 0x0e, 0x62, 0x73, 0x73, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x5f, 0x73, 0x68, 0x69, 0x66, 0x74,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x62, 0x73, 0x73, 0x41, 0x64, 0x64, 0x72, 0x53,
+	0x68, 0x69, 0x66, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x62, 0x79, 0x74,
+	0x65, 0x5f, 0x6c, 0x65, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x64, 0x61, 0x74,
+	0x61, 0x42, 0x79, 0x74, 0x65, 0x4c, 0x65, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x72, 0x75, 0x6e,
+	0x63, 0x61, 0x74, 0x65, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x74, 0x72, 0x75,
+	0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x72, 0x74, 0x69, 0x61,
+	0x6c, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x70, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c,
+	0x12, 0x18, 0x0a, 0x07, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x18, 0x0e, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x07, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x12, 0x2f, 0x0a, 0x13, 0x66, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x65, 0x64, 0x5f, 0x67, 0x6f, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x65,
+	0x73, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x12, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x65,
+	0x64, 0x47, 0x6f, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x65, 0x73, 0x12, 0x59, 0x0a, 0x10, 0x73,
+	0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x5f, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x65, 0x65, 0x73, 0x18,
+	0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x61, 0x2e,
 	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x12, 0x3f, 0x0a, 0x06, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73,
-	0x12, 0x16, 0x2e, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x61, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x6d, 0x61, 0x63, 0x68, 0x69,
-	0x6e, 0x61, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x12, 0x4c, 0x0a, 0x0b, 0x4d, 0x61, 0x63, 0x68, 0x69,
-	0x6e, 0x61, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1b, 0x2e, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x61,
-	0x2e, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x61, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x61, 0x2e, 0x4d, 0x61,
-	0x63, 0x68, 0x69, 0x6e, 0x61, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x00, 0x30, 0x01, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x2e, 0x53, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x65, 0x65, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0f, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x50, 0x6f,
+	0x69, 0x6e, 0x74, 0x65, 0x65, 0x73, 0x12, 0x30, 0x0a, 0x05, 0x74, 0x72, 0x61, 0x63, 0x65, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x61, 0x2e,
+	0x53, 0x74, 0x61, 0x63, 0x6b, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x54, 0x72, 0x61, 0x63,
+	0x65, 0x52, 0x05, 0x74, 0x72, 0x61, 0x63, 0x65, 0x12, 0x3a, 0x0a, 0x0d, 0x72, 0x75, 0x6e, 0x74,
+	0x69, 0x6d, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x61, 0x2e, 0x52, 0x75, 0x6e, 0x74, 0x69, 0x6d,
+	0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x0c, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x66, 0x72, 0x61, 0x6d, 0x69, 0x6e, 0x67, 0x5f,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x66,
+	0x72, 0x61, 0x6d, 0x69, 0x6e, 0x67, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x1a, 0x5c, 0x0a,
+	0x14, 0x53, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x65, 0x65, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2e, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x61,
+	0x2e, 0x53, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x65, 0x65, 0x73,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xfb, 0x04, 0x0a, 0x0c,
+	0x52, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x36, 0x0a, 0x07,
+	0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e,
+	0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x61, 0x2e, 0x52, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x2e, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x52, 0x07, 0x6d, 0x65, 0x74,
+	0x72, 0x69, 0x63, 0x73, 0x12, 0x3b, 0x0a, 0x09, 0x6d, 0x65, 0x6d, 0x5f, 0x73, 0x74, 0x61, 0x74,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e,
+	0x61, 0x2e, 0x52, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x2e, 0x4d,
+	0x65, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x08, 0x6d, 0x65, 0x6d, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x1a, 0x44, 0x0a, 0x10, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x36, 0x34, 0x48, 0x69, 0x73, 0x74,
+	0x6f, 0x67, 0x72, 0x61, 0x6d, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x04, 0x52, 0x06, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x12, 0x18, 0x0a,
+	0x07, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x01, 0x52, 0x07,
+	0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x1a, 0xc8, 0x01, 0x0a, 0x06, 0x4d, 0x65, 0x74, 0x72,
+	0x69, 0x63, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x0c, 0x75, 0x69, 0x6e, 0x74, 0x36, 0x34,
+	0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x48, 0x00, 0x52, 0x0b,
+	0x75, 0x69, 0x6e, 0x74, 0x36, 0x34, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x25, 0x0a, 0x0d, 0x66,
+	0x6c, 0x6f, 0x61, 0x74, 0x36, 0x34, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x01, 0x48, 0x00, 0x52, 0x0c, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x36, 0x34, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x12, 0x55, 0x0a, 0x11, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x36, 0x34, 0x5f, 0x68, 0x69,
+	0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e,
+	0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x61, 0x2e, 0x52, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x2e, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x36, 0x34, 0x48, 0x69, 0x73, 0x74,
+	0x6f, 0x67, 0x72, 0x61, 0x6d, 0x48, 0x00, 0x52, 0x10, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x36, 0x34,
+	0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x42, 0x07, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x1a, 0xe4, 0x01, 0x0a, 0x08, 0x4d, 0x65, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12,
+	0x25, 0x0a, 0x0f, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x67, 0x63, 0x5f, 0x75, 0x6e, 0x69, 0x78, 0x5f,
+	0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x47, 0x63,
+	0x55, 0x6e, 0x69, 0x78, 0x4e, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x67,
+	0x63, 0x5f, 0x6e, 0x61, 0x6e, 0x6f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0e, 0x6c, 0x61, 0x73, 0x74, 0x47, 0x63, 0x4e, 0x61, 0x6e, 0x6f, 0x74, 0x69, 0x6d, 0x65,
+	0x12, 0x24, 0x0a, 0x0e, 0x70, 0x61, 0x75, 0x73, 0x65, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f,
+	0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x70, 0x61, 0x75, 0x73, 0x65, 0x54,
+	0x6f, 0x74, 0x61, 0x6c, 0x4e, 0x73, 0x12, 0x15, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x5f, 0x67, 0x63,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6e, 0x75, 0x6d, 0x47, 0x63, 0x12, 0x22, 0x0a,
+	0x0d, 0x6e, 0x75, 0x6d, 0x5f, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x64, 0x5f, 0x67, 0x63, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x6e, 0x75, 0x6d, 0x46, 0x6f, 0x72, 0x63, 0x65, 0x64, 0x47,
+	0x63, 0x12, 0x26, 0x0a, 0x0f, 0x67, 0x63, 0x5f, 0x63, 0x70, 0x75, 0x5f, 0x66, 0x72, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x67, 0x63, 0x43, 0x70,
+	0x75, 0x46, 0x72, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x8b, 0x08, 0x0a, 0x11, 0x53, 0x74,
+	0x61, 0x63, 0x6b, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x54, 0x72, 0x61, 0x63, 0x65, 0x12,
+	0x38, 0x0a, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x20, 0x2e, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x61, 0x2e, 0x53, 0x74, 0x61, 0x63, 0x6b, 0x4d,
+	0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x54, 0x72, 0x61, 0x63, 0x65, 0x2e, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x52, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x64, 0x72, 0x6f,
+	0x70, 0x70, 0x65, 0x64, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0d, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73,
+	0x12, 0x45, 0x0a, 0x09, 0x6f, 0x70, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x61, 0x2e, 0x53, 0x74,
+	0x61, 0x63, 0x6b, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x54, 0x72, 0x61, 0x63, 0x65, 0x2e,
+	0x4f, 0x70, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x6f,
+	0x70, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x12, 0x44, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x67, 0x72,
+	0x61, 0x6d, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x6d, 0x61, 0x63, 0x68,
+	0x69, 0x6e, 0x61, 0x2e, 0x53, 0x74, 0x61, 0x63, 0x6b, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65,
+	0x54, 0x72, 0x61, 0x63, 0x65, 0x2e, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x35, 0x0a,
+	0x03, 0x6f, 0x70, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6d, 0x61, 0x63,
+	0x68, 0x69, 0x6e, 0x61, 0x2e, 0x53, 0x74, 0x61, 0x63, 0x6b, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e,
+	0x65, 0x54, 0x72, 0x61, 0x63, 0x65, 0x2e, 0x4f, 0x70, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x03, 0x6f, 0x70, 0x73, 0x1a, 0xab, 0x02, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x39,
+	0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x25, 0x2e, 0x6d,
+	0x61, 0x63, 0x68, 0x69, 0x6e, 0x61, 0x2e, 0x53, 0x74, 0x61, 0x63, 0x6b, 0x4d, 0x61, 0x63, 0x68,
+	0x69, 0x6e, 0x65, 0x54, 0x72, 0x61, 0x63, 0x65, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x4b,
+	0x69, 0x6e, 0x64, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x72, 0x6f,
+	0x67, 0x5f, 0x70, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x70, 0x72, 0x6f, 0x67,
+	0x50, 0x63, 0x12, 0x0e, 0x0a, 0x02, 0x70, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x02,
+	0x70, 0x63, 0x12, 0x17, 0x0a, 0x07, 0x6f, 0x70, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x06, 0x6f, 0x70, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x61,
+	0x64, 0x64, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x61, 0x64, 0x64, 0x72, 0x12,
+	0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x79, 0x74, 0x65, 0x5f, 0x6c, 0x65, 0x6e, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x62, 0x79, 0x74, 0x65, 0x4c, 0x65, 0x6e, 0x22, 0x62,
+	0x0a, 0x04, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57,
+	0x4e, 0x10, 0x00, 0x12, 0x06, 0x0a, 0x02, 0x4f, 0x50, 0x10, 0x01, 0x12, 0x16, 0x0a, 0x12, 0x44,
+	0x45, 0x52, 0x45, 0x46, 0x45, 0x52, 0x45, 0x4e, 0x43, 0x45, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45,
+	0x44, 0x10, 0x02, 0x12, 0x13, 0x0a, 0x0f, 0x45, 0x4e, 0x51, 0x55, 0x45, 0x55, 0x45, 0x5f, 0x53,
+	0x4b, 0x49, 0x50, 0x50, 0x45, 0x44, 0x10, 0x03, 0x12, 0x18, 0x0a, 0x14, 0x54, 0x59, 0x50, 0x45,
+	0x5f, 0x52, 0x45, 0x53, 0x4f, 0x4c, 0x55, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4d, 0x49, 0x53, 0x53,
+	0x10, 0x04, 0x1a, 0x3b, 0x0a, 0x0d, 0x4f, 0x70, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a,
+	0x5a, 0x0a, 0x07, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x75,
+	0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x72, 0x75, 0x6e, 0x73, 0x12, 0x1a,
+	0x0a, 0x08, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x08, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0a, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x73, 0x1a, 0x5f, 0x0a, 0x0d, 0x50,
+	0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x38,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e,
+	0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x61, 0x2e, 0x53, 0x74, 0x61, 0x63, 0x6b, 0x4d, 0x61, 0x63,
+	0x68, 0x69, 0x6e, 0x65, 0x54, 0x72, 0x61, 0x63, 0x65, 0x2e, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x61,
+	0x6d, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x52, 0x0a, 0x02,
+	0x4f, 0x70, 0x12, 0x17, 0x0a, 0x07, 0x6f, 0x70, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x06, 0x6f, 0x70, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x72,
+	0x75, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x72, 0x75, 0x6e, 0x73, 0x12,
+	0x1f, 0x0a, 0x0b, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x73,
+	0x1a, 0x55, 0x0a, 0x08, 0x4f, 0x70, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x33,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e,
+	0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x61, 0x2e, 0x53, 0x74, 0x61, 0x63, 0x6b, 0x4d, 0x61, 0x63,
+	0x68, 0x69, 0x6e, 0x65, 0x54, 0x72, 0x61, 0x63, 0x65, 0x2e, 0x4f, 0x70, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x42, 0x0a, 0x0f, 0x53, 0x6b, 0x69, 0x70, 0x70,
+	0x65, 0x64, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x65, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x12, 0x19, 0x0a, 0x08, 0x62, 0x79, 0x74, 0x65, 0x5f, 0x6c, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x07, 0x62, 0x79, 0x74, 0x65, 0x4c, 0x65, 0x6e, 0x22, 0x14, 0x0a, 0x12, 0x4d,
+	0x61, 0x63, 0x68, 0x69, 0x6e, 0x61, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x22, 0x9b, 0x02, 0x0a, 0x13, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x61, 0x49, 0x6e, 0x66,
+	0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x66, 0x69, 0x6e,
+	0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x68,
+	0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68,
+	0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x25, 0x0a, 0x0e, 0x6b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x5f, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6b, 0x65, 0x72, 0x6e, 0x65,
+	0x6c, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x65, 0x6e, 0x61,
+	0x6e, 0x74, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x65,
+	0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x21, 0x0a,
+	0x0c, 0x69, 0x70, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x07, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0b, 0x69, 0x70, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73,
+	0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x73, 0x5f, 0x6c, 0x69, 0x62, 0x72, 0x61, 0x72, 0x79, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x69, 0x73, 0x4c, 0x69, 0x62, 0x72, 0x61, 0x72, 0x79, 0x32,
+	0xe8, 0x02, 0x0a, 0x07, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x61, 0x12, 0x45, 0x0a, 0x0e, 0x57,
+	0x61, 0x74, 0x63, 0x68, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x1e, 0x2e,
+	0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x61, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x50, 0x72, 0x6f,
+	0x63, 0x65, 0x73, 0x73, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0f, 0x2e,
+	0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x61, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x22, 0x00,
+	0x30, 0x01, 0x12, 0x40, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x61,
+	0x62, 0x6c, 0x65, 0x12, 0x1d, 0x2e, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x61, 0x2e, 0x47, 0x65,
+	0x74, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x0c, 0x2e, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x2e, 0x43, 0x68, 0x75, 0x6e, 0x6b,
+	0x22, 0x00, 0x30, 0x01, 0x12, 0x45, 0x0a, 0x08, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
+	0x12, 0x18, 0x2e, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x61, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73,
+	0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x6d, 0x61, 0x63,
+	0x68, 0x69, 0x6e, 0x61, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x12, 0x3f, 0x0a, 0x06, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x16, 0x2e, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x61, 0x2e,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e,
+	0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x61, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x12, 0x4c, 0x0a, 0x0b,
+	0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x61, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1b, 0x2e, 0x6d, 0x61,
+	0x63, 0x68, 0x69, 0x6e, 0x61, 0x2e, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x61, 0x49, 0x6e, 0x66,
+	0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x6d, 0x61, 0x63, 0x68, 0x69,
+	0x6e, 0x61, 0x2e, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x61, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
 }
 
 var (
@@ -1725,73 +3042,107 @@ func file_machina_proto_rawDescGZIP() []byte {
 	return file_machina_proto_rawDescData
 }
 
-var file_machina_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_machina_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_machina_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_machina_proto_msgTypes = make([]protoimpl.MessageInfo, 39)
 var file_machina_proto_goTypes = []interface{}{
 	(ArrowIpcMessage_Kind)(0),                  // 0: machina.ArrowIpcMessage.Kind
-	(*WatchProcessesRequest)(nil),              // 1: machina.WatchProcessesRequest
-	(*Update)(nil),                             // 2: machina.Update
-	(*GetExecutableRequest)(nil),               // 3: machina.GetExecutableRequest
-	(*SnapshotRequest)(nil),                    // 4: machina.SnapshotRequest
-	(*EventsRequest)(nil),                      // 5: machina.EventsRequest
-	(*ArrowEncodedData)(nil),                   // 6: machina.ArrowEncodedData
-	(*ArrowIpcMessage)(nil),                    // 7: machina.ArrowIpcMessage
-	(*ArrowIpcStream)(nil),                     // 8: machina.ArrowIpcStream
-	(*ArrowIpcStreams)(nil),                    // 9: machina.ArrowIpcStreams
-	(*EventsResponse)(nil),                     // 10: machina.EventsResponse
-	(*SnapshotResponse)(nil),                   // 11: machina.SnapshotResponse
-	(*MachinaInfoRequest)(nil),                 // 12: machina.MachinaInfoRequest
-	(*MachinaInfoResponse)(nil),                // 13: machina.MachinaInfoResponse
-	(*SnapshotRequest_Setup)(nil),              // 14: machina.SnapshotRequest.Setup
-	(*SnapshotRequest_Snapshot)(nil),           // 15: machina.SnapshotRequest.Snapshot
-	(*EventsRequest_Setup)(nil),                // 16: machina.EventsRequest.Setup
-	(*EventsRequest_Stream)(nil),               // 17: machina.EventsRequest.Stream
-	(*EventsRequest_Finish)(nil),               // 18: machina.EventsRequest.Finish
-	(*EventsResponse_Event)(nil),               // 19: machina.EventsResponse.Event
-	(*EventsResponse_ApproximateBootTime)(nil), // 20: machina.EventsResponse.ApproximateBootTime
-	(*EventsResponse_Attached)(nil),            // 21: machina.EventsResponse.Attached
-	(*EventsResponse_SummaryStatistics)(nil),   // 22: machina.EventsResponse.SummaryStatistics
-	(*EventsResponse_Detached)(nil),            // 23: machina.EventsResponse.Detached
-	(*LabelRule)(nil),                          // 24: process.LabelRule
-	(*Process)(nil),                            // 25: process.Process
-	(*timestamppb.Timestamp)(nil),              // 26: google.protobuf.Timestamp
-	(*chunkpb.Chunk)(nil),                      // 27: chunk.Chunk
+	(StackMachineTrace_Event_Kind)(0),          // 1: machina.StackMachineTrace.Event.Kind
+	(*WatchProcessesRequest)(nil),              // 2: machina.WatchProcessesRequest
+	(*Update)(nil),                             // 3: machina.Update
+	(*GetExecutableRequest)(nil),               // 4: machina.GetExecutableRequest
+	(*SnapshotRequest)(nil),                    // 5: machina.SnapshotRequest
+	(*GoroutineFilter)(nil),                    // 6: machina.GoroutineFilter
+	(*EventsRequest)(nil),                      // 7: machina.EventsRequest
+	(*ArrowEncodedData)(nil),                   // 8: machina.ArrowEncodedData
+	(*ArrowIpcMessage)(nil),                    // 9: machina.ArrowIpcMessage
+	(*ArrowIpcStream)(nil),                     // 10: machina.ArrowIpcStream
+	(*ArrowIpcStreams)(nil),                    // 11: machina.ArrowIpcStreams
+	(*EventsResponse)(nil),                     // 12: machina.EventsResponse
+	(*SnapshotResponse)(nil),                   // 13: machina.SnapshotResponse
+	(*RuntimeStats)(nil),                       // 14: machina.RuntimeStats
+	(*StackMachineTrace)(nil),                  // 15: machina.StackMachineTrace
+	(*SkippedPointees)(nil),                    // 16: machina.SkippedPointees
+	(*MachinaInfoRequest)(nil),                 // 17: machina.MachinaInfoRequest
+	(*MachinaInfoResponse)(nil),                // 18: machina.MachinaInfoResponse
+	(*SnapshotRequest_Setup)(nil),              // 19: machina.SnapshotRequest.Setup
+	(*SnapshotRequest_Snapshot)(nil),           // 20: machina.SnapshotRequest.Snapshot
+	(*GoroutineFilter_PcRange)(nil),            // 21: machina.GoroutineFilter.PcRange
+	(*GoroutineFilter_LabelMatch)(nil),         // 22: machina.GoroutineFilter.LabelMatch
+	(*EventsRequest_Setup)(nil),                // 23: machina.EventsRequest.Setup
+	(*EventsRequest_Stream)(nil),               // 24: machina.EventsRequest.Stream
+	(*EventsRequest_Finish)(nil),               // 25: machina.EventsRequest.Finish
+	(*EventsResponse_Event)(nil),               // 26: machina.EventsResponse.Event
+	(*EventsResponse_ApproximateBootTime)(nil), // 27: machina.EventsResponse.ApproximateBootTime
+	(*EventsResponse_Attached)(nil),            // 28: machina.EventsResponse.Attached
+	(*EventsResponse_SummaryStatistics)(nil),   // 29: machina.EventsResponse.SummaryStatistics
+	(*EventsResponse_Detached)(nil),            // 30: machina.EventsResponse.Detached
+	nil,                                        // 31: machina.SnapshotResponse.SkippedPointeesEntry
+	(*RuntimeStats_Float64Histogram)(nil),      // 32: machina.RuntimeStats.Float64Histogram
+	(*RuntimeStats_Metric)(nil),                // 33: machina.RuntimeStats.Metric
+	(*RuntimeStats_MemStats)(nil),              // 34: machina.RuntimeStats.MemStats
+	(*StackMachineTrace_Event)(nil),            // 35: machina.StackMachineTrace.Event
+	nil,                                        // 36: machina.StackMachineTrace.OpCountsEntry
+	(*StackMachineTrace_Program)(nil),          // 37: machina.StackMachineTrace.Program
+	nil,                                        // 38: machina.StackMachineTrace.ProgramsEntry
+	(*StackMachineTrace_Op)(nil),               // 39: machina.StackMachineTrace.Op
+	nil,                                        // 40: machina.StackMachineTrace.OpsEntry
+	(*LabelRule)(nil),                          // 41: process.LabelRule
+	(*Process)(nil),                            // 42: process.Process
+	(*timestamppb.Timestamp)(nil),              // 43: google.protobuf.Timestamp
+	(*chunkpb.Chunk)(nil),                      // 44: chunk.Chunk
 }
 var file_machina_proto_depIdxs = []int32{
-	24, // 0: machina.WatchProcessesRequest.label_rules:type_name -> process.LabelRule
-	25, // 1: machina.Update.added:type_name -> process.Process
-	14, // 2: machina.SnapshotRequest.setup:type_name -> machina.SnapshotRequest.Setup
-	15, // 3: machina.SnapshotRequest.snapshot:type_name -> machina.SnapshotRequest.Snapshot
-	16, // 4: machina.EventsRequest.setup:type_name -> machina.EventsRequest.Setup
-	17, // 5: machina.EventsRequest.stream:type_name -> machina.EventsRequest.Stream
-	18, // 6: machina.EventsRequest.finish:type_name -> machina.EventsRequest.Finish
-	0,  // 7: machina.ArrowIpcMessage.kind:type_name -> machina.ArrowIpcMessage.Kind
-	6,  // 8: machina.ArrowIpcMessage.encoded_data:type_name -> machina.ArrowEncodedData
-	7,  // 9: machina.ArrowIpcStream.messages:type_name -> machina.ArrowIpcMessage
-	8,  // 10: machina.ArrowIpcStreams.streams:type_name -> machina.ArrowIpcStream
-	19, // 11: machina.EventsResponse.event:type_name -> machina.EventsResponse.Event
-	20, // 12: machina.EventsResponse.approximate_boot_time:type_name -> machina.EventsResponse.ApproximateBootTime
-	21, // 13: machina.EventsResponse.attached:type_name -> machina.EventsResponse.Attached
-	23, // 14: machina.EventsResponse.detached:type_name -> machina.EventsResponse.Detached
-	9,  // 15: machina.EventsResponse.arrow_streams:type_name -> machina.ArrowIpcStreams
-	26, // 16: machina.SnapshotResponse.timestamp:type_name -> google.protobuf.Timestamp
-	26, // 17: machina.SnapshotResponse.approximate_boot_time:type_name -> google.protobuf.Timestamp
-	22, // 18: machina.EventsResponse.Detached.summary_statistics:type_name -> machina.EventsResponse.SummaryStatistics
-	1,  // 19: machina.Machina.WatchProcesses:input_type -> machina.WatchProcessesRequest
-	3,  // 20: machina.Machina.GetExecutable:input_type -> machina.GetExecutableRequest
-	4,  // 21: machina.Machina.Snapshot:input_type -> machina.SnapshotRequest
-	5,  // 22: machina.Machina.Events:input_type -> machina.EventsRequest
-	12, // 23: machina.Machina.MachinaInfo:input_type -> machina.MachinaInfoRequest
-	2,  // 24: machina.Machina.WatchProcesses:output_type -> machina.Update
-	27, // 25: machina.Machina.GetExecutable:output_type -> chunk.Chunk
-	11, // 26: machina.Machina.Snapshot:output_type -> machina.SnapshotResponse
-	10, // 27: machina.Machina.Events:output_type -> machina.EventsResponse
-	13, // 28: machina.Machina.MachinaInfo:output_type -> machina.MachinaInfoResponse
-	24, // [24:29] is the sub-list for method output_type
-	19, // [19:24] is the sub-list for method input_type
-	19, // [19:19] is the sub-list for extension type_name
-	19, // [19:19] is the sub-list for extension extendee
-	0,  // [0:19] is the sub-list for field type_name
+	41, // 0: machina.WatchProcessesRequest.label_rules:type_name -> process.LabelRule
+	42, // 1: machina.Update.added:type_name -> process.Process
+	19, // 2: machina.SnapshotRequest.setup:type_name -> machina.SnapshotRequest.Setup
+	20, // 3: machina.SnapshotRequest.snapshot:type_name -> machina.SnapshotRequest.Snapshot
+	21, // 4: machina.GoroutineFilter.pc_ranges:type_name -> machina.GoroutineFilter.PcRange
+	22, // 5: machina.GoroutineFilter.labels:type_name -> machina.GoroutineFilter.LabelMatch
+	23, // 6: machina.EventsRequest.setup:type_name -> machina.EventsRequest.Setup
+	24, // 7: machina.EventsRequest.stream:type_name -> machina.EventsRequest.Stream
+	25, // 8: machina.EventsRequest.finish:type_name -> machina.EventsRequest.Finish
+	0,  // 9: machina.ArrowIpcMessage.kind:type_name -> machina.ArrowIpcMessage.Kind
+	8,  // 10: machina.ArrowIpcMessage.encoded_data:type_name -> machina.ArrowEncodedData
+	9,  // 11: machina.ArrowIpcStream.messages:type_name -> machina.ArrowIpcMessage
+	10, // 12: machina.ArrowIpcStreams.streams:type_name -> machina.ArrowIpcStream
+	26, // 13: machina.EventsResponse.event:type_name -> machina.EventsResponse.Event
+	27, // 14: machina.EventsResponse.approximate_boot_time:type_name -> machina.EventsResponse.ApproximateBootTime
+	28, // 15: machina.EventsResponse.attached:type_name -> machina.EventsResponse.Attached
+	30, // 16: machina.EventsResponse.detached:type_name -> machina.EventsResponse.Detached
+	11, // 17: machina.EventsResponse.arrow_streams:type_name -> machina.ArrowIpcStreams
+	43, // 18: machina.SnapshotResponse.timestamp:type_name -> google.protobuf.Timestamp
+	43, // 19: machina.SnapshotResponse.approximate_boot_time:type_name -> google.protobuf.Timestamp
+	31, // 20: machina.SnapshotResponse.skipped_pointees:type_name -> machina.SnapshotResponse.SkippedPointeesEntry
+	15, // 21: machina.SnapshotResponse.trace:type_name -> machina.StackMachineTrace
+	14, // 22: machina.SnapshotResponse.runtime_stats:type_name -> machina.RuntimeStats
+	33, // 23: machina.RuntimeStats.metrics:type_name -> machina.RuntimeStats.Metric
+	34, // 24: machina.RuntimeStats.mem_stats:type_name -> machina.RuntimeStats.MemStats
+	35, // 25: machina.StackMachineTrace.events:type_name -> machina.StackMachineTrace.Event
+	36, // 26: machina.StackMachineTrace.op_counts:type_name -> machina.StackMachineTrace.OpCountsEntry
+	38, // 27: machina.StackMachineTrace.programs:type_name -> machina.StackMachineTrace.ProgramsEntry
+	40, // 28: machina.StackMachineTrace.ops:type_name -> machina.StackMachineTrace.OpsEntry
+	6,  // 29: machina.SnapshotRequest.Snapshot.goroutine_filter:type_name -> machina.GoroutineFilter
+	29, // 30: machina.EventsResponse.Detached.summary_statistics:type_name -> machina.EventsResponse.SummaryStatistics
+	16, // 31: machina.SnapshotResponse.SkippedPointeesEntry.value:type_name -> machina.SkippedPointees
+	32, // 32: machina.RuntimeStats.Metric.float64_histogram:type_name -> machina.RuntimeStats.Float64Histogram
+	1,  // 33: machina.StackMachineTrace.Event.kind:type_name -> machina.StackMachineTrace.Event.Kind
+	37, // 34: machina.StackMachineTrace.ProgramsEntry.value:type_name -> machina.StackMachineTrace.Program
+	39, // 35: machina.StackMachineTrace.OpsEntry.value:type_name -> machina.StackMachineTrace.Op
+	2,  // 36: machina.Machina.WatchProcesses:input_type -> machina.WatchProcessesRequest
+	4,  // 37: machina.Machina.GetExecutable:input_type -> machina.GetExecutableRequest
+	5,  // 38: machina.Machina.Snapshot:input_type -> machina.SnapshotRequest
+	7,  // 39: machina.Machina.Events:input_type -> machina.EventsRequest
+	17, // 40: machina.Machina.MachinaInfo:input_type -> machina.MachinaInfoRequest
+	3,  // 41: machina.Machina.WatchProcesses:output_type -> machina.Update
+	44, // 42: machina.Machina.GetExecutable:output_type -> chunk.Chunk
+	13, // 43: machina.Machina.Snapshot:output_type -> machina.SnapshotResponse
+	12, // 44: machina.Machina.Events:output_type -> machina.EventsResponse
+	18, // 45: machina.Machina.MachinaInfo:output_type -> machina.MachinaInfoResponse
+	41, // [41:46] is the sub-list for method output_type
+	36, // [36:41] is the sub-list for method input_type
+	36, // [36:36] is the sub-list for extension type_name
+	36, // [36:36] is the sub-list for extension extendee
+	0,  // [0:36] is the sub-list for field type_name
 }
 
 func init() { file_machina_proto_init() }
@@ -1850,7 +3201,7 @@ func file_machina_proto_init() {
 			}
 		}
 		file_machina_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EventsRequest); i {
+			switch v := v.(*GoroutineFilter); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1862,7 +3213,7 @@ func file_machina_proto_init() {
 			}
 		}
 		file_machina_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ArrowEncodedData); i {
+			switch v := v.(*EventsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1874,7 +3225,7 @@ func file_machina_proto_init() {
 			}
 		}
 		file_machina_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ArrowIpcMessage); i {
+			switch v := v.(*ArrowEncodedData); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1886,7 +3237,7 @@ func file_machina_proto_init() {
 			}
 		}
 		file_machina_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ArrowIpcStream); i {
+			switch v := v.(*ArrowIpcMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1898,7 +3249,7 @@ func file_machina_proto_init() {
 			}
 		}
 		file_machina_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ArrowIpcStreams); i {
+			switch v := v.(*ArrowIpcStream); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1910,7 +3261,7 @@ func file_machina_proto_init() {
 			}
 		}
 		file_machina_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EventsResponse); i {
+			switch v := v.(*ArrowIpcStreams); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1922,7 +3273,7 @@ func file_machina_proto_init() {
 			}
 		}
 		file_machina_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SnapshotResponse); i {
+			switch v := v.(*EventsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1934,7 +3285,7 @@ func file_machina_proto_init() {
 			}
 		}
 		file_machina_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MachinaInfoRequest); i {
+			switch v := v.(*SnapshotResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1946,7 +3297,7 @@ func file_machina_proto_init() {
 			}
 		}
 		file_machina_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MachinaInfoResponse); i {
+			switch v := v.(*RuntimeStats); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1958,7 +3309,7 @@ func file_machina_proto_init() {
 			}
 		}
 		file_machina_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SnapshotRequest_Setup); i {
+			switch v := v.(*StackMachineTrace); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1970,7 +3321,7 @@ func file_machina_proto_init() {
 			}
 		}
 		file_machina_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SnapshotRequest_Snapshot); i {
+			switch v := v.(*SkippedPointees); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1982,7 +3333,7 @@ func file_machina_proto_init() {
 			}
 		}
 		file_machina_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EventsRequest_Setup); i {
+			switch v := v.(*MachinaInfoRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1994,7 +3345,7 @@ func file_machina_proto_init() {
 			}
 		}
 		file_machina_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EventsRequest_Stream); i {
+			switch v := v.(*MachinaInfoResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2006,7 +3357,7 @@ func file_machina_proto_init() {
 			}
 		}
 		file_machina_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EventsRequest_Finish); i {
+			switch v := v.(*SnapshotRequest_Setup); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2018,7 +3369,7 @@ func file_machina_proto_init() {
 			}
 		}
 		file_machina_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EventsResponse_Event); i {
+			switch v := v.(*SnapshotRequest_Snapshot); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2030,7 +3381,7 @@ func file_machina_proto_init() {
 			}
 		}
 		file_machina_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EventsResponse_ApproximateBootTime); i {
+			switch v := v.(*GoroutineFilter_PcRange); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2042,7 +3393,7 @@ func file_machina_proto_init() {
 			}
 		}
 		file_machina_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EventsResponse_Attached); i {
+			switch v := v.(*GoroutineFilter_LabelMatch); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2054,7 +3405,7 @@ func file_machina_proto_init() {
 			}
 		}
 		file_machina_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EventsResponse_SummaryStatistics); i {
+			switch v := v.(*EventsRequest_Setup); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2066,6 +3417,78 @@ func file_machina_proto_init() {
 			}
 		}
 		file_machina_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EventsRequest_Stream); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_machina_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EventsRequest_Finish); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_machina_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EventsResponse_Event); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_machina_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EventsResponse_ApproximateBootTime); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_machina_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EventsResponse_Attached); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_machina_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EventsResponse_SummaryStatistics); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_machina_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*EventsResponse_Detached); i {
 			case 0:
 				return &v.state
@@ -2077,31 +3500,109 @@ func file_machina_proto_init() {
 				return nil
 			}
 		}
+		file_machina_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RuntimeStats_Float64Histogram); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_machina_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RuntimeStats_Metric); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_machina_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RuntimeStats_MemStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_machina_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StackMachineTrace_Event); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_machina_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StackMachineTrace_Program); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_machina_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StackMachineTrace_Op); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_machina_proto_msgTypes[3].OneofWrappers = []interface{}{
 		(*SnapshotRequest_Setup_)(nil),
 		(*SnapshotRequest_Snapshot_)(nil),
 	}
-	file_machina_proto_msgTypes[4].OneofWrappers = []interface{}{
+	file_machina_proto_msgTypes[5].OneofWrappers = []interface{}{
 		(*EventsRequest_Setup_)(nil),
 		(*EventsRequest_Stream_)(nil),
 		(*EventsRequest_Finish_)(nil),
 	}
-	file_machina_proto_msgTypes[9].OneofWrappers = []interface{}{
+	file_machina_proto_msgTypes[10].OneofWrappers = []interface{}{
 		(*EventsResponse_Event_)(nil),
 		(*EventsResponse_ApproximateBootTime_)(nil),
 		(*EventsResponse_Attached_)(nil),
 		(*EventsResponse_Detached_)(nil),
 		(*EventsResponse_ArrowStreams)(nil),
 	}
-	file_machina_proto_msgTypes[16].OneofWrappers = []interface{}{}
+	file_machina_proto_msgTypes[18].OneofWrappers = []interface{}{}
+	file_machina_proto_msgTypes[22].OneofWrappers = []interface{}{}
+	file_machina_proto_msgTypes[31].OneofWrappers = []interface{}{
+		(*RuntimeStats_Metric_Uint64Value)(nil),
+		(*RuntimeStats_Metric_Float64Value)(nil),
+		(*RuntimeStats_Metric_Float64Histogram)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_machina_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   23,
+			NumEnums:      2,
+			NumMessages:   39,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -38,7 +38,8 @@ type MachinaClient interface {
 	//
 	// Snapshot: Once the headers have been received by the client, the client may
 	// send a Snapshot message to the server. The server will respond with a
-	// stream that has a single SnapshotResponse message.
+	// stream of SnapshotResponse messages. The first message carries the
+	// snapshot metadata, and the data is split across the messages in chunks.
 	//
 	// The protocol may be extended in the future to allow for multiple snapshots.
 	Snapshot(ctx context.Context, opts ...grpc.CallOption) (Machina_SnapshotClient, error)
@@ -259,7 +260,8 @@ type MachinaServer interface {
 	//
 	// Snapshot: Once the headers have been received by the client, the client may
 	// send a Snapshot message to the server. The server will respond with a
-	// stream that has a single SnapshotResponse message.
+	// stream of SnapshotResponse messages. The first message carries the
+	// snapshot metadata, and the data is split across the messages in chunks.
 	//
 	// The protocol may be extended in the future to allow for multiple snapshots.
 	Snapshot(Machina_SnapshotServer) error
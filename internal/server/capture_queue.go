@@ -0,0 +1,121 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// errorDomain is the domain of the ErrorInfo details of the errors
+	// returned by the server.
+	errorDomain = "side-eye.io"
+	// reasonCpuProfilerInUse is the ErrorInfo reason of the captures that
+	// failed because the CPU profiler was in use.
+	reasonCpuProfilerInUse = "CPU_PROFILER_IN_USE"
+	// reasonExecutionTracerInUse is the ErrorInfo reason of the captures that
+	// failed because the execution tracer was in use.
+	reasonExecutionTracerInUse = "EXECUTION_TRACER_IN_USE"
+
+	// inUseRetryInterval is the interval at which the CPU profiler or the
+	// execution tracer are polled while waiting for them to be released.
+	inUseRetryInterval = 100 * time.Millisecond
+)
+
+// inUseError returns the FailedPrecondition error reported when the CPU
+// profiler or the execution tracer is in use, with an ErrorInfo detail carrying
+// the given reason.
+func inUseError(reason string, format string, args ...any) error {
+	st := status.Newf(codes.FailedPrecondition, format, args...)
+	if withDetails, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: reason,
+		Domain: errorDomain,
+	}); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+// startWhenAvailable calls start, which starts the CPU profiler or the
+// execution tracer, until it succeeds or wait elapses. Both only fail if they
+// are already in use.
+func startWhenAvailable(ctx context.Context, wait time.Duration, start func() error) error {
+	deadline := time.Now().Add(wait)
+	for {
+		err := start()
+		if err == nil || !time.Now().Before(deadline) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w (canceled while waiting: %w)", err, context.Cause(ctx))
+		case <-time.After(inUseRetryInterval):
+		}
+	}
+}
+
+// captureQueue serializes captures that use the same profiler, such as the CPU
+// profiler and the execution tracer, in the order in which they arrive.
+type captureQueue struct {
+	mu struct {
+		sync.Mutex
+		// busy is set while a capture runs.
+		busy bool
+		// waiters are the captures waiting for their turn, in order. The
+		// channel of a capture is closed when its turn comes.
+		waiters []chan struct{}
+	}
+}
+
+// acquire waits for the captures queued before this one to complete. It fails
+// if ctx is canceled first. If it succeeds, release must be called once the
+// capture is done.
+func (q *captureQueue) acquire(ctx context.Context) error {
+	q.mu.Lock()
+	if !q.mu.busy {
+		q.mu.busy = true
+		q.mu.Unlock()
+		return nil
+	}
+	turn := make(chan struct{})
+	q.mu.waiters = append(q.mu.waiters, turn)
+	q.mu.Unlock()
+
+	select {
+	case <-turn:
+		return nil
+	case <-ctx.Done():
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if i := slices.Index(q.mu.waiters, turn); i >= 0 {
+		q.mu.waiters = slices.Delete(q.mu.waiters, i, i+1)
+	} else {
+		// Our turn came concurrently with the cancellation; pass it on.
+		q.releaseLocked()
+	}
+	return fmt.Errorf("canceled while waiting for other captures: %w", context.Cause(ctx))
+}
+
+// release ends the current capture and starts the next one in line, if any.
+func (q *captureQueue) release() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.releaseLocked()
+}
+
+func (q *captureQueue) releaseLocked() {
+	if len(q.mu.waiters) == 0 {
+		q.mu.busy = false
+		return
+	}
+	next := q.mu.waiters[0]
+	q.mu.waiters = q.mu.waiters[1:]
+	close(next)
+}
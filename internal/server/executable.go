@@ -0,0 +1,106 @@
+package server
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"sync"
+
+	"github.com/minio/highwayhash"
+)
+
+// executable is the executable file of the process. It is opened once per
+// process, by the first server created, and kept open and shared by all the
+// servers, so that the file served to the Side-Eye service and its hash are
+// not affected by the binary being replaced on disk afterwards.
+type executable struct {
+	file *os.File
+	size int64
+	// err is set if the executable could not be opened.
+	err error
+
+	hash struct {
+		sync.Once
+		hash string
+		err  error
+	}
+}
+
+// processExecutable returns the executable of the process, opening it on the
+// first call.
+var processExecutable = sync.OnceValue(openExecutable)
+
+func openExecutable() *executable {
+	e := &executable{}
+	e.file, e.err = openExecutableFile()
+	if e.err != nil {
+		return e
+	}
+	info, err := e.file.Stat()
+	if err != nil {
+		_ = e.file.Close()
+		e.file, e.err = nil, fmt.Errorf("failed to stat executable: %w", err)
+		return e
+	}
+	e.size = info.Size()
+	return e
+}
+
+func openExecutableFile() (*os.File, error) {
+	// On Linux, /proc/self/exe refers to the file that the process was
+	// started from, even if it has since been replaced or deleted.
+	if runtime.GOOS == "linux" {
+		if f, err := os.Open("/proc/self/exe"); err == nil {
+			return f, nil
+		}
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get executable path: %w", err)
+	}
+	f, err := os.Open(exe)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open executable at %s: %w", exe, err)
+	}
+	return f, nil
+}
+
+var hashKey = [32]byte{}
+
+// getHash returns the hash of the executable, which is computed the first time
+// it is requested.
+func (e *executable) getHash() (string, error) {
+	if e.err != nil {
+		return "", e.err
+	}
+	e.hash.Do(func() {
+		e.hash.hash, e.hash.err = doHash(io.NewSectionReader(e.file, 0, e.size))
+	})
+	return e.hash.hash, e.hash.err
+}
+
+func doHash(r io.Reader) (string, error) {
+	hasher, err := highwayhash.New64(hashKey[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to create hasher: %w", err)
+	}
+	if _, err := io.Copy(hasher, bufio.NewReader(r)); err != nil {
+		return "", fmt.Errorf("failed to hash executable: %w", err)
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// reader returns a reader of the executable from offset to the end. Readers
+// can be used concurrently with each other.
+func (e *executable) reader(offset uint64) (io.Reader, error) {
+	if e.err != nil {
+		return nil, e.err
+	}
+	if offset > uint64(e.size) {
+		return nil, fmt.Errorf("offset %d is past the end of the executable (%d bytes)", offset, e.size)
+	}
+	return io.NewSectionReader(e.file, int64(offset), e.size-int64(offset)), nil
+}
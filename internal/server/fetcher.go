@@ -12,6 +12,7 @@ import (
 
 	"github.com/DataExMachina-dev/side-eye-go/internal/artifactspb"
 	"github.com/DataExMachina-dev/side-eye-go/internal/snapshotpb"
+	"github.com/DataExMachina-dev/side-eye-go/internal/stackmachine"
 )
 
 type SnapshotFetcher interface {
@@ -69,6 +70,11 @@ func (s *cachedSnapshotFetcher) FetchSnapshotProgram(ctx context.Context, key st
 			if err != nil {
 				return nil, err
 			}
+			// Verify the program once, before it is cached, rather than
+			// executing it blindly with the world stopped.
+			if err := stackmachine.Verify(p); err != nil {
+				return nil, fmt.Errorf("invalid snapshot program %s: %w", key, err)
+			}
 			s.mu.Lock()
 			defer s.mu.Unlock()
 			for len(s.mu.cache) >= s.maxCapacity && len(s.mu.cache) > 0 {
@@ -0,0 +1,44 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/DataExMachina-dev/side-eye-go/internal/snapshotpb"
+	"github.com/DataExMachina-dev/side-eye-go/internal/stackmachine"
+)
+
+type staticSnapshotFetcher struct {
+	p     *snapshotpb.SnapshotProgram
+	calls int
+}
+
+func (f *staticSnapshotFetcher) FetchSnapshotProgram(
+	context.Context, string,
+) (*snapshotpb.SnapshotProgram, error) {
+	f.calls++
+	return f.p, nil
+}
+
+func TestCachedSnapshotFetcherRejectsInvalidPrograms(t *testing.T) {
+	invalid := &snapshotpb.SnapshotProgram{
+		PcClassifier: &snapshotpb.PcClassifier{TargetPc: []uint64{0x1000}, ProgPc: []uint32{1}},
+		Prog:         []byte{byte(stackmachine.OpCodeIllegal), byte(stackmachine.OpCodeIllegal)},
+	}
+	underlying := &staticSnapshotFetcher{p: invalid}
+	f := newCachedSnapshotFetcher(underlying, 2)
+	// The program is not cached, so every fetch fetches and rejects it again.
+	for i := 0; i < 2; i++ {
+		p, err := f.FetchSnapshotProgram(context.Background(), "key")
+		if err == nil || !strings.Contains(err.Error(), "invalid snapshot program key") {
+			t.Fatalf("expected a verification error, got %v", err)
+		}
+		if p != nil {
+			t.Fatalf("expected no program, got %v", p)
+		}
+	}
+	if underlying.calls != 2 {
+		t.Fatalf("expected the invalid program to be fetched twice, got %d", underlying.calls)
+	}
+}
@@ -0,0 +1,246 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"runtime"
+	"runtime/pprof"
+	"time"
+
+	"github.com/DataExMachina-dev/side-eye-go/internal/chunkpb"
+	"github.com/DataExMachina-dev/side-eye-go/internal/machinapb"
+
+	"github.com/google/pprof/profile"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UnknownBlockProfileRate is the block profile rate of a process that did not
+// declare it. The runtime does not expose the current rate, so block profiles
+// cannot be captured over a duration in such a process.
+const UnknownBlockProfileRate = -1
+
+const (
+	// captureMutexProfileFraction is the mutex profile fraction used while
+	// capturing a mutex profile: on average, 1 in this many contention events
+	// is reported.
+	captureMutexProfileFraction = 10
+	// captureBlockProfileRate is the block profile rate used while capturing
+	// a block profile: on average, one blocking event per this many
+	// nanoseconds spent blocked is reported.
+	captureBlockProfileRate = 10_000
+)
+
+// pprofProfile describes how a runtime/pprof profile is captured.
+type pprofProfile struct {
+	// name is the name of the profile, as passed to pprof.Lookup.
+	name string
+	// debug is the debug level passed to (*pprof.Profile).WriteTo.
+	debug int
+	// setRate, if set, indicates that the profile is sampled at a rate that
+	// is raised while the profile is captured over a duration.
+	setRate func(s *Server) (restore func())
+}
+
+var pprofProfiles = map[machinapb.CaptureContents]pprofProfile{
+	machinapb.CaptureContents_HEAP_PROFILE:           {name: "heap"},
+	machinapb.CaptureContents_ALLOCS_PROFILE:         {name: "allocs"},
+	machinapb.CaptureContents_MUTEX_PROFILE:          {name: "mutex", setRate: setMutexProfileRate},
+	machinapb.CaptureContents_BLOCK_PROFILE:          {name: "block", setRate: setBlockProfileRate},
+	machinapb.CaptureContents_THREADCREATE_PROFILE:   {name: "threadcreate"},
+	machinapb.CaptureContents_GOROUTINE_PROFILE:      {name: "goroutine"},
+	machinapb.CaptureContents_GOROUTINE_PROFILE_TEXT: {name: "goroutine", debug: 1},
+	machinapb.CaptureContents_GOROUTINE_STACKS_TEXT:  {name: "goroutine", debug: 2},
+}
+
+// profileRates serializes the captures that change the sampling rate of a
+// profile, so that one capture does not restore the rate while another one
+// relies on it. Every profile has its own queue.
+type profileRates struct {
+	mutex, block captureQueue
+}
+
+// setMutexProfileRate raises the mutex profile fraction, unless it is already
+// at least as high. The fraction is only restored if the program did not
+// change it during the capture.
+func setMutexProfileRate(s *Server) (restore func()) {
+	prev := runtime.SetMutexProfileFraction(-1)
+	if prev > 0 && prev <= captureMutexProfileFraction {
+		return func() {}
+	}
+	runtime.SetMutexProfileFraction(captureMutexProfileFraction)
+	return func() {
+		if runtime.SetMutexProfileFraction(-1) == captureMutexProfileFraction {
+			runtime.SetMutexProfileFraction(prev)
+		}
+	}
+}
+
+// setBlockProfileRate raises the block profile rate, unless it is already at
+// least as high. The runtime does not expose the current rate, so it is
+// restored to the rate declared for the server, which must be known, and a
+// rate set by the program during the capture is overwritten.
+func setBlockProfileRate(s *Server) (restore func()) {
+	prev := s.blockProfileRate
+	if prev > 0 && prev <= captureBlockProfileRate {
+		return func() {}
+	}
+	runtime.SetBlockProfileRate(captureBlockProfileRate)
+	return func() { runtime.SetBlockProfileRate(prev) }
+}
+
+// rateQueue returns the queue that serializes the captures of the profile that
+// change its sampling rate.
+func (s *Server) rateQueue(contents machinapb.CaptureContents) *captureQueue {
+	if contents == machinapb.CaptureContents_MUTEX_PROFILE {
+		return &s.profileRates.mutex
+	}
+	return &s.profileRates.block
+}
+
+// runProfile captures the runtime/pprof profile requested and streams it to
+// the client.
+func (s *Server) runProfile(
+	ctx context.Context, request *machinapb.CaptureRequest, serializer *sendSerializer,
+) error {
+	p, ok := pprofProfiles[request.Contents]
+	if !ok {
+		return status.Errorf(codes.InvalidArgument, "unsupported capture contents: %s", request.Contents)
+	}
+	var duration time.Duration
+	if p.setRate != nil {
+		duration = time.Second * time.Duration(request.Seconds)
+	}
+	if duration > 0 && request.Contents == machinapb.CaptureContents_BLOCK_PROFILE &&
+		s.blockProfileRate == UnknownBlockProfileRate {
+		return status.Errorf(
+			codes.FailedPrecondition,
+			"the block profile rate of the process is unknown; declare it with sideeye.WithBlockProfileRate",
+		)
+	}
+	s.loggers.InfoLogger("starting %s profile for %s", p.name, duration)
+	defer s.loggers.InfoLogger("%s profile complete", p.name)
+
+	if duration > 0 {
+		// Captures of the same profile wait for each other.
+		q := s.rateQueue(request.Contents)
+		if err := q.acquire(ctx); err != nil {
+			return status.FromContextError(err).Err()
+		}
+		defer q.release()
+	}
+
+	msg := &machinapb.CaptureResponse{
+		Message: &machinapb.CaptureResponse_ProfileStart_{
+			ProfileStart: &machinapb.CaptureResponse_ProfileStart{
+				Contents:        request.Contents,
+				DurationSeconds: uint32(duration / time.Second),
+			},
+		},
+	}
+	if err := serializer.Send(msg); err != nil {
+		return fmt.Errorf("failed to send %s profile start: %w", p.name, err)
+	}
+
+	var data []byte
+	var err error
+	if duration > 0 {
+		data, err = s.captureDeltaProfile(ctx, p, duration)
+	} else {
+		data, err = writeProfile(p.name, p.debug)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := sendChunks(serializer, data, func(c *chunkpb.Chunk) *machinapb.CaptureResponse {
+		return &machinapb.CaptureResponse{
+			Message: &machinapb.CaptureResponse_ProfileChunk{ProfileChunk: c},
+		}
+	}); err != nil {
+		return fmt.Errorf("failed to send %s profile chunk: %w", p.name, err)
+	}
+
+	msg = &machinapb.CaptureResponse{
+		Message: &machinapb.CaptureResponse_ProfileComplete_{
+			ProfileComplete: &machinapb.CaptureResponse_ProfileComplete{},
+		},
+	}
+	if err := serializer.Send(msg); err != nil {
+		return fmt.Errorf("failed to send %s profile complete: %w", p.name, err)
+	}
+	return nil
+}
+
+// captureDeltaProfile raises the sampling rate of the profile for duration and
+// returns the difference between the profile at the end and at the start of
+// the duration.
+func (s *Server) captureDeltaProfile(
+	ctx context.Context, p pprofProfile, duration time.Duration,
+) ([]byte, error) {
+	restore := p.setRate(s)
+	defer restore()
+	before, err := writeProfile(p.name, 0 /* debug */)
+	if err != nil {
+		return nil, err
+	}
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("%s profiling canceled: %w", p.name, context.Cause(ctx))
+	case <-time.After(duration):
+	}
+	after, err := writeProfile(p.name, 0 /* debug */)
+	if err != nil {
+		return nil, err
+	}
+	return deltaProfile(before, after)
+}
+
+// writeProfile returns the named runtime/pprof profile.
+func writeProfile(name string, debug int) ([]byte, error) {
+	prof := pprof.Lookup(name)
+	if prof == nil {
+		return nil, fmt.Errorf("unknown profile: %s", name)
+	}
+	var buf bytes.Buffer
+	if err := prof.WriteTo(&buf, debug); err != nil {
+		return nil, fmt.Errorf("failed to write %s profile: %w", name, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// deltaProfile returns the profile of the samples accumulated between the
+// encoded profiles before and after. Samples that did not change are dropped.
+func deltaProfile(before, after []byte) ([]byte, error) {
+	p0, err := profile.ParseData(before)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse profile: %w", err)
+	}
+	p1, err := profile.ParseData(after)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse profile: %w", err)
+	}
+	p0.Scale(-1)
+	delta, err := profile.Merge([]*profile.Profile{p0, p1})
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge profiles: %w", err)
+	}
+	samples := delta.Sample[:0]
+	for _, sample := range delta.Sample {
+		for _, v := range sample.Value {
+			if v != 0 {
+				samples = append(samples, sample)
+				break
+			}
+		}
+	}
+	delta.Sample = samples
+	delta.TimeNanos = p1.TimeNanos
+	delta.DurationNanos = p1.TimeNanos - p0.TimeNanos
+	var buf bytes.Buffer
+	if err := delta.Write(&buf); err != nil {
+		return nil, fmt.Errorf("failed to write profile: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,277 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DataExMachina-dev/side-eye-go/internal/machinapb"
+	"github.com/DataExMachina-dev/side-eye-go/internal/snapshot"
+
+	"github.com/google/pprof/profile"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// captureStream collects the responses sent by Capture.
+type captureStream struct {
+	grpc.ServerStream
+	ctx       context.Context
+	responses []*machinapb.CaptureResponse
+}
+
+func (c *captureStream) Context() context.Context { return c.ctx }
+
+func (c *captureStream) Send(msg *machinapb.CaptureResponse) error {
+	c.responses = append(c.responses, msg)
+	return nil
+}
+
+func newTestServer() *Server {
+	return NewServer(
+		uuid.New(), "process", "token", "env", "program", nil, /* fetcher */
+		false /* ephemeralProcess */, snapshot.Options{}, 0, /* blockProfileRate */
+		nil /* flightRecorder */, Loggers{},
+	)
+}
+
+// capture runs a Capture of contents and returns the profile data streamed.
+func capture(
+	t *testing.T, s *Server, contents machinapb.CaptureContents, seconds uint32,
+) []byte {
+	t.Helper()
+	stream := &captureStream{ctx: context.Background()}
+	if err := s.Capture(&machinapb.CaptureRequest{
+		ProcessFingerprint: "process",
+		Seconds:            seconds,
+		Contents:           contents,
+	}, stream); err != nil {
+		t.Fatalf("Capture(%s) failed: %v", contents, err)
+	}
+	if len(stream.responses) < 2 {
+		t.Fatalf("expected at least 2 responses, got %d", len(stream.responses))
+	}
+	start := stream.responses[0].GetProfileStart()
+	if start == nil || start.Contents != contents {
+		t.Fatalf("expected a ProfileStart for %s, got %v", contents, stream.responses[0])
+	}
+	if stream.responses[len(stream.responses)-1].GetProfileComplete() == nil {
+		t.Fatalf("expected a ProfileComplete, got %v", stream.responses[len(stream.responses)-1])
+	}
+	var data []byte
+	for _, r := range stream.responses[1 : len(stream.responses)-1] {
+		chunk := r.GetProfileChunk()
+		if chunk == nil {
+			t.Fatalf("expected a ProfileChunk, got %v", r)
+		}
+		data = append(data, chunk.Data...)
+	}
+	return data
+}
+
+func TestCaptureProfiles(t *testing.T) {
+	s := newTestServer()
+	for _, contents := range []machinapb.CaptureContents{
+		machinapb.CaptureContents_HEAP_PROFILE,
+		machinapb.CaptureContents_ALLOCS_PROFILE,
+		machinapb.CaptureContents_THREADCREATE_PROFILE,
+		machinapb.CaptureContents_GOROUTINE_PROFILE,
+	} {
+		data := capture(t, s, contents, 0)
+		if _, err := profile.ParseData(data); err != nil {
+			t.Errorf("failed to parse %s: %v", contents, err)
+		}
+	}
+	text := string(capture(t, s, machinapb.CaptureContents_GOROUTINE_PROFILE_TEXT, 0))
+	if !strings.HasPrefix(text, "goroutine profile: total") {
+		t.Errorf("unexpected goroutine profile text:\n%s", text)
+	}
+	stacks := string(capture(t, s, machinapb.CaptureContents_GOROUTINE_STACKS_TEXT, 0))
+	if !strings.Contains(stacks, "TestCaptureProfiles") {
+		t.Errorf("expected the stacks to contain the test:\n%s", stacks)
+	}
+}
+
+func TestCaptureMutexProfile(t *testing.T) {
+	prev := runtime.SetMutexProfileFraction(0)
+	defer runtime.SetMutexProfileFraction(prev)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+	var mu sync.Mutex
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				mu.Lock()
+				time.Sleep(10 * time.Microsecond)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	s := newTestServer()
+	data := capture(t, s, machinapb.CaptureContents_MUTEX_PROFILE, 1)
+	p, err := profile.ParseData(data)
+	if err != nil {
+		t.Fatalf("failed to parse the mutex profile: %v", err)
+	}
+	if len(p.Sample) == 0 {
+		t.Errorf("expected the mutex profile to have samples")
+	}
+	if p.DurationNanos < int64(time.Second) {
+		t.Errorf("expected the profile to cover at least 1s, got %s", time.Duration(p.DurationNanos))
+	}
+	if got := runtime.SetMutexProfileFraction(-1); got != 0 {
+		t.Errorf("expected the mutex profile fraction to be restored to 0, got %d", got)
+	}
+}
+
+func TestSetMutexProfileRate(t *testing.T) {
+	prev := runtime.SetMutexProfileFraction(0)
+	defer runtime.SetMutexProfileFraction(prev)
+	s := newTestServer()
+
+	// A fraction that is already high enough is left alone.
+	runtime.SetMutexProfileFraction(captureMutexProfileFraction / 2)
+	restore := setMutexProfileRate(s)
+	if got := runtime.SetMutexProfileFraction(-1); got != captureMutexProfileFraction/2 {
+		t.Errorf("expected the mutex profile fraction to be left at %d, got %d", captureMutexProfileFraction/2, got)
+	}
+	runtime.SetMutexProfileFraction(1)
+	restore()
+	if got := runtime.SetMutexProfileFraction(-1); got != 1 {
+		t.Errorf("expected the fraction set during the capture to be kept, got %d", got)
+	}
+
+	// A fraction that the program changes during the capture is not
+	// restored.
+	runtime.SetMutexProfileFraction(0)
+	restore = setMutexProfileRate(s)
+	if got := runtime.SetMutexProfileFraction(-1); got != captureMutexProfileFraction {
+		t.Errorf("expected the mutex profile fraction to be raised to %d, got %d", captureMutexProfileFraction, got)
+	}
+	runtime.SetMutexProfileFraction(2)
+	restore()
+	if got := runtime.SetMutexProfileFraction(-1); got != 2 {
+		t.Errorf("expected the fraction set during the capture to be kept, got %d", got)
+	}
+}
+
+func TestCaptureBlockProfileConcurrent(t *testing.T) {
+	s := newTestServer()
+	request := &machinapb.CaptureRequest{
+		ProcessFingerprint: "process",
+		Seconds:            1,
+		Contents:           machinapb.CaptureContents_BLOCK_PROFILE,
+	}
+
+	// Concurrent captures of the block profile wait for each other, rather
+	// than fail.
+	start := time.Now()
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = s.Capture(request, &captureStream{ctx: context.Background()})
+		}()
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("failed to capture the block profile: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 2*time.Second {
+		t.Errorf("expected the captures to run one after the other, took %s", elapsed)
+	}
+
+	// A capture waiting for its turn gives up when it is canceled.
+	if err := s.profileRates.block.acquire(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer s.profileRates.block.release()
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err := s.Capture(request, &captureStream{ctx: ctx})
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Fatalf("expected DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestCaptureBlockProfileUnknownRate(t *testing.T) {
+	s := NewServer(
+		uuid.New(), "process", "token", "env", "program", nil, /* fetcher */
+		false /* ephemeralProcess */, snapshot.Options{}, UnknownBlockProfileRate,
+		nil /* flightRecorder */, Loggers{},
+	)
+	// Raising the rate would clobber the rate set by the program, which
+	// could not be restored.
+	err := s.Capture(&machinapb.CaptureRequest{
+		ProcessFingerprint: "process",
+		Seconds:            1,
+		Contents:           machinapb.CaptureContents_BLOCK_PROFILE,
+	}, &captureStream{ctx: context.Background()})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition, got %v", err)
+	}
+	// The profile can still be captured as is.
+	data := capture(t, s, machinapb.CaptureContents_BLOCK_PROFILE, 0)
+	if _, err := profile.ParseData(data); err != nil {
+		t.Errorf("failed to parse the block profile: %v", err)
+	}
+}
+
+func TestDeltaProfile(t *testing.T) {
+	fn := &profile.Function{ID: 1, Name: "f"}
+	loc := &profile.Location{ID: 1, Line: []profile.Line{{Function: fn}}}
+	fn2 := &profile.Function{ID: 2, Name: "g"}
+	loc2 := &profile.Location{ID: 2, Line: []profile.Line{{Function: fn2}}}
+	mk := func(timeNanos int64, f, g int64) []byte {
+		p := &profile.Profile{
+			SampleType: []*profile.ValueType{{Type: "contentions", Unit: "count"}},
+			Sample: []*profile.Sample{
+				{Location: []*profile.Location{loc}, Value: []int64{f}},
+				{Location: []*profile.Location{loc2}, Value: []int64{g}},
+			},
+			Location:  []*profile.Location{loc, loc2},
+			Function:  []*profile.Function{fn, fn2},
+			TimeNanos: timeNanos,
+		}
+		var buf bytes.Buffer
+		if err := p.Write(&buf); err != nil {
+			t.Fatal(err)
+		}
+		return buf.Bytes()
+	}
+	data, err := deltaProfile(mk(100, 3, 5), mk(250, 7, 5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := profile.ParseData(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.TimeNanos != 250 || p.DurationNanos != 150 {
+		t.Errorf("unexpected time %d and duration %d", p.TimeNanos, p.DurationNanos)
+	}
+	if len(p.Sample) != 1 {
+		t.Fatalf("expected 1 sample, got %d", len(p.Sample))
+	}
+	if name := p.Sample[0].Location[0].Line[0].Function.Name; name != "f" || p.Sample[0].Value[0] != 4 {
+		t.Errorf("unexpected sample %s: %v", name, p.Sample[0].Value)
+	}
+}
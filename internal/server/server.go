@@ -1,10 +1,8 @@
 package server
 
 import (
-	"bufio"
 	"bytes"
 	"context"
-	"encoding/hex"
 	"errors"
 	"fmt"
 	"github.com/DataExMachina-dev/side-eye-go/internal/boottime"
@@ -18,11 +16,12 @@ import (
 	"time"
 
 	"github.com/DataExMachina-dev/side-eye-go/internal/chunkpb"
+	"github.com/DataExMachina-dev/side-eye-go/internal/flightrecorder"
+	"github.com/DataExMachina-dev/side-eye-go/internal/framing"
 	"github.com/DataExMachina-dev/side-eye-go/internal/machinapb"
 	"github.com/DataExMachina-dev/side-eye-go/internal/snapshot"
 
 	"github.com/google/uuid"
-	"github.com/minio/highwayhash"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -45,8 +44,22 @@ type Server struct {
 	// Side-Eye UI.
 	ephemeralProcess bool
 	fetcher          SnapshotFetcher
-
-	hash binaryHashOnce
+	// snapshotOptions configure the snapshots taken by this server.
+	snapshotOptions snapshot.Options
+	// arenas holds the snapshot arena for reuse across snapshots, so that
+	// periodic snapshots do not allocate with the world stopped.
+	arenas arenaCache
+	// blockProfileRate is the block profile rate of the process, restored
+	// after a block profile is captured, or UnknownBlockProfileRate.
+	blockProfileRate int
+	profileRates     profileRates
+	// flightRecorder is the flight recorder of the process, if enabled.
+	flightRecorder *flightrecorder.Recorder
+	// captures serializes the captures of execution traces and CPU profiles.
+	captures captureQueue
+
+	// exe is the executable of the process, served by GetExecutable.
+	exe *executable
 
 	loggers Loggers
 
@@ -57,10 +70,29 @@ type Server struct {
 var _ machinapb.MachinaServer = (*Server)(nil)
 var _ machinapb.GoPprofServer = (*Server)(nil)
 
-type binaryHashOnce struct {
-	sync.Once
-	hash string
-	err  error
+// arenaCache retains a snapshot.Arena between snapshots. Only one snapshot
+// can stop the world at a time, so a single arena covers the common case;
+// snapshots that overlap with another allocate their own.
+type arenaCache struct {
+	mu    sync.Mutex
+	arena *snapshot.Arena
+}
+
+func (c *arenaCache) get() *snapshot.Arena {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	a := c.arena
+	c.arena = nil
+	if a == nil {
+		a = snapshot.NewArena()
+	}
+	return a
+}
+
+func (c *arenaCache) put(a *snapshot.Arena) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.arena = a
 }
 
 type Loggers struct {
@@ -77,6 +109,9 @@ func NewServer(
 	programName string,
 	fetcher SnapshotFetcher,
 	ephemeralProcess bool,
+	snapshotOptions snapshot.Options,
+	blockProfileRate int,
+	flightRecorder *flightrecorder.Recorder,
 	loggers Loggers,
 ) *Server {
 	if loggers.ErrorLogger == nil {
@@ -93,39 +128,55 @@ func NewServer(
 		programName:        programName,
 		fetcher:            fetcher,
 		ephemeralProcess:   ephemeralProcess,
+		snapshotOptions:    snapshotOptions,
+		blockProfileRate:   blockProfileRate,
+		flightRecorder:     flightRecorder,
+		exe:                processExecutable(),
 		loggers:            loggers,
 	}
 }
 
 // GetExecutable implements machinapb.MachinaServer.
+//
+// The executable is the file that the process was started from, which is
+// opened once per process, when the first server is created. If the request
+// has a hash, it must match the hash of the executable.
 func (s *Server) GetExecutable(req *machinapb.GetExecutableRequest, stream machinapb.Machina_GetExecutableServer) error {
-	exe, err := os.Executable()
-	if err != nil {
-		return fmt.Errorf("failed to get executable path: %w", err)
+	if req.Hash != "" {
+		hash, err := s.getBinaryHash()
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to get binary hash: %v", err)
+		}
+		if hash != req.Hash {
+			return status.Errorf(
+				codes.FailedPrecondition,
+				"executable hash mismatch: got %s, want %s", req.Hash, hash,
+			)
+		}
 	}
-	exeFile, err := os.Open(exe)
+	r, err := s.exe.reader(req.Offset)
 	if err != nil {
-		return fmt.Errorf("failed to open executable at %s: %w", exe, err)
+		return status.Errorf(codes.OutOfRange, "failed to read executable: %v", err)
 	}
-	defer exeFile.Close()
 	const chunkSize = 128 << 10
 	chunk := chunkpb.Chunk{
 		Data: make([]byte, chunkSize),
 	}
 	for {
-		n, err := exeFile.Read(chunk.Data[:chunkSize])
-		if errors.Is(err, io.EOF) {
-			break
+		n, err := io.ReadFull(r, chunk.Data[:chunkSize])
+		if n > 0 {
+			chunk.Data = chunk.Data[:n]
+			if err := stream.Send(&chunk); err != nil {
+				return fmt.Errorf("failed to send executable: %w", err)
+			}
 		}
-		if err != nil {
-			return fmt.Errorf("failed to read executable from %s: %w", exe, err)
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil
 		}
-		chunk.Data = chunk.Data[:n]
-		if err := stream.Send(&chunk); err != nil {
-			return fmt.Errorf("failed to send executable: %w", err)
+		if err != nil {
+			return fmt.Errorf("failed to read executable: %w", err)
 		}
 	}
-	return nil
 }
 
 func ipAddresses() []string {
@@ -198,17 +249,58 @@ func (s *Server) Snapshot(stream machinapb.Machina_SnapshotServer) (err error) {
 	if err != nil {
 		return fmt.Errorf("failed to receive SnapshotRequest: %w", err)
 	}
-	if _, ok := msg.Request.(*machinapb.SnapshotRequest_Snapshot_); !ok {
+	snapshotReq, ok := msg.Request.(*machinapb.SnapshotRequest_Snapshot_)
+	if !ok {
 		return fmt.Errorf("expected SnapshotRequest_Snapshot_ but got %T", msg.Request)
 	}
-	output, err := snapshot.Snapshot(snapshotProgram)
+	opts := s.snapshotOptions
+	opts.GoroutineFilter = snapshotReq.Snapshot.GoroutineFilter
+	if snapshotReq.Snapshot.MaxPauseNs != nil {
+		opts.MaxPause = time.Duration(*snapshotReq.Snapshot.MaxPauseNs)
+	}
+	if snapshotReq.Snapshot.MaxStackPauseNs != nil {
+		opts.MaxStackPause = time.Duration(*snapshotReq.Snapshot.MaxStackPauseNs)
+	}
+	opts.Trace = opts.Trace || snapshotReq.Snapshot.Trace
+	opts.FramingVersion = snapshotReq.Snapshot.FramingVersion
+	// The snapshot data aliases the arena, so it can only be released once the
+	// response has been sent.
+	opts.Arena = s.arenas.get()
+	defer s.arenas.put(opts.Arena)
+	output, err := snapshot.Snapshot(snapshotProgram, opts)
 	if err != nil {
 		return status.Errorf(codes.Internal, "failed to snapshot: %v", err)
 	}
-	if err := stream.Send(output); err != nil {
-		return fmt.Errorf("failed to send SnapshotResponse: %w", err)
+	// Clients reassemble chunked snapshots from framing version 1.
+	chunked := snapshotReq.Snapshot.FramingVersion >= framing.Version1
+	return sendSnapshotResponse(stream, output, chunked)
+}
+
+// sendSnapshotResponse sends the snapshot to the client. If chunked, the data
+// is split into chunks so that large snapshots don't hit gRPC's maximum
+// message size, and the first message carries the metadata along with the
+// first chunk. Otherwise, the snapshot is sent in a single message.
+func sendSnapshotResponse(
+	stream machinapb.Machina_SnapshotServer, output *machinapb.SnapshotResponse, chunked bool,
+) error {
+	const chunkSize = 1 << 20
+	data := output.Data
+	msg := output
+	for {
+		chunkLen := chunkSize
+		if !chunked || chunkLen > len(data) {
+			chunkLen = len(data)
+		}
+		msg.Data = data[:chunkLen]
+		data = data[chunkLen:]
+		if err := stream.Send(msg); err != nil {
+			return fmt.Errorf("failed to send SnapshotResponse: %w", err)
+		}
+		if len(data) == 0 {
+			return nil
+		}
+		msg = &machinapb.SnapshotResponse{}
 	}
-	return nil
 }
 
 // WatchProcesses implements machinapb.MachinaServer.
@@ -251,32 +343,7 @@ func (s *Server) WatchProcesses(req *machinapb.WatchProcessesRequest, watchServe
 }
 
 func (s *Server) getBinaryHash() (string, error) {
-	s.hash.Once.Do(func() {
-		s.hash.hash, s.hash.err = doHash()
-	})
-	return s.hash.hash, s.hash.err
-}
-
-var hashKey = [32]byte{}
-
-func doHash() (string, error) {
-	exe, err := os.Executable()
-	if err != nil {
-		return "", fmt.Errorf("failed to get executable path: %w", err)
-	}
-	exeFile, err := os.Open(exe)
-	if err != nil {
-		return "", fmt.Errorf("failed to open executable file at %s: %w", exe, err)
-	}
-	defer exeFile.Close()
-	hasher, err := highwayhash.New64(hashKey[:])
-	if err != nil {
-		return "", fmt.Errorf("failed to create hasher: %w", err)
-	}
-	if _, err := io.Copy(hasher, bufio.NewReader(exeFile)); err != nil {
-		return "", fmt.Errorf("failed to hash executable: %w", err)
-	}
-	return hex.EncodeToString(hasher.Sum(nil)), nil
+	return s.exe.getHash()
 }
 
 // Capture implements machinapb.GoPprofServer interface.
@@ -290,30 +357,55 @@ func (s *Server) Capture(request *machinapb.CaptureRequest, server machinapb.GoP
 	}
 
 	serializer := newSendSerializer(server)
+	if _, ok := pprofProfiles[request.Contents]; ok {
+		return s.runProfile(server.Context(), request, serializer)
+	}
+	if request.Contents == machinapb.CaptureContents_FLIGHT_RECORDER_TRACE {
+		return s.dumpFlightRecorder(serializer)
+	}
+	if s.flightRecorder != nil && flightrecorder.ExclusiveTracing {
+		return inUseError(
+			reasonExecutionTracerInUse,
+			"execution traces cannot be captured while the flight recorder is enabled before go1.25; "+
+				"dump the flight recorder instead",
+		)
+	}
+
+	// Captures are serialized, so that they don't fail because of each other.
+	if err := s.captures.acquire(server.Context()); err != nil {
+		return status.FromContextError(err).Err()
+	}
+	defer s.captures.release()
+
+	wait := time.Second * time.Duration(request.InUseWaitSeconds)
 	g, ctx := errgroup.WithContext(server.Context())
 	explicitCpuProfile := request.Contents == machinapb.CaptureContents_EXECUTION_TRACE_AND_CPU_PROFILE
 	if explicitCpuProfile {
 		g.Go(func() error {
-			return s.runCpuProfile(ctx, time.Second*time.Duration(request.Seconds), serializer)
+			return s.runCpuProfile(ctx, time.Second*time.Duration(request.Seconds), wait, serializer)
 		})
 	}
 	g.Go(func() error {
 		// If the CPU profile was not explicitly requested, we still want to start a
 		// CPU profile, in order for its data to be automatically included in the
-		// execution trace.
+		// execution trace. If the CPU profiler is already in use, its data is
+		// included all the same.
 		if !explicitCpuProfile {
-			if err := pprof.StartCPUProfile(nilWriter{}); err != nil {
-				return fmt.Errorf("failed to start CPU profile: %w", err)
+			if err := pprof.StartCPUProfile(nilWriter{}); err == nil {
+				defer pprof.StopCPUProfile()
 			}
-			defer pprof.StopCPUProfile()
 		}
-		return s.runExecutionTrace(ctx, time.Second*time.Duration(request.Seconds), serializer)
+		return s.runExecutionTrace(ctx, time.Second*time.Duration(request.Seconds), wait, serializer)
 	})
 
 	return g.Wait()
 }
 
-func (s *Server) runCpuProfile(ctx context.Context, duration time.Duration, serializer *sendSerializer) error {
+// runCpuProfile captures a CPU profile for duration. If the CPU profiler is
+// already in use, it waits for up to wait for it to be released.
+func (s *Server) runCpuProfile(
+	ctx context.Context, duration, wait time.Duration, serializer *sendSerializer,
+) error {
 	s.loggers.InfoLogger("starting CPU profile for %s", duration)
 	defer s.loggers.InfoLogger("CPU profile complete")
 
@@ -321,8 +413,10 @@ func (s *Server) runCpuProfile(ctx context.Context, duration time.Duration, seri
 	// Note: nothing gets written to profileBuf until pprof.StopCPUProfile() is
 	// called; the profile proto is not streamable. We'll read the whole buffer
 	// after the profile is done.
-	if err := pprof.StartCPUProfile(profileBuf); err != nil {
-		return fmt.Errorf("failed to start CPU profile: %w", err)
+	if err := startWhenAvailable(ctx, wait, func() error {
+		return pprof.StartCPUProfile(profileBuf)
+	}); err != nil {
+		return inUseError(reasonCpuProfilerInUse, "failed to start CPU profile: %v", err)
 	}
 	msg := &machinapb.CaptureResponse{
 		Message: &machinapb.CaptureResponse_CpuProfileStart_{
@@ -350,24 +444,12 @@ func (s *Server) runCpuProfile(ctx context.Context, duration time.Duration, seri
 	pprof.StopCPUProfile()
 	stop = nil // inhibit the deferred call
 
-	// Send chunk by chunk from profileBuf. We send in chunk to not hit gRPC's
-	// maximum message size.
-	const chunkSize = 64 << 10
-	data := profileBuf.Bytes()
-	for len(data) > 0 {
-		chunkLen := chunkSize
-		if chunkLen > len(data) {
-			chunkLen = len(data)
+	if err := sendChunks(serializer, profileBuf.Bytes(), func(c *chunkpb.Chunk) *machinapb.CaptureResponse {
+		return &machinapb.CaptureResponse{
+			Message: &machinapb.CaptureResponse_CpuProfileChunk{CpuProfileChunk: c},
 		}
-		msg := &machinapb.CaptureResponse{
-			Message: &machinapb.CaptureResponse_CpuProfileChunk{
-				CpuProfileChunk: &chunkpb.Chunk{Data: data[:chunkLen]},
-			},
-		}
-		if err := serializer.Send(msg); err != nil {
-			return fmt.Errorf("failed to send CPU profile chunk: %w", err)
-		}
-		data = data[chunkLen:]
+	}); err != nil {
+		return fmt.Errorf("failed to send CPU profile chunk: %w", err)
 	}
 
 	msg = &machinapb.CaptureResponse{
@@ -382,27 +464,101 @@ func (s *Server) runCpuProfile(ctx context.Context, duration time.Duration, seri
 	return nil
 }
 
-func (s *Server) runExecutionTrace(ctx context.Context, duration time.Duration, serializer *sendSerializer) error {
-	s.loggers.InfoLogger("starting execution trace for %s", duration)
-	defer s.loggers.InfoLogger("execution trace complete")
-
-	reader, writer := io.Pipe()
-	if err := trace.Start(writer); err != nil {
-		return fmt.Errorf("failed to start CPU profile: %w", err)
+// sendChunks sends data chunk by chunk, each wrapped into a message by wrap. We
+// send in chunks to not hit gRPC's maximum message size.
+func sendChunks(
+	serializer *sendSerializer, data []byte, wrap func(*chunkpb.Chunk) *machinapb.CaptureResponse,
+) error {
+	const chunkSize = 64 << 10
+	for len(data) > 0 {
+		chunkLen := chunkSize
+		if chunkLen > len(data) {
+			chunkLen = len(data)
+		}
+		if err := serializer.Send(wrap(&chunkpb.Chunk{Data: data[:chunkLen]})); err != nil {
+			return err
+		}
+		data = data[chunkLen:]
 	}
+	return nil
+}
 
+// executionTraceStart returns the message that starts an execution trace of the
+// given duration, which starts at the given time.
+func executionTraceStart(start time.Time, duration time.Duration) (*machinapb.CaptureResponse, error) {
 	boot, err := boottime.BootTime()
 	if err != nil {
-		return fmt.Errorf("failed to get boot time: %w", err)
+		return nil, fmt.Errorf("failed to get boot time: %w", err)
 	}
-	msg := &machinapb.CaptureResponse{
+	return &machinapb.CaptureResponse{
 		Message: &machinapb.CaptureResponse_ExecutionTraceStart_{
 			ExecutionTraceStart: &machinapb.CaptureResponse_ExecutionTraceStart{
 				ApproximateBootTime: &timestamppb.Timestamp{Seconds: boot.Unix(), Nanos: int32(boot.UnixNano() % 1e9)},
-				TraceStartMonotonic: uint64(time.Since(boot)),
+				TraceStartMonotonic: uint64(start.Sub(boot)),
 				DurationSeconds:     uint32(duration / time.Second),
 			},
 		},
+	}, nil
+}
+
+// dumpFlightRecorder streams the execution trace kept by the flight recorder
+// to the client, framed like the execution traces of runExecutionTrace.
+func (s *Server) dumpFlightRecorder(serializer *sendSerializer) error {
+	if s.flightRecorder == nil {
+		return status.Errorf(codes.FailedPrecondition, "the flight recorder is not enabled")
+	}
+	s.loggers.InfoLogger("dumping the flight recorder")
+	defer s.loggers.InfoLogger("flight recorder dump complete")
+
+	var buf bytes.Buffer
+	span, err := s.flightRecorder.Dump(&buf)
+	if err != nil {
+		if errors.Is(err, flightrecorder.ErrNoData) {
+			return status.Error(codes.Unavailable, err.Error())
+		}
+		return fmt.Errorf("failed to dump the flight recorder: %w", err)
+	}
+	// The trace covers the past, so the start message reports the span that
+	// the recorder actually retained rather than the current time.
+	msg, err := executionTraceStart(span.Start, span.End.Sub(span.Start))
+	if err != nil {
+		return err
+	}
+	if err := serializer.Send(msg); err != nil {
+		return err
+	}
+	if err := sendChunks(serializer, buf.Bytes(), func(c *chunkpb.Chunk) *machinapb.CaptureResponse {
+		return &machinapb.CaptureResponse{
+			Message: &machinapb.CaptureResponse_ExecutionTraceChunk{ExecutionTraceChunk: c},
+		}
+	}); err != nil {
+		return fmt.Errorf("failed to send flight recorder chunk: %w", err)
+	}
+	return serializer.Send(&machinapb.CaptureResponse{
+		Message: &machinapb.CaptureResponse_ExecutionTraceComplete_{
+			ExecutionTraceComplete: &machinapb.CaptureResponse_ExecutionTraceComplete{},
+		},
+	})
+}
+
+// runExecutionTrace captures an execution trace for duration. If the execution
+// tracer is already in use, it waits for up to wait for it to be released.
+func (s *Server) runExecutionTrace(
+	ctx context.Context, duration, wait time.Duration, serializer *sendSerializer,
+) error {
+	s.loggers.InfoLogger("starting execution trace for %s", duration)
+	defer s.loggers.InfoLogger("execution trace complete")
+
+	reader, writer := io.Pipe()
+	if err := startWhenAvailable(ctx, wait, func() error {
+		return trace.Start(writer)
+	}); err != nil {
+		return inUseError(reasonExecutionTracerInUse, "failed to start execution trace: %v", err)
+	}
+
+	msg, err := executionTraceStart(time.Now(), duration)
+	if err != nil {
+		return err
 	}
 	if err := serializer.Send(msg); err != nil {
 		return err
@@ -0,0 +1,315 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"runtime/pprof"
+	"runtime/trace"
+	"testing"
+	"time"
+
+	"github.com/DataExMachina-dev/side-eye-go/internal/chunkpb"
+	"github.com/DataExMachina-dev/side-eye-go/internal/flightrecorder"
+	"github.com/DataExMachina-dev/side-eye-go/internal/machinapb"
+	"github.com/DataExMachina-dev/side-eye-go/internal/snapshot"
+
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCaptureFlightRecorder(t *testing.T) {
+	request := &machinapb.CaptureRequest{
+		ProcessFingerprint: "process",
+		Contents:           machinapb.CaptureContents_FLIGHT_RECORDER_TRACE,
+	}
+	err := newTestServer().Capture(request, &captureStream{ctx: context.Background()})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition without a flight recorder, got %v", err)
+	}
+
+	recorderStart := time.Now()
+	recorder, err := flightrecorder.Start(flightrecorder.Config{MinAge: 5 * time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer recorder.Stop()
+	s := NewServer(
+		uuid.New(), "process", "token", "env", "program", nil, /* fetcher */
+		false /* ephemeralProcess */, snapshot.Options{}, 0, /* blockProfileRate */
+		recorder, Loggers{},
+	)
+
+	// Before go1.25, the flight recorder needs a second or so before it has
+	// something to dump.
+	var stream *captureStream
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		stream = &captureStream{ctx: context.Background()}
+		err := s.Capture(request, stream)
+		if err == nil {
+			break
+		}
+		if status.Code(err) != codes.Unavailable || time.Now().After(deadline) {
+			t.Fatalf("failed to dump the flight recorder: %v", err)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	if len(stream.responses) < 3 {
+		t.Fatalf("expected at least 3 responses, got %d", len(stream.responses))
+	}
+	start := stream.responses[0].GetExecutionTraceStart()
+	if start == nil || start.DurationSeconds > 5 || start.ApproximateBootTime == nil {
+		t.Fatalf("unexpected ExecutionTraceStart: %v", stream.responses[0])
+	}
+	// The trace covers the time since the recorder was started, which ended
+	// before the dump.
+	traceStart := start.ApproximateBootTime.AsTime().Add(time.Duration(start.TraceStartMonotonic))
+	traceEnd := traceStart.Add(time.Duration(start.DurationSeconds) * time.Second)
+	if traceStart.Before(recorderStart.Add(-time.Second)) || traceEnd.After(time.Now().Add(time.Second)) {
+		t.Fatalf("trace from %s to %s is not between the start of the recorder at %s and now",
+			traceStart, traceEnd, recorderStart)
+	}
+	if stream.responses[len(stream.responses)-1].GetExecutionTraceComplete() == nil {
+		t.Fatalf("expected an ExecutionTraceComplete, got %v", stream.responses[len(stream.responses)-1])
+	}
+	var data []byte
+	for _, r := range stream.responses[1 : len(stream.responses)-1] {
+		data = append(data, r.GetExecutionTraceChunk().GetData()...)
+	}
+	if !bytes.HasPrefix(data, []byte("go 1.")) {
+		t.Fatalf("expected an execution trace, got %q", data[:min(len(data), 16)])
+	}
+
+	if flightrecorder.ExclusiveTracing {
+		err := s.Capture(&machinapb.CaptureRequest{
+			ProcessFingerprint: "process",
+			Seconds:            1,
+			Contents:           machinapb.CaptureContents_EXECUTION_TRACE,
+		}, &captureStream{ctx: context.Background()})
+		if status.Code(err) != codes.FailedPrecondition {
+			t.Fatalf("expected FailedPrecondition, got %v", err)
+		}
+	}
+}
+
+// requireInUse checks that err reports that the CPU profiler or the execution
+// tracer was in use for the given reason.
+func requireInUse(t *testing.T, err error, reason string) {
+	t.Helper()
+	st := status.Convert(err)
+	if st.Code() != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition, got %v", err)
+	}
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok && info.Reason == reason && info.Domain == errorDomain {
+			return
+		}
+	}
+	t.Fatalf("expected an ErrorInfo with reason %s, got %v", reason, st.Details())
+}
+
+func TestCaptureInUse(t *testing.T) {
+	s := newTestServer()
+	capture := func(contents machinapb.CaptureContents, wait uint32) error {
+		return s.Capture(&machinapb.CaptureRequest{
+			ProcessFingerprint: "process",
+			Contents:           contents,
+			InUseWaitSeconds:   wait,
+		}, &captureStream{ctx: context.Background()})
+	}
+
+	if err := pprof.StartCPUProfile(io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	err := capture(machinapb.CaptureContents_EXECUTION_TRACE_AND_CPU_PROFILE, 0)
+	requireInUse(t, err, reasonCpuProfilerInUse)
+	// The CPU profile started by an execution trace is optional.
+	if err := capture(machinapb.CaptureContents_EXECUTION_TRACE, 0); err != nil {
+		t.Fatalf("failed to capture an execution trace: %v", err)
+	}
+	// The capture waits for the CPU profiler to be released.
+	time.AfterFunc(200*time.Millisecond, pprof.StopCPUProfile)
+	if err := capture(machinapb.CaptureContents_EXECUTION_TRACE_AND_CPU_PROFILE, 10); err != nil {
+		t.Fatalf("failed to capture once the CPU profiler was released: %v", err)
+	}
+
+	if err := trace.Start(io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	err = capture(machinapb.CaptureContents_EXECUTION_TRACE, 0)
+	requireInUse(t, err, reasonExecutionTracerInUse)
+	time.AfterFunc(200*time.Millisecond, trace.Stop)
+	if err := capture(machinapb.CaptureContents_EXECUTION_TRACE, 10); err != nil {
+		t.Fatalf("failed to capture once the execution tracer was released: %v", err)
+	}
+}
+
+func TestCaptureQueue(t *testing.T) {
+	var q captureQueue
+	ctx := context.Background()
+	if err := q.acquire(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// Queue up captures, one of which gives up waiting.
+	canceledCtx, cancel := context.WithCancel(ctx)
+	order := make(chan int, 3)
+	canceled := make(chan error)
+	for i := 0; i < 4; i++ {
+		c := ctx
+		if i == 1 {
+			c = canceledCtx
+		}
+		go func() {
+			if err := q.acquire(c); err != nil {
+				canceled <- err
+				return
+			}
+			order <- i
+			q.release()
+		}()
+		// Wait for the capture to be queued.
+		for {
+			q.mu.Lock()
+			n := len(q.mu.waiters)
+			q.mu.Unlock()
+			if n == i+1 {
+				break
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+	cancel()
+	if err := <-canceled; err == nil {
+		t.Fatal("expected the canceled capture to fail")
+	}
+	q.release()
+	for _, want := range []int{0, 2, 3} {
+		if got := <-order; got != want {
+			t.Fatalf("expected capture %d to run, got %d", want, got)
+		}
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.mu.busy || len(q.mu.waiters) != 0 {
+		t.Fatalf("expected the queue to be idle")
+	}
+}
+
+// executableStream collects the executable sent by GetExecutable.
+type executableStream struct {
+	grpc.ServerStream
+	data []byte
+}
+
+func (e *executableStream) Send(chunk *chunkpb.Chunk) error {
+	e.data = append(e.data, chunk.Data...)
+	return nil
+}
+
+func TestGetExecutable(t *testing.T) {
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := os.ReadFile(exe)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := newTestServer()
+	// The executable is opened once, and shared by the servers created on
+	// every connection.
+	if newTestServer().exe != s.exe {
+		t.Errorf("expected the servers to share the executable")
+	}
+	hash, err := s.getBinaryHash()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		hash   string
+		offset uint64
+	}{
+		{hash: "", offset: 0},
+		{hash: hash, offset: 0},
+		{hash: hash, offset: uint64(len(want)) - 1000},
+		{hash: hash, offset: uint64(len(want))},
+	} {
+		stream := &executableStream{}
+		err := s.GetExecutable(&machinapb.GetExecutableRequest{Hash: tc.hash, Offset: tc.offset}, stream)
+		if err != nil {
+			t.Fatalf("GetExecutable(%q, %d) failed: %v", tc.hash, tc.offset, err)
+		}
+		if !bytes.Equal(stream.data, want[tc.offset:]) {
+			t.Errorf("GetExecutable(%q, %d) returned %d bytes, want %d",
+				tc.hash, tc.offset, len(stream.data), len(want)-int(tc.offset))
+		}
+	}
+
+	err = s.GetExecutable(&machinapb.GetExecutableRequest{Hash: "0123"}, &executableStream{})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected FailedPrecondition for a hash mismatch, got %v", err)
+	}
+	err = s.GetExecutable(&machinapb.GetExecutableRequest{Offset: uint64(len(want)) + 1}, &executableStream{})
+	if status.Code(err) != codes.OutOfRange {
+		t.Errorf("expected OutOfRange for an offset past the end, got %v", err)
+	}
+}
+
+// snapshotStream collects the messages sent by sendSnapshotResponse.
+type snapshotStream struct {
+	machinapb.Machina_SnapshotServer
+	msgs []*machinapb.SnapshotResponse
+}
+
+func (s *snapshotStream) Send(msg *machinapb.SnapshotResponse) error {
+	s.msgs = append(s.msgs, msg)
+	return nil
+}
+
+func TestSendSnapshotResponse(t *testing.T) {
+	data := make([]byte, 5<<19)
+	for i := range data {
+		data[i] = byte(i)
+	}
+	for _, tc := range []struct {
+		chunked  bool
+		wantMsgs int
+	}{
+		{chunked: false, wantMsgs: 1},
+		{chunked: true, wantMsgs: 3},
+	} {
+		var stream snapshotStream
+		err := sendSnapshotResponse(&stream, &machinapb.SnapshotResponse{
+			Data:        data,
+			DataByteLen: uint64(len(data)),
+			Truncated:   true,
+		}, tc.chunked)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(stream.msgs) != tc.wantMsgs {
+			t.Fatalf("chunked=%t: expected %d messages, got %d", tc.chunked, tc.wantMsgs, len(stream.msgs))
+		}
+		// The metadata is only carried by the first message.
+		if first := stream.msgs[0]; first.DataByteLen != uint64(len(data)) || !first.Truncated {
+			t.Errorf("chunked=%t: expected the metadata in the first message", tc.chunked)
+		}
+		var got []byte
+		for i, msg := range stream.msgs {
+			if i > 0 && msg.DataByteLen != 0 {
+				t.Errorf("chunked=%t: unexpected metadata in message %d", tc.chunked, i)
+			}
+			got = append(got, msg.Data...)
+		}
+		if !bytes.Equal(got, data) {
+			t.Errorf("chunked=%t: the data was not reassembled", tc.chunked)
+		}
+	}
+}
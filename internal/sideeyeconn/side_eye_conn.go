@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"github.com/DataExMachina-dev/side-eye-go/internal/artifactspb"
+	"github.com/DataExMachina-dev/side-eye-go/internal/flightrecorder"
 	"github.com/DataExMachina-dev/side-eye-go/internal/machinapb"
 	"github.com/DataExMachina-dev/side-eye-go/internal/server"
 	"github.com/DataExMachina-dev/side-eye-go/internal/serverdial"
+	"github.com/DataExMachina-dev/side-eye-go/internal/snapshot"
 	"github.com/DataExMachina-dev/side-eye-go/internal/stoptheworld"
 	"github.com/google/uuid"
 	"google.golang.org/grpc"
@@ -25,8 +27,30 @@ type Config struct {
 	AgentUrl    string
 	Environment string
 	ProgramName string
-	ErrorLogger func(err error)
-	InfoLogger  func(format string, args ...any)
+	// MaxSnapshotBytes bounds the size of the snapshots of this process. If
+	// zero, snapshot.DefaultMaxBytes is used.
+	MaxSnapshotBytes uint32
+	// MaxSnapshotPause bounds the time for which the process is paused by a
+	// snapshot. If zero, the pause is not bounded.
+	MaxSnapshotPause time.Duration
+	// MaxSnapshotStackPause bounds the part of the pause spent capturing
+	// goroutine stacks. If zero, half of MaxSnapshotPause is used.
+	MaxSnapshotStackPause time.Duration
+	// TraceSnapshots enables the tracing of the stack machine in all the
+	// snapshots of this process.
+	TraceSnapshots bool
+	// BlockProfileRate, if set, is the rate passed by the program to
+	// runtime.SetBlockProfileRate, or 0 if it does not call it. The rate is
+	// raised while a block profile is captured over a duration, and restored
+	// to this value afterwards. If nil, such captures are refused, so as not
+	// to clobber a rate that the program set.
+	BlockProfileRate *int
+	// FlightRecorder, if set, enables the flight recorder, which keeps the
+	// execution trace of the recent past in memory so that it can be dumped
+	// on demand.
+	FlightRecorder *flightrecorder.Config
+	ErrorLogger    func(err error)
+	InfoLogger     func(format string, args ...any)
 }
 
 const (
@@ -76,6 +100,8 @@ type SideEyeConn struct {
 		server     *server.Server
 		grpcServer *grpc.Server
 		grpcConn   *grpc.ClientConn
+		// flightRecorder is the flight recorder, if enabled.
+		flightRecorder *flightrecorder.Recorder
 	}
 
 	wg *sync.WaitGroup
@@ -153,11 +179,28 @@ func (c *SideEyeConn) Connect(
 	if err != nil {
 		return fmt.Errorf("failed to create artifacts client: %w", err)
 	}
+	var recorder *flightrecorder.Recorder
+	if cfg.FlightRecorder != nil {
+		recorder, err = flightrecorder.Start(*cfg.FlightRecorder)
+		if err != nil {
+			_ = conn.Close()
+			return err
+		}
+	}
 	fetcher := server.NewSnapshotFetcher(client)
+	blockProfileRate := server.UnknownBlockProfileRate
+	if cfg.BlockProfileRate != nil {
+		blockProfileRate = *cfg.BlockProfileRate
+	}
 	server := server.NewServer(
 		c.agentFingerprint, c.processFingerprint,
 		cfg.TenantToken, cfg.Environment, cfg.ProgramName, fetcher,
-		ephemeralProcess, server.Loggers{
+		ephemeralProcess, snapshot.Options{
+			MaxBytes:      cfg.MaxSnapshotBytes,
+			MaxPause:      cfg.MaxSnapshotPause,
+			MaxStackPause: cfg.MaxSnapshotStackPause,
+			Trace:         cfg.TraceSnapshots,
+		}, blockProfileRate, recorder, server.Loggers{
 			ErrorLogger: cfg.ErrorLogger,
 			InfoLogger:  cfg.InfoLogger,
 		})
@@ -168,6 +211,7 @@ func (c *SideEyeConn) Connect(
 	c.mu.server = server
 	c.mu.grpcServer = s
 	c.mu.grpcConn = conn
+	c.mu.flightRecorder = recorder
 	c.mu.listener = l
 	c.mu.Unlock()
 	wg := &sync.WaitGroup{}
@@ -219,6 +263,10 @@ func (c *SideEyeConn) closeInner() {
 	}
 	c.mu.grpcServer.Stop()
 	c.mu.grpcConn.Close()
+	if c.mu.flightRecorder != nil {
+		c.mu.flightRecorder.Stop()
+		c.mu.flightRecorder = nil
+	}
 	c.mu.grpcConn = nil
 	c.mu.grpcServer = nil
 	c.mu.server = nil
@@ -0,0 +1,283 @@
+package snapshot
+
+import (
+	"math/bits"
+
+	"github.com/DataExMachina-dev/side-eye-go/internal/snapshotpb"
+)
+
+// Arena holds the memory used while the world is stopped to take a snapshot.
+//
+// Nothing is allocated with the world stopped. The arena's memory is sized
+// before the world is stopped, from the number of goroutines and from what the
+// previous snapshot used; if a snapshot needs more of it, what does not fit is
+// dropped, as described for each of the fields below, and the snapshot is
+// marked as such in its header. Reusing an Arena across snapshots means that,
+// once it has grown to fit the snapshots of a process, nothing is dropped and
+// no garbage is produced.
+//
+// An Arena must not be used by concurrent snapshots. The data of a snapshot
+// taken with an Arena is only valid until the Arena is used again.
+type Arena struct {
+	// out holds the snapshot data. The data that does not fit is left out,
+	// and the snapshot is marked as truncated.
+	out   outBuf
+	queue queue
+	// stacks holds the stacks written to the snapshot. Stacks that do not fit
+	// are written again for every goroutine they are found in.
+	stacks stackTable
+	// frames holds the frames of interest of all the stacks in stacks. The
+	// frames of interest of the stacks that do not fit are not cached.
+	frames []frameOfInterest
+	// framesFull is set if a stack's frames of interest did not fit in
+	// frames.
+	framesFull bool
+	// classified is the scratch space in which the frames of interest of a
+	// stack are classified.
+	classified [maxStackFrames]frameOfInterest
+}
+
+// NewArena allocates an Arena. The snapshot buffer is only allocated by the
+// first snapshot, which is given a small one.
+func NewArena() *Arena {
+	return &Arena{
+		queue:  makeQueue(nil /* typeInfo */),
+		stacks: makeStackTable(1 << 10),
+		frames: make([]frameOfInterest, 0, 4<<10),
+	}
+}
+
+// rootsPerGoroutine is the number of roots that every goroutine is expected to
+// contribute to a snapshot: its frames of interest and the channels it is
+// waiting on.
+const rootsPerGoroutine = 4
+
+// reset prepares the arena for a snapshot of a process with the given number
+// of goroutines and static variables, retaining its memory and growing the
+// parts of it that the snapshot is not expected to fit in.
+func (a *Arena) reset(
+	maxLen uint32, typeInfo map[uint32]*snapshotpb.TypeInfo, numGoroutines, numStatics int,
+) {
+	a.out.reset(maxLen)
+	a.queue.reset(typeInfo, numStatics+rootsPerGoroutine*numGoroutines)
+	a.stacks.reset()
+	if a.framesFull {
+		a.frames = make([]frameOfInterest, 0, 2*cap(a.frames))
+		a.framesFull = false
+	}
+	a.frames = a.frames[:0]
+}
+
+// cacheFrames records the frames of interest of a stack that is not in
+// stacks, unless they do not fit.
+func (a *Arena) cacheFrames(hash uint64, frames []frameOfInterest) {
+	if len(a.frames)+len(frames) > cap(a.frames) {
+		a.framesFull = true
+		return
+	}
+	start := uint32(len(a.frames))
+	a.frames = append(a.frames, frames...)
+	if !a.stacks.put(hash, start, uint32(len(a.frames))) {
+		a.frames = a.frames[:start]
+	}
+}
+
+// hashKey mixes a 64-bit key with Fibonacci hashing, returning a value whose
+// upper bits are suitable to index a power-of-two sized table.
+func hashKey(k uint64) uint64 {
+	return k * 0x9e3779b97f4a7c15
+}
+
+// tableSize returns the power-of-two size for an open-addressing table that
+// holds n entries at a load factor of at most 1/2, and the corresponding
+// shift to apply to hashKey.
+func tableSize(n int) (size int, shift uint) {
+	if n < 8 {
+		n = 8
+	}
+	logSize := bits.Len(uint(2*n - 1))
+	return 1 << logSize, uint(64 - logSize)
+}
+
+// seenSet is an open-addressing hash set of the (address, type) pairs that
+// have been enqueued. The zero address is never inserted, so it marks empty
+// slots.
+type seenSet struct {
+	slots []queueEntryKey
+	shift uint
+	len   int
+	// maxLen is the largest len since the last reset.
+	maxLen int
+	// full is set if an insertion failed because the set was at its maximum
+	// load.
+	full bool
+}
+
+func makeSeenSet(n int) seenSet {
+	size, shift := tableSize(n)
+	return seenSet{slots: make([]queueEntryKey, size), shift: shift}
+}
+
+// reset empties the set, growing it if needed so that it can hold n entries.
+func (s *seenSet) reset(n int) {
+	if size, _ := tableSize(n); size > len(s.slots) {
+		*s = makeSeenSet(n)
+		return
+	}
+	clear(s.slots)
+	s.len, s.maxLen = 0, 0
+	s.full = false
+}
+
+// home returns the slot at which the probe sequence for k starts.
+func (s *seenSet) home(k queueEntryKey) int {
+	return int(hashKey(uint64(k.addr)^bits.RotateLeft64(uint64(k.t), 48)) >> s.shift)
+}
+
+// contains returns whether k is in the set.
+func (s *seenSet) contains(k queueEntryKey) bool {
+	mask := len(s.slots) - 1
+	for i := s.home(k); ; i = (i + 1) & mask {
+		switch s.slots[i] {
+		case k:
+			return true
+		case queueEntryKey{}:
+			return false
+		}
+	}
+}
+
+// insert adds k to the set. It returns false if k was already present, and
+// also if it could not be added because the set is full.
+func (s *seenSet) insert(k queueEntryKey) (added bool, ok bool) {
+	mask := len(s.slots) - 1
+	for i := s.home(k); ; i = (i + 1) & mask {
+		switch s.slots[i] {
+		case k:
+			return false, true
+		case queueEntryKey{}:
+			if 2*(s.len+1) > len(s.slots) {
+				s.full = true
+				return false, false
+			}
+			s.slots[i] = k
+			s.len++
+			s.maxLen = max(s.maxLen, s.len)
+			return true, true
+		}
+	}
+}
+
+// remove removes k from the set, if present. The entries that follow it in
+// its probe sequence are shifted back into the hole, so that no tombstone is
+// needed.
+func (s *seenSet) remove(k queueEntryKey) {
+	mask := len(s.slots) - 1
+	i := s.home(k)
+	for ; s.slots[i] != k; i = (i + 1) & mask {
+		if s.slots[i] == (queueEntryKey{}) {
+			return
+		}
+	}
+	for j := (i + 1) & mask; s.slots[j] != (queueEntryKey{}); j = (j + 1) & mask {
+		// The entry at j can fill the hole at i unless its home is
+		// cyclically in (i, j].
+		h := s.home(s.slots[j])
+		if (i < j && (h <= i || h > j)) || (j < i && h <= i && h > j) {
+			s.slots[i] = s.slots[j]
+			i = j
+		}
+	}
+	s.slots[i] = queueEntryKey{}
+	s.len--
+}
+
+// stackTableEntry locates the frames of interest of a stack in
+// Arena.frames.
+type stackTableEntry struct {
+	hash       uint64
+	start, end uint32
+	used       bool
+}
+
+// stackTable is an open-addressing hash table from the hash of a stack to its
+// frames of interest, for the stacks that have been written to the snapshot.
+type stackTable struct {
+	slots []stackTableEntry
+	shift uint
+	len   int
+	// full is set if a stack was not added because the table was at its
+	// maximum load. The table is grown by the next reset.
+	full bool
+}
+
+func makeStackTable(n int) stackTable {
+	size, shift := tableSize(n)
+	return stackTable{slots: make([]stackTableEntry, size), shift: shift}
+}
+
+func (t *stackTable) reset() {
+	if t.full {
+		*t = makeStackTable(len(t.slots))
+		return
+	}
+	clear(t.slots)
+	t.len = 0
+}
+
+func (t *stackTable) get(hash uint64) (start, end uint32, ok bool) {
+	mask := len(t.slots) - 1
+	for i := int(hashKey(hash) >> t.shift); ; i = (i + 1) & mask {
+		e := &t.slots[i]
+		if !e.used {
+			return 0, 0, false
+		}
+		if e.hash == hash {
+			return e.start, e.end, true
+		}
+	}
+}
+
+// put records the frames of interest of a stack that is not in the table. It
+// returns false if the table is full.
+func (t *stackTable) put(hash uint64, start, end uint32) (ok bool) {
+	if 2*(t.len+1) > len(t.slots) {
+		t.full = true
+		return false
+	}
+	mask := len(t.slots) - 1
+	i := int(hashKey(hash) >> t.shift)
+	for t.slots[i].used {
+		i = (i + 1) & mask
+	}
+	t.slots[i] = stackTableEntry{hash: hash, start: start, end: end, used: true}
+	t.len++
+	return true
+}
+
+// ring is a FIFO of root indexes backed by a slice that is reused across
+// snapshots. Its capacity is set by reset.
+type ring struct {
+	buf        []uint32
+	head, size int
+}
+
+// reset empties the ring and ensures that it can hold n values.
+func (r *ring) reset(n int) {
+	if len(r.buf) < n {
+		r.buf = make([]uint32, n)
+	}
+	r.head, r.size = 0, 0
+}
+
+func (r *ring) pushBack(v uint32) {
+	r.buf[(r.head+r.size)%len(r.buf)] = v
+	r.size++
+}
+
+func (r *ring) popFront() uint32 {
+	v := r.buf[r.head]
+	r.head = (r.head + 1) % len(r.buf)
+	r.size--
+	return v
+}
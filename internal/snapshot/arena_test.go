@@ -0,0 +1,208 @@
+package snapshot
+
+import (
+	"math"
+	"runtime"
+	"testing"
+	"time"
+	"unsafe"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataExMachina-dev/side-eye-go/internal/framing"
+	"github.com/DataExMachina-dev/side-eye-go/internal/snapshotpb"
+	. "github.com/DataExMachina-dev/side-eye-go/internal/stackmachine"
+	"github.com/DataExMachina-dev/side-eye-go/internal/stoptheworld"
+	"github.com/DataExMachina-dev/side-eye-go/internal/testutil"
+)
+
+const arenaObjType = 1
+
+// arenaWorkload is the work done with the world stopped by the arena tests:
+// capturing stacks, all of whose frames are frames of interest, and chasing
+// objects from them.
+type arenaWorkload struct {
+	p      *snapshotpb.SnapshotProgram
+	objs   [][2]uint64
+	stacks [][]uintptr
+	fps    []uintptr
+}
+
+func makeArenaWorkload(t *testing.T, numObjs int, numStacks int) *arenaWorkload {
+	if err := stoptheworld.PlatformSupported(); err != nil {
+		t.Skipf("platform not supported: %v", err)
+	}
+	cfg, err := testutil.StopTheWorldConfig()
+	require.NoError(t, err)
+
+	e := newEncoder()
+	pc := e.Encode(OpReturn{})
+	w := &arenaWorkload{
+		p: &snapshotpb.SnapshotProgram{
+			RuntimeConfig: cfg,
+			PcClassifier: &snapshotpb.PcClassifier{
+				TargetPc: []uint64{1 << 62},
+				ProgPc:   []uint32{pc},
+			},
+			TypeInfo: map[uint32]*snapshotpb.TypeInfo{
+				arenaObjType: {ByteLen: 16, SerializeBeforeEnqueue: true},
+			},
+			Prog: e.Bytes(),
+		},
+		objs:   make([][2]uint64, numObjs),
+		stacks: make([][]uintptr, numStacks),
+		fps:    make([]uintptr, 32),
+	}
+	for i := range w.stacks {
+		w.stacks[i] = make([]uintptr, 1+i%32)
+		for j := range w.stacks[i] {
+			w.stacks[i][j] = uintptr(0x1000*i + j)
+		}
+	}
+	return w
+}
+
+// run stops the world and captures the workload with s.
+func (w *arenaWorkload) run(s *snapshotter) bool {
+	return stoptheworld.StopTheWorld(w.p.RuntimeConfig, func() {
+		for i, pcs := range w.stacks {
+			// Every stack is seen twice, to exercise the deduplication.
+			for k := 0; k < 2; k++ {
+				if _, ok := s.out.reserveGoroutineHeader(); !ok {
+					return
+				}
+				if _, _, ok := s.captureStack(pcs, w.fps); !ok {
+					return
+				}
+			}
+			s.queue.beginRoot(1 /* depth */)
+			for j := i; j < len(w.objs); j += len(w.stacks) {
+				s.queue.Push(uintptr(unsafe.Pointer(&w.objs[j])), arenaObjType, 0)
+				// Pushing the same object again is a no-op.
+				s.queue.Push(uintptr(unsafe.Pointer(&w.objs[j])), arenaObjType, 0)
+			}
+		}
+		s.processQueue()
+	})
+}
+
+// requireCaptured checks that the snapshot taken by s captured everything.
+func (w *arenaWorkload) requireCaptured(t *testing.T, s *snapshotter) {
+	t.Helper()
+	require.Nil(t, s.skippedPointees())
+	require.Equal(t, len(w.stacks), s.arena.stacks.len)
+	entrySize := int(unsafe.Sizeof(framing.QueueEntry{})) + 16
+	require.GreaterOrEqual(t, int(s.out.Len()), len(w.objs)*entrySize)
+}
+
+// TestStopTheWorldDoesNotAllocate checks that, once an arena has been warmed
+// up, the work done with the world stopped does not allocate.
+func TestStopTheWorldDoesNotAllocate(t *testing.T) {
+	w := makeArenaWorkload(t, 4096 /* numObjs */, 256 /* numStacks */)
+	s := newSnapshotter(w.p, Options{Arena: NewArena()}, liveMemory{}, 0 /* numGoroutines */)
+	// Let the arena grow to fit the workload.
+	for i := 0; i < 10; i++ {
+		s.arena.reset(DefaultMaxBytes, w.p.TypeInfo, 0 /* numGoroutines */, 0 /* numStatics */)
+		require.True(t, w.run(s))
+	}
+	var ok bool
+	allocs := testing.AllocsPerRun(10, func() {
+		s.arena.reset(DefaultMaxBytes, w.p.TypeInfo, 0 /* numGoroutines */, 0 /* numStatics */)
+		ok = w.run(s)
+	})
+	require.True(t, ok)
+	require.Zero(t, allocs)
+	w.requireCaptured(t, s)
+}
+
+// TestStopTheWorldDoesNotGrowArena checks that snapshots that do not fit in
+// their arena do not allocate with the world stopped either, and that the
+// arena grows between snapshots until they fit.
+func TestStopTheWorldDoesNotGrowArena(t *testing.T) {
+	// The workload overflows every part of a new arena.
+	w := makeArenaWorkload(t, 48<<10 /* numObjs */, 2048 /* numStacks */)
+
+	// The runtime itself may allocate around stopping the world, e.g. to
+	// start a thread, so the first snapshot with a new arena is taken a few
+	// times.
+	minAllocs := uint64(math.MaxUint64)
+	for i := 0; i < 5 && minAllocs > 0; i++ {
+		s := newSnapshotter(w.p, Options{Arena: NewArena()}, liveMemory{}, 0 /* numGoroutines */)
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+		ok := w.run(s)
+		runtime.ReadMemStats(&after)
+		require.True(t, ok)
+		require.NotNil(t, s.skippedPointees())
+		require.True(t, s.queue.overflowed())
+		minAllocs = min(minAllocs, after.Mallocs-before.Mallocs)
+	}
+	require.Zero(t, minAllocs)
+
+	s := newSnapshotter(w.p, Options{Arena: NewArena()}, liveMemory{}, 0 /* numGoroutines */)
+	// The seen set is grown to twice what the previous snapshot used before
+	// it fills up, so its overflow is tested by TestSeenSetFull.
+	var overflowed struct{ frames, stacks, slots, roots bool }
+	for i := 0; ; i++ {
+		require.Less(t, i, 10, "the arena did not grow to fit the snapshot")
+		require.True(t, w.run(s))
+		overflowed.frames = overflowed.frames || s.arena.framesFull
+		overflowed.stacks = overflowed.stacks || s.arena.stacks.full
+		overflowed.slots = overflowed.slots || s.queue.slotsFull
+		overflowed.roots = overflowed.roots || s.queue.rootsFull
+		// The snapshots that dropped pointees are marked as such.
+		res, err := s.response(time.Now(), 0 /* bssAddrShift */)
+		require.NoError(t, err)
+		require.Equal(t, s.queue.overflowed(), s.header.Flags&framing.SnapshotFlagDropped != 0)
+		require.Equal(t, s.queue.overflowed(), res.Dropped)
+		require.Equal(t, s.out.full(), res.Truncated)
+		if !s.queue.overflowed() && !s.out.full() &&
+			s.skippedPointees() == nil && s.arena.stacks.len == len(w.stacks) {
+			break
+		}
+		s.arena.reset(DefaultMaxBytes, w.p.TypeInfo, 0 /* numGoroutines */, 0 /* numStatics */)
+		s.header = framing.SnapshotHeader{}
+	}
+	w.requireCaptured(t, s)
+	require.False(t, s.queue.overflowed())
+	require.Equal(t, struct{ frames, stacks, slots, roots bool }{true, true, true, true}, overflowed)
+}
+
+// TestPastDeadlineIsPartial checks that pointer chasing stops at the deadline,
+// and that the snapshot is then reported as partial.
+func TestPastDeadlineIsPartial(t *testing.T) {
+	w := makeArenaWorkload(t, 64 /* numObjs */, 4 /* numStacks */)
+	s := newSnapshotter(w.p, Options{Arena: NewArena()}, liveMemory{}, 0 /* numGoroutines */)
+	s.deadline = 1
+	require.True(t, w.run(s))
+	require.NotNil(t, s.skippedPointees())
+	res, err := s.response(time.Now(), 0 /* bssAddrShift */)
+	require.NoError(t, err)
+	require.True(t, res.Partial)
+	require.False(t, res.Truncated)
+	require.NotZero(t, s.header.Flags&framing.SnapshotFlagPartial)
+
+	s = newSnapshotter(w.p, Options{Arena: NewArena()}, liveMemory{}, 0 /* numGoroutines */)
+	require.True(t, w.run(s))
+	w.requireCaptured(t, s)
+	res, err = s.response(time.Now(), 0 /* bssAddrShift */)
+	require.NoError(t, err)
+	require.False(t, res.Partial)
+}
+
+// TestArenaSizedForGoroutines checks that the queue of an arena is sized from
+// the number of goroutines before the world is stopped, and that it keeps
+// room for twice what the previous snapshot used.
+func TestArenaSizedForGoroutines(t *testing.T) {
+	a := NewArena()
+	a.reset(DefaultMaxBytes, nil /* typeInfo */, 100<<10 /* numGoroutines */, 1000 /* numStatics */)
+	wantRoots := 1000 + rootsPerGoroutine*(100<<10)
+	require.GreaterOrEqual(t, cap(a.queue.roots), wantRoots)
+	require.GreaterOrEqual(t, cap(a.queue.slots), wantRoots)
+	require.GreaterOrEqual(t, len(a.queue.seen.slots), 2*wantRoots)
+
+	// A snapshot that fills most of the queue makes the next one larger.
+	a.queue.roots = a.queue.roots[:cap(a.queue.roots)-1]
+	a.reset(DefaultMaxBytes, nil /* typeInfo */, 0 /* numGoroutines */, 0 /* numStatics */)
+	require.GreaterOrEqual(t, cap(a.queue.roots), 2*(wantRoots-1))
+}
@@ -0,0 +1,149 @@
+package snapshot
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/DataExMachina-dev/side-eye-go/internal/allgs"
+	"github.com/DataExMachina-dev/side-eye-go/internal/framing"
+	"github.com/DataExMachina-dev/side-eye-go/internal/machinapb"
+	"github.com/DataExMachina-dev/side-eye-go/internal/snapshotpb"
+	"github.com/DataExMachina-dev/side-eye-go/internal/stackmachine"
+)
+
+// SimulatedProcess is the state of a process that a snapshot program is dry
+// run against.
+type SimulatedProcess struct {
+	// Memory is the memory that the program reads from. Static variables
+	// are read at the addresses in the program's RuntimeConfig, unshifted.
+	Memory SimulatedMemory
+	// Goroutines are captured in order.
+	Goroutines []SimulatedGoroutine
+	// TypesStart and TypesEnd delimit the runtime type descriptors of the
+	// process: the go runtime type id of a type at an address in
+	// [TypesStart, TypesEnd) is the address minus TypesStart, as for types
+	// in the first moduledata of a live process.
+	TypesStart, TypesEnd uint64
+}
+
+// SimulatedGoroutine is a goroutine of a SimulatedProcess.
+type SimulatedGoroutine struct {
+	Goid       uint64
+	Status     uint32
+	WaitReason uint8
+	// Labels are the pprof labels of the goroutine. They are written in
+	// the order of their keys, as the runtime keeps them since go1.24.
+	Labels map[string]string
+	// CreatorPc is the pc of the go statement that created the goroutine,
+	// as a static address in the binary.
+	CreatorPc uint64
+	// ParentGoid is the goid of the goroutine that created the goroutine.
+	ParentGoid uint64
+	// Waiting is the address of the first sudog in the goroutine's
+	// g.waiting list. The sudogs are read from the Memory, with the layout
+	// in the program's RuntimeConfig, if the goroutine is waiting.
+	Waiting uint64
+	// Stack holds the frames of the goroutine, leaf first. It must not be
+	// empty.
+	Stack []SimulatedFrame
+}
+
+// SimulatedFrame is a stack frame of a SimulatedGoroutine.
+type SimulatedFrame struct {
+	// Pc is the program counter of the frame, as a static address in the
+	// binary, like the pcs of the program's PcClassifier.
+	Pc uint64
+	// CFA is the canonical frame address of the frame.
+	CFA uint64
+}
+
+// DryRun executes a snapshot program against a simulated process rather than
+// the current one. The world is not stopped, and every read of the process'
+// memory is served by the SimulatedProcess, which makes it possible to
+// exercise programs deterministically in tests.
+//
+// The program is verified before it is executed. Of the options, the
+// GoroutineFilter and the pause limits are ignored.
+func DryRun(
+	p *snapshotpb.SnapshotProgram, proc *SimulatedProcess, opts Options,
+) (*machinapb.SnapshotResponse, error) {
+	if err := stackmachine.Verify(p); err != nil {
+		return nil, fmt.Errorf("invalid snapshot program: %w", err)
+	}
+	b := newSnapshotter(p, opts, proc.Memory, len(proc.Goroutines))
+	b.goRuntimeTypeResolver = goRuntimeTypeResolver{resolved: true}
+	b.goRuntimeTypeResolver.insertRange(moduledataTypeRange{
+		start: proc.TypesStart,
+		end:   proc.TypesEnd,
+	})
+	var bssAddrShift uint64
+	b.sm.bssAddrShift = &bssAddrShift
+	start := time.Now()
+	if !b.out.reserveSnapshotHeader() {
+		return nil, fmt.Errorf("failed to write snapshot header")
+	}
+
+	for _, v := range p.GetRuntimeConfig().GetStaticVariables() {
+		b.queue.beginRoot(0 /* depth */)
+		b.queue.Push(uintptr(v.Address), v.Type, 0)
+	}
+	for i := range proc.Goroutines {
+		g := &proc.Goroutines[i]
+		if len(g.Stack) == 0 {
+			return nil, fmt.Errorf("goroutine %d has an empty stack", g.Goid)
+		}
+		pcs := make([]uintptr, len(g.Stack))
+		fps := make([]uintptr, len(g.Stack))
+		for j, f := range g.Stack {
+			pcs[j], fps[j] = uintptr(f.Pc), uintptr(f.CFA)
+		}
+		before := b.out.Len()
+		if !b.writeSimulatedGoroutine(g, pcs, fps) || b.out.full() {
+			b.out.truncate(before)
+			continue
+		}
+		b.header.Statistics.NumGoroutines++
+	}
+	b.header.GoroutinesByteLen = b.out.Len() - framing.SnapshotHeaderByteLen(b.out.version)
+	b.processQueue()
+	return b.response(start, bssAddrShift)
+}
+
+// writeSimulatedGoroutine writes g and, in the framing versions that have
+// them, its labels and what it is waiting on, and captures its stack. It
+// returns whether g was written.
+func (s *snapshotter) writeSimulatedGoroutine(g *SimulatedGoroutine, pcs, fps []uintptr) bool {
+	headerOffset, ok := s.out.reserveGoroutineHeader()
+	if !ok {
+		return false
+	}
+	var labelsByteLen, numWaitingOn uint32
+	if s.out.version != framing.Version0 {
+		labelsOffset := s.out.Len()
+		for _, k := range slices.Sorted(maps.Keys(g.Labels)) {
+			if !s.out.writeLabel(labelsOffset, k, g.Labels[k]) {
+				break
+			}
+		}
+		if labelsByteLen, ok = s.out.concludeLabels(labelsOffset); !ok {
+			return false
+		}
+		if allgs.Status(g.Status) == allgs.Status_Gwaiting {
+			if numWaitingOn, ok = s.writeWaitingOn(uintptr(g.Waiting)); !ok {
+				return false
+			}
+		}
+	}
+	return s.writeGoroutine(headerOffset, framing.GoroutineHeader{
+		Goid:          g.Goid,
+		Status:        g.Status,
+		WaitReason:    g.WaitReason,
+		StackSource:   framing.StackSourceSched,
+		LabelsByteLen: labelsByteLen,
+		NumWaitingOn:  numWaitingOn,
+		CreatorPc:     g.CreatorPc,
+		ParentGoid:    g.ParentGoid,
+	}, pcs, fps)
+}
@@ -0,0 +1,607 @@
+package snapshot
+
+import (
+	"encoding/binary"
+	"strings"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataExMachina-dev/side-eye-go/internal/allgs"
+	"github.com/DataExMachina-dev/side-eye-go/internal/framing"
+	"github.com/DataExMachina-dev/side-eye-go/internal/snapshotpb"
+	. "github.com/DataExMachina-dev/side-eye-go/internal/stackmachine"
+	"github.com/DataExMachina-dev/side-eye-go/snapshotdata"
+)
+
+// words encodes 64-bit words as simulated memory.
+func words(ws ...uint64) []byte {
+	b := make([]byte, 0, 8*len(ws))
+	for _, w := range ws {
+		b = binary.NativeEndian.AppendUint64(b, w)
+	}
+	return b
+}
+
+// framePc is the pc of the only frame that dry run programs capture; pcs in
+// (framePc-0x100, framePc] are classified as that frame.
+const framePc = 0x2ff
+
+// frameProgram returns a program that runs the bytecode at pc for the frames
+// at framePc.
+func frameProgram(e *OpEncoder, pc uint32, typeInfo map[uint32]*snapshotpb.TypeInfo) *snapshotpb.SnapshotProgram {
+	return &snapshotpb.SnapshotProgram{
+		PcClassifier: &snapshotpb.PcClassifier{
+			TargetPc: []uint64{framePc - 0x100, framePc},
+			ProgPc:   []uint32{0, pc},
+		},
+		TypeInfo: typeInfo,
+		Prog:     e.Bytes(),
+	}
+}
+
+// newEncoder returns an encoder with pc 0 taken, as a program pc of 0 means no
+// program.
+func newEncoder() *OpEncoder {
+	e := &OpEncoder{}
+	e.Encode(OpIllegal{})
+	return e
+}
+
+// frameOf returns a single-frame goroutine whose frame runs the program.
+func frameOf(goid uint64, cfa uint64) SimulatedGoroutine {
+	return SimulatedGoroutine{Goid: goid, Stack: []SimulatedFrame{{Pc: framePc, CFA: cfa}}}
+}
+
+func dryRun(t *testing.T, p *snapshotpb.SnapshotProgram, proc *SimulatedProcess) *snapshotdata.Snapshot {
+	t.Helper()
+	res, err := DryRun(p, proc, Options{FramingVersion: framing.CurrentVersion})
+	require.NoError(t, err)
+	require.Equal(t, framing.CurrentVersion, res.FramingVersion)
+	s, err := snapshotdata.DecodeVersion(res.Data, res.FramingVersion)
+	require.NoError(t, err)
+	return s
+}
+
+type pointeeKey struct {
+	t    uint32
+	addr uint64
+}
+
+func pointeesByKey(s *snapshotdata.Snapshot) map[pointeeKey]snapshotdata.Entry {
+	m := make(map[pointeeKey]snapshotdata.Entry)
+	for _, e := range s.Pointees {
+		m[pointeeKey{t: e.Type, addr: e.Addr}] = e
+	}
+	return m
+}
+
+func TestDryRunFrameData(t *testing.T) {
+	const (
+		frameType   = 1
+		pointeeType = 2
+	)
+	e := newEncoder()
+	pc := e.Encode(OpPrepareFrameData{ProgID: 7, DataByteLen: 16, TypeID: frameType})
+	e.Encode(OpDereferenceCFAOffset{Offset: -24, ByteLen: 16})
+	e.Encode(OpAdvanceOffset{Increment: 8})
+	e.Encode(OpEnqueuePointer{ElemType: pointeeType})
+	e.Encode(OpConcludeFrameData{})
+	e.Encode(OpReturn{})
+	p := frameProgram(e, pc, map[uint32]*snapshotpb.TypeInfo{
+		pointeeType: {ByteLen: 8, SerializeBeforeEnqueue: true},
+	})
+
+	stack := []SimulatedFrame{
+		{Pc: 0x100, CFA: 0x5000},
+		{Pc: 0x280, CFA: 0x7000},
+		{Pc: 0x380, CFA: 0x8000},
+	}
+	proc := &SimulatedProcess{
+		Memory: SimulatedMemory{
+			0x7000 - 24: words(0x1234, 0x9000),
+			0x9000:      words(0xabcd),
+			0xa000 - 24: words(0x5678, 0xdead0000),
+		},
+		Goroutines: []SimulatedGoroutine{
+			{Goid: 1, Status: 4, WaitReason: 2, CreatorPc: 0x180, ParentGoid: 7, Stack: stack},
+			// Same stack, which is deduplicated.
+			{Goid: 2, Status: 4, WaitReason: 2, Stack: stack},
+			// A frame with a dangling pointer.
+			frameOf(3, 0xa000),
+		},
+	}
+	s := dryRun(t, p, proc)
+	require.Len(t, s.Goroutines, 3)
+	require.Equal(t, uint32(3), s.Header.Statistics.NumGoroutines)
+
+	g1, g2, g3 := s.Goroutines[0], s.Goroutines[1], s.Goroutines[2]
+	require.Equal(t, uint64(1), g1.Goid)
+	require.Equal(t, uint32(4), g1.Status)
+	require.Equal(t, uint8(2), g1.WaitReason)
+	require.Equal(t, uint64(0x180), g1.CreatorPc)
+	require.Equal(t, uint64(7), g1.ParentGoid)
+	require.Equal(t, []uint64{0x100, 0x280, 0x380}, g1.Stack)
+	require.Equal(t, uint32(24), g1.StackBytes)
+	require.Zero(t, g2.StackBytes)
+	require.Equal(t, g1.StackHash, g2.StackHash)
+	for _, g := range []snapshotdata.Goroutine{g1, g2} {
+		require.Equal(t, []snapshotdata.Frame{{
+			Type:   frameType,
+			ProgID: 7,
+			Depth:  1,
+			Data:   words(0x1234, 0x9000),
+		}}, g.Frames)
+	}
+	require.Len(t, g3.Frames, 1)
+	require.Equal(t, uint32(0), g3.Frames[0].Depth)
+	require.Equal(t, words(0x5678, 0xdead0000), g3.Frames[0].Data)
+
+	require.Equal(t, []snapshotdata.Entry{
+		{Type: pointeeType, Addr: 0x9000, Data: words(0xabcd)},
+		{Type: pointeeType, Addr: 0xdead0000, Data: words(0), DereferenceFailed: true},
+	}, s.Pointees)
+}
+
+func TestDryRunLabels(t *testing.T) {
+	const frameType = 1
+	e := newEncoder()
+	pc := e.Encode(OpPrepareFrameData{ProgID: 7, DataByteLen: 8, TypeID: frameType})
+	e.Encode(OpDereferenceCFAOffset{Offset: -8, ByteLen: 8})
+	e.Encode(OpConcludeFrameData{})
+	e.Encode(OpReturn{})
+	p := frameProgram(e, pc, nil)
+
+	labels := map[string]string{"handler": "/debug", "a": "b"}
+	long := map[string]string{
+		"a":    "b",
+		"long": strings.Repeat("x", maxLabelsByteLen),
+	}
+	withLabels := func(g SimulatedGoroutine, labels map[string]string) SimulatedGoroutine {
+		g.Labels = labels
+		return g
+	}
+	s := dryRun(t, p, &SimulatedProcess{
+		Memory: SimulatedMemory{0x7000 - 8: words(42)},
+		Goroutines: []SimulatedGoroutine{
+			withLabels(frameOf(1, 0x7000), labels),
+			frameOf(2, 0x7000),
+			// Labels that do not fit are dropped.
+			withLabels(frameOf(3, 0x7000), long),
+		},
+	})
+	require.Len(t, s.Goroutines, 3)
+	require.Equal(t, labels, s.Goroutines[0].Labels)
+	require.Nil(t, s.Goroutines[1].Labels)
+	require.Equal(t, map[string]string{"a": "b"}, s.Goroutines[2].Labels)
+	for _, g := range s.Goroutines {
+		require.Equal(t, []uint64{framePc}, g.Stack)
+		require.Equal(t, []snapshotdata.Frame{{
+			Type:   frameType,
+			ProgID: 7,
+			Data:   words(42),
+		}}, g.Frames)
+	}
+}
+
+func TestDryRunWaitingOn(t *testing.T) {
+	const hchanType = 5
+	p := frameProgram(newEncoder(), 0, map[uint32]*snapshotpb.TypeInfo{
+		hchanType: {ByteLen: 16, SerializeBeforeEnqueue: true},
+	})
+	p.RuntimeConfig = &snapshotpb.RuntimeConfig{
+		SudogElemOffset:     8,
+		SudogCOffset:        16,
+		SudogWaitlinkOffset: 24,
+		HchanType:           hchanType,
+	}
+	// sudog returns the memory of a sudog.
+	sudog := func(elem, c, waitlink uint64) []byte {
+		return words(0, elem, c, waitlink)
+	}
+	waiting := func(goid, sudog uint64) SimulatedGoroutine {
+		g := frameOf(goid, 0x7000)
+		g.Status = uint32(allgs.Status_Gwaiting)
+		g.Waiting = sudog
+		return g
+	}
+	runnable := waiting(4, 0x1000)
+	runnable.Status = uint32(allgs.Status_Grunnable)
+	s := dryRun(t, p, &SimulatedProcess{
+		Memory: SimulatedMemory{
+			// A receive.
+			0x1000: sudog(0xe000, 0xc000, 0),
+			// A select on two channels, one of which is shared with the
+			// receive.
+			0x2000: sudog(0xe100, 0xc100, 0x2100),
+			0x2100: sudog(0xe200, 0xc000, 0),
+			// A semaphore, whose waitlink is not followed.
+			0x3000: sudog(0x5000, 0, 0x2000),
+			0xc000: words(1, 2),
+			0xc100: words(3, 4),
+		},
+		Goroutines: []SimulatedGoroutine{
+			waiting(1, 0x1000),
+			waiting(2, 0x2000),
+			waiting(3, 0x3000),
+			// Only waiting goroutines are blocked on anything.
+			runnable,
+		},
+	})
+	require.Len(t, s.Goroutines, 4)
+	require.Equal(t, []snapshotdata.WaitingOn{{Chan: 0xc000, Elem: 0xe000}}, s.Goroutines[0].WaitingOn)
+	require.Equal(t, []snapshotdata.WaitingOn{
+		{Chan: 0xc100, Elem: 0xe100},
+		{Chan: 0xc000, Elem: 0xe200},
+	}, s.Goroutines[1].WaitingOn)
+	require.Equal(t, []snapshotdata.WaitingOn{{Elem: 0x5000}}, s.Goroutines[2].WaitingOn)
+	require.Nil(t, s.Goroutines[3].WaitingOn)
+	for _, g := range s.Goroutines {
+		require.Equal(t, []uint64{framePc}, g.Stack)
+	}
+	require.Equal(t, map[uint64][]uint64{0xc000: {1, 2}, 0xc100: {2}}, s.ChannelWaiters())
+
+	// Every channel is captured once.
+	require.Equal(t, []snapshotdata.Entry{
+		{Type: hchanType, Addr: 0xc000, Data: words(1, 2)},
+		{Type: hchanType, Addr: 0xc100, Data: words(3, 4)},
+	}, s.Pointees)
+}
+
+func TestDryRunStaticVariables(t *testing.T) {
+	const varType = 3
+	p := frameProgram(newEncoder(), 0, map[uint32]*snapshotpb.TypeInfo{
+		varType: {ByteLen: 16, SerializeBeforeEnqueue: true},
+	})
+	p.RuntimeConfig = &snapshotpb.RuntimeConfig{
+		StaticVariables: []*snapshotpb.RuntimeConfig_StaticVariable{
+			{Type: varType, Address: 0x4000},
+		},
+	}
+	s := dryRun(t, p, &SimulatedProcess{
+		Memory: SimulatedMemory{0x4000: words(1, 2)},
+	})
+	require.Empty(t, s.Goroutines)
+	require.Equal(t, []snapshotdata.Entry{
+		{Type: varType, Addr: 0x4000, Data: words(1, 2)},
+	}, s.Pointees)
+}
+
+func TestDryRunHMap(t *testing.T) {
+	const (
+		hmapType    = 2
+		bucketsType = 3
+		bucketLen   = 32
+	)
+	e := newEncoder()
+	pc := e.Encode(OpPrepareFrameData{ProgID: 1, DataByteLen: 8, TypeID: 1})
+	e.Encode(OpDereferenceCFAOffset{Offset: -8, ByteLen: 8})
+	e.Encode(OpEnqueuePointer{ElemType: hmapType})
+	e.Encode(OpConcludeFrameData{})
+	e.Encode(OpReturn{})
+	hmapPc := e.Encode(OpPushImm{Value: 1})
+	e.Encode(OpEnqueueHMapHeader{
+		BucketsArrayType: bucketsType,
+		BucketByteLen:    bucketLen,
+		FlagsOffset:      8,
+		BOffset:          9,
+		BucketsOffset:    16,
+		OldBucketsOffset: 24,
+	})
+	e.Encode(OpPop{})
+	e.Encode(OpReturn{})
+	p := frameProgram(e, pc, map[uint32]*snapshotpb.TypeInfo{
+		hmapType:    {ByteLen: 32, EnqueuePc: hmapPc, SerializeBeforeEnqueue: true},
+		bucketsType: {ByteLen: 1 << 10, SerializeBeforeEnqueue: true},
+	})
+
+	for _, tc := range []struct {
+		name         string
+		flags        uint8
+		oldBucketLen int
+	}{
+		// While a map doubles, the old buckets are half as many as the new.
+		{name: "growing", flags: 0, oldBucketLen: 2 * bucketLen},
+		{name: "same size growing", flags: 8, oldBucketLen: 4 * bucketLen},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// count, flags and B=2, buckets, oldbuckets
+			header := words(5, 0, 0xb000, 0xc000)
+			header[8], header[9] = tc.flags, 2
+			proc := &SimulatedProcess{
+				Memory: SimulatedMemory{
+					0x7000 - 8: words(0xa000),
+					0xa000:     header,
+					0xb000:     make([]byte, 4*bucketLen),
+					0xc000:     make([]byte, 4*bucketLen),
+				},
+				Goroutines: []SimulatedGoroutine{frameOf(1, 0x7000)},
+			}
+			s := dryRun(t, p, proc)
+			pointees := pointeesByKey(s)
+			require.Len(t, pointees, 3)
+			require.Equal(t, header, pointees[pointeeKey{hmapType, 0xa000}].Data)
+			require.Len(t, pointees[pointeeKey{bucketsType, 0xb000}].Data, 4*bucketLen)
+			require.Len(t, pointees[pointeeKey{bucketsType, 0xc000}].Data, tc.oldBucketLen)
+		})
+	}
+}
+
+func TestDryRunSwissMap(t *testing.T) {
+	const (
+		mapType           = 2
+		tablePtrSliceType = 3
+		groupType         = 4
+		tableType         = 5
+		groupSliceType    = 6
+		groupLen          = 24
+	)
+	e := newEncoder()
+	// A frame with two maps.
+	pc := e.Encode(OpPrepareFrameData{ProgID: 1, DataByteLen: 16, TypeID: 1})
+	e.Encode(OpDereferenceCFAOffset{Offset: -16, ByteLen: 16})
+	e.Encode(OpEnqueuePointer{ElemType: mapType})
+	e.Encode(OpAdvanceOffset{Increment: 8})
+	e.Encode(OpEnqueuePointer{ElemType: mapType})
+	e.Encode(OpConcludeFrameData{})
+	e.Encode(OpReturn{})
+	// The map header is a directory pointer followed by its length.
+	mapPc := e.Encode(OpEnqueueSwissMap{
+		TablePtrSliceType: tablePtrSliceType,
+		GroupType:         groupType,
+		DirPtrOffset:      0,
+		DirLenOffset:      8,
+	})
+	e.Encode(OpReturn{})
+	// The directory is a slice of table pointers.
+	dirPc := e.Encode(OpPushSliceLen{ElemByteLen: 8})
+	loop := e.Encode(OpEnqueuePointer{ElemType: tableType})
+	e.Encode(OpAdvanceOffset{Increment: 8})
+	e.Encode(OpDecrement{})
+	e.Encode(OpCondJump{Pc: loop})
+	e.Encode(OpPop{})
+	e.Encode(OpReturn{})
+	// A table is a pointer to its groups followed by their length mask.
+	tablePc := e.Encode(OpEnqueueSwissMapGroups{
+		GroupSliceType:   groupSliceType,
+		GroupByteLen:     groupLen,
+		DataOffset:       0,
+		LengthMaskOffset: 8,
+	})
+	e.Encode(OpReturn{})
+	p := frameProgram(e, pc, map[uint32]*snapshotpb.TypeInfo{
+		mapType:           {ByteLen: 16, EnqueuePc: mapPc, SerializeBeforeEnqueue: true},
+		tablePtrSliceType: {ByteLen: 1 << 10, EnqueuePc: dirPc, SerializeBeforeEnqueue: true},
+		groupType:         {ByteLen: groupLen, SerializeBeforeEnqueue: true},
+		tableType:         {ByteLen: 16, EnqueuePc: tablePc, SerializeBeforeEnqueue: true},
+		groupSliceType:    {ByteLen: 1 << 10, SerializeBeforeEnqueue: true},
+	})
+
+	proc := &SimulatedProcess{
+		Memory: SimulatedMemory{
+			0x7000 - 16: words(0x10000, 0x20000),
+			// A map with a directory of two tables.
+			0x10000: words(0x11000, 2),
+			0x11000: words(0x12000, 0x13000),
+			0x12000: words(0x14000, 1),
+			0x13000: words(0x15000, 0),
+			0x14000: make([]byte, 2*groupLen),
+			0x15000: make([]byte, groupLen),
+			// A small map, with a single group and no directory.
+			0x20000: words(0x21000, 0),
+			0x21000: make([]byte, groupLen),
+		},
+		Goroutines: []SimulatedGoroutine{frameOf(1, 0x7000)},
+	}
+	s := dryRun(t, p, proc)
+	pointees := pointeesByKey(s)
+	require.Len(t, pointees, 8)
+	for k, n := range map[pointeeKey]int{
+		{mapType, 0x10000}:           16,
+		{tablePtrSliceType, 0x11000}: 16,
+		{tableType, 0x12000}:         16,
+		{tableType, 0x13000}:         16,
+		{groupSliceType, 0x14000}:    2 * groupLen,
+		{groupSliceType, 0x15000}:    groupLen,
+		{mapType, 0x20000}:           16,
+		{groupType, 0x21000}:         groupLen,
+	} {
+		e, ok := pointees[k]
+		require.True(t, ok, "missing pointee %+v", k)
+		require.False(t, e.DereferenceFailed)
+		require.Len(t, e.Data, n, "pointee %+v", k)
+	}
+}
+
+func TestDryRunGoContext(t *testing.T) {
+	const (
+		frameType      = 1
+		contextType    = 20
+		valueCtxType   = 21
+		keyType        = 22
+		keyValueType   = 23
+		typedValueType = 24
+
+		typesStart = 0x50000
+	)
+	// The runtime type descriptors of the types, and their go runtime type
+	// ids.
+	const (
+		valueCtxRType   = typesStart + 0x100
+		keyRType        = typesStart + 0x200
+		keyValueRType   = typesStart + 0x300
+		typedValueRType = typesStart + 0x400
+		unknownRType    = typesStart + 0x500
+	)
+	e := newEncoder()
+	pc := e.Encode(OpPrepareFrameData{ProgID: 1, DataByteLen: 24, TypeID: frameType})
+	e.Encode(OpDereferenceCFAOffset{Offset: -24, ByteLen: 24})
+	e.Encode(OpPrepareGoContext{DataByteLen: 32, TypeID: contextType, CaptureCount: 2})
+	// The offset is restored after the traversal.
+	e.Encode(OpAdvanceOffset{Increment: 16})
+	e.Encode(OpZeroFill{ByteLen: 8})
+	e.Encode(OpConcludeFrameData{})
+	e.Encode(OpReturn{})
+	u32 := func(v uint32) *uint32 { return &v }
+	p := frameProgram(e, pc, map[uint32]*snapshotpb.TypeInfo{
+		// A context.valueCtx: its parent Context, then the key and the value.
+		valueCtxType: {ByteLen: 48, GoContextImpl: &snapshotpb.GoContextImpl{
+			ContextOffset: u32(0), KeyOffset: u32(16), ValueOffset: u32(32),
+		}},
+		// A key whose value is captured in the first slot.
+		keyType: {
+			ByteLen:               8,
+			GoContextKey:          &snapshotpb.GoContextValueType{Index: 0, Offset: 0},
+			GoContextKeyValueType: u32(keyValueType),
+		},
+		keyValueType: {ByteLen: 8, SerializeBeforeEnqueue: true},
+		// A value that is captured in the second slot regardless of its key.
+		typedValueType: {
+			ByteLen:                8,
+			SerializeBeforeEnqueue: true,
+			GoContextValue:         &snapshotpb.GoContextValueType{Index: 1, Offset: 16},
+		},
+	})
+	p.GoRuntimeTypeToTypeId = map[uint64]uint32{
+		valueCtxRType - typesStart:   valueCtxType,
+		keyRType - typesStart:        keyType,
+		keyValueRType - typesStart:   keyValueType,
+		typedValueRType - typesStart: typedValueType,
+	}
+
+	const valueCtxItab = 0x40000
+	proc := &SimulatedProcess{
+		Memory: SimulatedMemory{
+			// The frame holds a context.Context, followed by another word.
+			0x7000 - 24:  words(valueCtxItab, 0x30000, 0xffff),
+			valueCtxItab: words(0, valueCtxRType),
+			// The innermost context holds the key of the first slot.
+			0x30000: words(valueCtxItab, 0x31000, keyRType, 0x32000, keyValueRType, 0x33000),
+			// Its parent holds a value of the second slot, under an
+			// unknown key.
+			0x31000: words(valueCtxItab, 0x34000, unknownRType, 0x35000, typedValueRType, 0x36000),
+			0x33000: words(0x3333),
+			0x36000: words(0x3636),
+		},
+		Goroutines: []SimulatedGoroutine{frameOf(1, 0x7000)},
+		TypesStart: typesStart,
+		TypesEnd:   typesStart + 0x1000,
+	}
+	s := dryRun(t, p, proc)
+	require.Len(t, s.Goroutines, 1)
+	frames := s.Goroutines[0].Frames
+	require.Len(t, frames, 1)
+	require.Equal(t, uint64(0x30000), binary.NativeEndian.Uint64(frames[0].Data[8:]))
+	require.Zero(t, binary.NativeEndian.Uint64(frames[0].Data[16:]))
+	// The contexts traversed are not part of the snapshot; only the
+	// synthetic object holding the captured values is.
+	require.Equal(t, []snapshotdata.Entry{{
+		Type: contextType,
+		Addr: 0x30000,
+		Data: words(0x33000, keyValueRType-typesStart, 0x36000, typedValueRType-typesStart),
+	}}, frames[0].Entries)
+	require.Equal(t, []snapshotdata.Entry{
+		{Type: keyValueType, Addr: 0x33000, Data: words(0x3333)},
+		{Type: typedValueType, Addr: 0x36000, Data: words(0x3636)},
+	}, s.Pointees)
+}
+
+func TestDryRunInvalidProgram(t *testing.T) {
+	e := newEncoder()
+	pc := e.Encode(OpPop{})
+	e.Encode(OpReturn{})
+	_, err := DryRun(frameProgram(e, pc, nil), &SimulatedProcess{}, Options{})
+	require.ErrorContains(t, err, "invalid snapshot program")
+}
+
+func TestDryRunFramingVersion(t *testing.T) {
+	e := newEncoder()
+	pc := e.Encode(OpReturn{})
+	p := frameProgram(e, pc, nil)
+	g := frameOf(1, 0x1000)
+	g.Labels = map[string]string{"k": "v"}
+	proc := &SimulatedProcess{Goroutines: []SimulatedGoroutine{g}}
+
+	// By default, the headers have the layout of framing.h, and the
+	// goroutines have no labels.
+	res, err := DryRun(p, proc, Options{})
+	require.NoError(t, err)
+	require.Equal(t, framing.Version0, res.FramingVersion)
+	const stackBytes = 8
+	require.Len(t, res.Data, 56+40+stackBytes)
+	require.Equal(t, uint32(40+stackBytes), binary.NativeEndian.Uint32(res.Data[4:]))
+	s, err := snapshotdata.DecodeVersion(res.Data, res.FramingVersion)
+	require.NoError(t, err)
+	require.Len(t, s.Goroutines, 1)
+	require.Equal(t, uint64(1), s.Goroutines[0].Goid)
+	require.Nil(t, s.Goroutines[0].Labels)
+	require.Equal(t, []uint64{framePc}, s.Goroutines[0].Stack)
+
+	// Versions newer than the agent's are downgraded.
+	res, err = DryRun(p, proc, Options{FramingVersion: framing.CurrentVersion + 1})
+	require.NoError(t, err)
+	require.Equal(t, framing.CurrentVersion, res.FramingVersion)
+	const labelsBytes = 16
+	require.Len(t, res.Data, 64+64+labelsBytes+stackBytes)
+	s, err = snapshotdata.DecodeVersion(res.Data, res.FramingVersion)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"k": "v"}, s.Goroutines[0].Labels)
+	_, err = snapshotdata.DecodeVersion(res.Data, framing.CurrentVersion+1)
+	require.ErrorContains(t, err, "unsupported framing version")
+}
+
+func TestDryRunTruncatedGoroutines(t *testing.T) {
+	e := newEncoder()
+	pc := e.Encode(OpReturn{})
+	p := frameProgram(e, pc, nil)
+	var proc SimulatedProcess
+	for goid := uint64(1); goid <= 3; goid++ {
+		// Distinct stacks, so that every goroutine has its own pcs.
+		proc.Goroutines = append(proc.Goroutines, SimulatedGoroutine{
+			Goid:  goid,
+			Stack: []SimulatedFrame{{Pc: framePc, CFA: 0x1000}, {Pc: goid, CFA: 0x2000}},
+		})
+	}
+	// The header and two goroutines fit, but the third one only partly does.
+	const goroutineBytes = 64 + 16
+	res, err := DryRun(p, &proc, Options{
+		FramingVersion: framing.CurrentVersion,
+		MaxBytes:       64 + 2*goroutineBytes + goroutineBytes/2,
+	})
+	require.NoError(t, err)
+	s, err := snapshotdata.DecodeVersion(res.Data, res.FramingVersion)
+	require.NoError(t, err)
+	require.True(t, s.Truncated())
+	require.True(t, res.Truncated)
+	require.Len(t, s.Goroutines, 2)
+	require.Equal(t, uint32(2), s.Header.Statistics.NumGoroutines)
+
+	// The framing.h layout has no flags, but the response still reports that
+	// the snapshot was truncated.
+	const version0GoroutineBytes = 40 + 16
+	res, err = DryRun(p, &proc, Options{
+		FramingVersion: framing.Version0,
+		MaxBytes:       56 + 2*version0GoroutineBytes + version0GoroutineBytes/2,
+	})
+	require.NoError(t, err)
+	require.True(t, res.Truncated)
+	s, err = snapshotdata.DecodeVersion(res.Data, res.FramingVersion)
+	require.NoError(t, err)
+	require.Len(t, s.Goroutines, 2)
+
+	res, err = DryRun(p, &proc, Options{FramingVersion: framing.Version0})
+	require.NoError(t, err)
+	require.False(t, res.Truncated)
+}
+
+func TestSimulatedMemory(t *testing.T) {
+	m := SimulatedMemory{0x1000: words(1, 2)}
+	var dst [2]uint64
+	require.True(t, m.Dereference(unsafe.Pointer(&dst[0]), 0x1000, 16))
+	require.Equal(t, [2]uint64{1, 2}, dst)
+	require.True(t, m.Dereference(unsafe.Pointer(&dst[0]), 0x1008, 8))
+	require.Equal(t, uint64(2), dst[0])
+	require.False(t, m.Dereference(unsafe.Pointer(&dst[0]), 0x1008, 16))
+	require.False(t, m.Dereference(unsafe.Pointer(&dst[0]), 0xff8, 8))
+}
@@ -0,0 +1,104 @@
+package snapshot
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/DataExMachina-dev/side-eye-go/internal/allgs"
+	"github.com/DataExMachina-dev/side-eye-go/internal/machinapb"
+	"github.com/DataExMachina-dev/side-eye-go/internal/snapshotpb"
+)
+
+// goroutineFilter is the form of a machinapb.GoroutineFilter that is applied
+// with the world stopped. It is built ahead of time so that applying it does
+// not allocate.
+type goroutineFilter struct {
+	// pcRanges is sorted by start and non-overlapping.
+	pcRanges   []*machinapb.GoroutineFilter_PcRange
+	goids      map[uint64]struct{}
+	statusMask uint32
+	labels     []*machinapb.GoroutineFilter_LabelMatch
+}
+
+func makeGoroutineFilter(
+	f *machinapb.GoroutineFilter, cfg *snapshotpb.RuntimeConfig,
+) (goroutineFilter, error) {
+	if f == nil {
+		return goroutineFilter{}, nil
+	}
+	if len(f.Labels) > 0 && cfg.GLabelsOffset == 0 {
+		return goroutineFilter{}, fmt.Errorf("invalid goroutine filter: runtime config does not support labels")
+	}
+	gf := goroutineFilter{
+		statusMask: f.StatusMask,
+		labels:     f.Labels,
+	}
+	if len(f.Goids) > 0 {
+		gf.goids = make(map[uint64]struct{}, len(f.Goids))
+		for _, goid := range f.Goids {
+			gf.goids[goid] = struct{}{}
+		}
+	}
+	for _, r := range f.PcRanges {
+		if r.Start >= r.End {
+			return goroutineFilter{}, fmt.Errorf("invalid goroutine filter: empty pc range [%#x, %#x)", r.Start, r.End)
+		}
+		gf.pcRanges = append(gf.pcRanges, r)
+	}
+	sort.Slice(gf.pcRanges, func(i, j int) bool {
+		return gf.pcRanges[i].Start < gf.pcRanges[j].Start
+	})
+	// Merge overlapping ranges so that lookups can binary search.
+	merged := gf.pcRanges[:0]
+	for _, r := range gf.pcRanges {
+		if n := len(merged); n > 0 && r.Start <= merged[n-1].End {
+			if r.End > merged[n-1].End {
+				merged[n-1] = &machinapb.GoroutineFilter_PcRange{
+					Start: merged[n-1].Start, End: r.End,
+				}
+			}
+			continue
+		}
+		merged = append(merged, r)
+	}
+	gf.pcRanges = merged
+	return gf, nil
+}
+
+// matchesGoroutine applies the parts of the filter that do not depend on the
+// goroutine's stack, so that goroutines can be rejected before unwinding.
+func (f *goroutineFilter) matchesGoroutine(g allgs.Goroutine, status allgs.Status) bool {
+	if f.statusMask != 0 && (status >= 32 || f.statusMask&(1<<status) == 0) {
+		return false
+	}
+	if f.goids != nil {
+		if _, ok := f.goids[g.Goid()]; !ok {
+			return false
+		}
+	}
+	for _, l := range f.labels {
+		v, ok := g.Label(l.Key)
+		if !ok || (l.Value != "" && v != l.Value) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesStack returns whether any of the pcs, which are object file
+// addresses, falls in one of the filter's pc ranges.
+func (f *goroutineFilter) matchesStack(pcs []uintptr) bool {
+	if len(f.pcRanges) == 0 {
+		return true
+	}
+	for _, pc := range pcs {
+		pc := uint64(pc)
+		i := sort.Search(len(f.pcRanges), func(i int) bool {
+			return f.pcRanges[i].End > pc
+		})
+		if i < len(f.pcRanges) && pc >= f.pcRanges[i].Start {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,170 @@
+package snapshot
+
+import (
+	"runtime"
+	"testing"
+	"time"
+	"unsafe"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataExMachina-dev/side-eye-go/internal/allgs"
+	"github.com/DataExMachina-dev/side-eye-go/internal/machinapb"
+	"github.com/DataExMachina-dev/side-eye-go/internal/snapshotpb"
+)
+
+func TestGoroutineFilterPcRanges(t *testing.T) {
+	cfg := &snapshotpb.RuntimeConfig{}
+	f, err := makeGoroutineFilter(&machinapb.GoroutineFilter{
+		PcRanges: []*machinapb.GoroutineFilter_PcRange{
+			{Start: 0x300, End: 0x400},
+			{Start: 0x100, End: 0x200},
+			{Start: 0x180, End: 0x280},
+		},
+	}, cfg)
+	require.NoError(t, err)
+	require.Len(t, f.pcRanges, 2)
+	require.Equal(t, uint64(0x280), f.pcRanges[0].End)
+
+	require.True(t, f.matchesStack([]uintptr{0x50, 0x250}))
+	require.True(t, f.matchesStack([]uintptr{0x3ff}))
+	require.False(t, f.matchesStack([]uintptr{0x50, 0x280, 0x400}))
+	require.False(t, f.matchesStack(nil))
+
+	// An empty filter matches everything.
+	f, err = makeGoroutineFilter(nil, cfg)
+	require.NoError(t, err)
+	require.True(t, f.matchesStack([]uintptr{0x50}))
+
+	_, err = makeGoroutineFilter(&machinapb.GoroutineFilter{
+		PcRanges: []*machinapb.GoroutineFilter_PcRange{{Start: 0x100, End: 0x100}},
+	}, cfg)
+	require.Error(t, err)
+	_, err = makeGoroutineFilter(&machinapb.GoroutineFilter{
+		Labels: []*machinapb.GoroutineFilter_LabelMatch{{Key: "k"}},
+	}, cfg)
+	require.Error(t, err)
+}
+
+// fakeLabelMap is a runtime/pprof label map in fake memory, along with a
+// runtime config holding the label layout with which it is read.
+type fakeLabelMap struct {
+	ptr unsafe.Pointer
+	cfg *snapshotpb.RuntimeConfig
+}
+
+func TestGoroutineFilterGoroutine(t *testing.T) {
+	labelMaps := fakeLabels(map[string]string{"a": "1", "b": "2"})
+	defer runtime.KeepAlive(labelMaps)
+	for _, lm := range labelMaps {
+		cfg := lm.cfg
+		cfg.GGoidOffset = 0x8
+		cfg.GLabelsOffset = 0x10
+		mem := fakeMemory(4)
+		mem[1] = 42
+		mem[2] = uintptr(lm.ptr)
+		g := allgs.MakeGoroutine(unsafe.Pointer(&mem[0]), cfg)
+		matches := func(f *machinapb.GoroutineFilter, status allgs.Status) bool {
+			t.Helper()
+			gf, err := makeGoroutineFilter(f, cfg)
+			require.NoError(t, err)
+			return gf.matchesGoroutine(g, status)
+		}
+		type labels = []*machinapb.GoroutineFilter_LabelMatch
+
+		// An empty filter matches every goroutine.
+		require.True(t, matches(nil, allgs.Status_Gwaiting))
+		require.True(t, matches(&machinapb.GoroutineFilter{}, allgs.Status_Gwaiting))
+
+		waitingOrRunnable := &machinapb.GoroutineFilter{
+			StatusMask: 1<<allgs.Status_Gwaiting | 1<<allgs.Status_Grunnable,
+		}
+		require.True(t, matches(waitingOrRunnable, allgs.Status_Gwaiting))
+		require.True(t, matches(waitingOrRunnable, allgs.Status_Grunnable))
+		require.False(t, matches(waitingOrRunnable, allgs.Status_Gsyscall))
+		require.False(t, matches(waitingOrRunnable, allgs.Status_Gscanwaiting))
+
+		require.True(t, matches(&machinapb.GoroutineFilter{Goids: []uint64{1, 42}}, allgs.Status_Gwaiting))
+		require.False(t, matches(&machinapb.GoroutineFilter{Goids: []uint64{1, 2}}, allgs.Status_Gwaiting))
+
+		// A label with an empty value matches any value.
+		for _, tc := range []struct {
+			labels labels
+			want   bool
+		}{
+			{labels: labels{{Key: "a"}}, want: true},
+			{labels: labels{{Key: "a", Value: "1"}, {Key: "b", Value: "2"}}, want: true},
+			{labels: labels{{Key: "b", Value: "2"}, {Key: "a"}}, want: true},
+			{labels: labels{{Key: "a", Value: "2"}}, want: false},
+			{labels: labels{{Key: "a", Value: "1"}, {Key: "c"}}, want: false},
+		} {
+			require.Equal(t, tc.want, matches(&machinapb.GoroutineFilter{Labels: tc.labels}, allgs.Status_Gwaiting), "%v", tc.labels)
+		}
+
+		// Every criterion must be satisfied.
+		all := &machinapb.GoroutineFilter{
+			Goids:      []uint64{42},
+			StatusMask: 1 << allgs.Status_Gwaiting,
+			Labels:     labels{{Key: "b", Value: "2"}},
+		}
+		require.True(t, matches(all, allgs.Status_Gwaiting))
+		require.False(t, matches(all, allgs.Status_Grunnable))
+		all.Labels[0].Value = "1"
+		require.False(t, matches(all, allgs.Status_Gwaiting))
+
+		// A goroutine without labels only matches filters without labels.
+		mem[2] = 0
+		require.True(t, matches(&machinapb.GoroutineFilter{Goids: []uint64{42}}, allgs.Status_Gwaiting))
+		require.False(t, matches(&machinapb.GoroutineFilter{Labels: labels{{Key: "a"}}}, allgs.Status_Gwaiting))
+	}
+}
+
+func TestGoroutineFilterAccounting(t *testing.T) {
+	cfg := &snapshotpb.RuntimeConfig{
+		GGoidOffset:         0x8,
+		GAtomicstatusOffset: 0x10,
+		GSchedOffset:        0x18,
+		GGoBufPcOffset:      0x20,
+		GStktopspOffset:     0x28,
+	}
+	s := newSnapshotter(&snapshotpb.SnapshotProgram{
+		RuntimeConfig: cfg,
+		PcClassifier:  &snapshotpb.PcClassifier{},
+	}, Options{}, liveMemory{}, 0 /* numGoroutines */)
+	s.unwinder = newUnwinder(0 /* base */)
+	var err error
+	s.filter, err = makeGoroutineFilter(&machinapb.GoroutineFilter{
+		StatusMask: 1 << allgs.Status_Gwaiting,
+		PcRanges:   []*machinapb.GoroutineFilter_PcRange{{Start: 0x1000, End: 0x2000}},
+	}, cfg)
+	require.NoError(t, err)
+
+	goroutine := func(goid uint64, status allgs.Status, pc uintptr) allgs.Goroutine {
+		mem := fakeMemory(6)
+		mem[1], mem[2], mem[4] = uintptr(goid), uintptr(status), pc
+		return allgs.MakeGoroutine(unsafe.Pointer(&mem[0]), cfg)
+	}
+	gs := []allgs.Goroutine{
+		goroutine(1, allgs.Status_Gwaiting, 0x1800),
+		// Filtered out before unwinding, by its status.
+		goroutine(2, allgs.Status_Grunnable, 0x1800),
+		// Filtered out after unwinding, by its stack.
+		goroutine(3, allgs.Status_Gwaiting, 0x2800),
+		// Dead goroutines are not counted as filtered.
+		goroutine(4, allgs.Status_Gdead, 0x1800),
+		goroutine(5, allgs.Status_Gwaiting, 0x1000),
+	}
+	var captured []uint64
+	for _, g := range gs {
+		if s.snapshotGoroutine(&s.header, g) {
+			captured = append(captured, g.Goid())
+		}
+	}
+	runtime.KeepAlive(gs)
+	require.Equal(t, []uint64{1, 5}, captured)
+	require.Equal(t, uint32(2), s.header.FilteredGoroutines)
+	require.Equal(t, uint32(1), s.header.Statistics.NonLiveGoroutines)
+	res, err := s.response(time.Now(), 0 /* bssAddrShift */)
+	require.NoError(t, err)
+	require.Equal(t, uint32(2), res.FilteredGoroutines)
+}
@@ -0,0 +1,20 @@
+//go:build go1.20 && !go1.24
+
+package snapshot
+
+import (
+	"unsafe"
+
+	"github.com/DataExMachina-dev/side-eye-go/internal/snapshotpb"
+)
+
+// fakeLabels returns runtime/pprof label maps holding labels. Before go1.24,
+// a label map is a map[string]string, which is read with the map
+// implementation of the build; the runtime config has no layout for it.
+func fakeLabels(labels map[string]string) []fakeLabelMap {
+	m := make(map[string]string, len(labels))
+	for k, v := range labels {
+		m[k] = v
+	}
+	return []fakeLabelMap{{ptr: unsafe.Pointer(&m), cfg: &snapshotpb.RuntimeConfig{}}}
+}
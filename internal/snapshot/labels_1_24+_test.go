@@ -0,0 +1,59 @@
+//go:build go1.24
+
+package snapshot
+
+import (
+	"sort"
+	"unsafe"
+
+	"github.com/DataExMachina-dev/side-eye-go/internal/snapshotpb"
+)
+
+// pprofLabelMap mirrors runtime/pprof.labelMap since go1.24.
+type pprofLabelMap struct {
+	list []pprofLabel
+}
+
+type pprofLabel struct {
+	key   string
+	value string
+}
+
+// paddedLabelMap is a label map with a layout that differs from that of
+// runtime/pprof, which can only be read with the layout in the runtime config.
+type paddedLabelMap struct {
+	_    uint64
+	list []paddedLabel
+}
+
+type paddedLabel struct {
+	value string
+	_     uint64
+	key   string
+}
+
+// fakeLabels returns runtime/pprof label maps holding labels. Since go1.24, a
+// label map is a sorted list of labels, which is read with the layout in the
+// runtime config if it provides one, and with the layout of the build
+// otherwise; a map is returned for each.
+func fakeLabels(labels map[string]string) []fakeLabelMap {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	builtin, padded := &pprofLabelMap{}, &paddedLabelMap{}
+	for _, k := range keys {
+		builtin.list = append(builtin.list, pprofLabel{key: k, value: labels[k]})
+		padded.list = append(padded.list, paddedLabel{key: k, value: labels[k]})
+	}
+	return []fakeLabelMap{
+		{ptr: unsafe.Pointer(builtin), cfg: &snapshotpb.RuntimeConfig{}},
+		{ptr: unsafe.Pointer(padded), cfg: &snapshotpb.RuntimeConfig{
+			LabelMapListOffset: uint32(unsafe.Offsetof(padded.list)),
+			LabelByteLen:       uint32(unsafe.Sizeof(paddedLabel{})),
+			LabelKeyOffset:     uint32(unsafe.Offsetof(paddedLabel{}.key)),
+			LabelValueOffset:   uint32(unsafe.Offsetof(paddedLabel{}.value)),
+		}},
+	}
+}
@@ -0,0 +1,52 @@
+package snapshot
+
+import (
+	"unsafe"
+
+	"github.com/DataExMachina-dev/side-eye-go/internal/stoptheworld"
+)
+
+// Memory is the memory of the process that a snapshot reads from.
+type Memory interface {
+	// Dereference copies n bytes at addr into dst. It returns false if any
+	// of the bytes could not be read, in which case dst may have been
+	// partially written.
+	Dereference(dst unsafe.Pointer, addr uintptr, n int) bool
+}
+
+// liveMemory is the memory of the current process. It must only be read with
+// the world stopped, as it relies on the signal handler installed by
+// stoptheworld to recover from faults.
+type liveMemory struct{}
+
+// Dereference implements Memory. The addresses it reads are arbitrary, so
+// they are exempt from the checkptr instrumentation of race builds.
+//
+//go:nocheckptr
+func (liveMemory) Dereference(dst unsafe.Pointer, addr uintptr, n int) bool {
+	return stoptheworld.Dereference(dst, unsafe.Pointer(addr), n)
+}
+
+// SimulatedMemory is a memory image used to dry run snapshot programs. It maps
+// the start address of every readable region to the region's contents.
+type SimulatedMemory map[uint64][]byte
+
+// Dereference implements Memory. A read succeeds if it falls entirely within
+// one region.
+func (m SimulatedMemory) Dereference(dst unsafe.Pointer, addr uintptr, n int) bool {
+	if n < 0 {
+		return false
+	}
+	for start, region := range m {
+		if uint64(addr) < start {
+			continue
+		}
+		off := uint64(addr) - start
+		if off+uint64(n) > uint64(len(region)) {
+			continue
+		}
+		copy(unsafe.Slice((*byte)(dst), n), region[off:])
+		return true
+	}
+	return false
+}
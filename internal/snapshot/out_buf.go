@@ -4,19 +4,50 @@ import (
 	"unsafe"
 
 	"github.com/DataExMachina-dev/side-eye-go/internal/framing"
-	"github.com/DataExMachina-dev/side-eye-go/internal/stoptheworld"
 )
 
+// outBuf is the buffer that a snapshot is serialized into.
+//
+// The buffer is sized by reset before the world is stopped, so that writing to
+// it never allocates. Once data would exceed its capacity or maxLen, the
+// buffer is marked as full; a buffer that filled up below maxLen is grown by
+// the next reset.
 type outBuf struct {
 	out    []byte
+	maxLen uint32
 	isFull bool
+	// mem is the memory that Dereference reads from.
+	mem Memory
+	// trace, if set, records the failed dereferences.
+	trace *tracer
+	// version is the framing version of the data.
+	version uint32
+}
+
+func makeOutBuf(maxLen uint32) outBuf {
+	o := outBuf{mem: liveMemory{}}
+	o.reset(maxLen)
+	return o
 }
 
-func makeOutBuf(size uint32) outBuf {
-	return outBuf{
-		out:    make([]byte, 0, size),
-		isFull: false,
+// initialOutBufLen is the capacity of a new buffer, unless its maximum length
+// is smaller.
+const initialOutBufLen = 1 << 20
+
+// reset empties the buffer and sets its maximum length. The buffer's memory is
+// retained, and grown to twice the length of the previous snapshot, or to
+// twice its capacity if the previous snapshot filled it, up to maxLen.
+func (o *outBuf) reset(maxLen uint32) {
+	n := max(2*uint64(len(o.out)), initialOutBufLen)
+	if o.isFull {
+		n = max(n, 2*uint64(cap(o.out)))
+	}
+	if n = min(n, uint64(maxLen)); uint64(cap(o.out)) < n {
+		o.out = make([]byte, 0, n)
 	}
+	o.out = o.out[:0]
+	o.maxLen = maxLen
+	o.isFull = false
 }
 
 // GetEntryLen implements stackmachine.OutBuf.
@@ -26,31 +57,31 @@ func (o *outBuf) GetEntryLen(entryOffset uint32) uint32 {
 }
 
 // PrepareFrameData implements stackmachine.OutBuf.
+//
+// It returns the offset of the frame header, to be passed to
+// ConcludeFrameData, and the offset of the frame data.
 func (o *outBuf) PrepareFrameData(
 	typeID uint32,
 	progID uint32,
 	dataLen uint32,
 	depth uint32,
-) (*framing.FrameHeader, uint32, bool) {
+) (frameHeaderOffset uint32, offset uint32, ok bool) {
 	paddedLen := dataLen
 	rem := paddedLen % 8
 	if rem != 0 {
 		paddedLen += 8 - rem
 	}
-	frameHeaderOffset := o.Len()
-	newLen := len(o.out) +
-		int(unsafe.Sizeof(framing.FrameHeader{})) +
-		int(unsafe.Sizeof(framing.QueueEntry{})) +
-		int(paddedLen) +
+	frameHeaderOffset = o.Len()
+	newLen := frameHeaderOffset +
+		uint32(unsafe.Sizeof(framing.FrameHeader{})) +
+		uint32(unsafe.Sizeof(framing.QueueEntry{})) +
+		paddedLen +
 		8
-	if newLen > cap(o.out) {
-		o.isFull = true
-		return nil, 0, false
+	if !o.EnsureLen(newLen) {
+		return 0, 0, false
 	}
-	o.out = o.out[:newLen]
 	queueEntryOffset := frameHeaderOffset + uint32(unsafe.Sizeof(framing.FrameHeader{}))
-	var frameHeader *framing.FrameHeader = (*framing.FrameHeader)(o.Ptr(frameHeaderOffset))
-	*frameHeader = framing.FrameHeader{
+	*(*framing.FrameHeader)(o.Ptr(frameHeaderOffset)) = framing.FrameHeader{
 		// Actual length will be computed once frame is processed.
 		DataByteLen: queueEntryOffset,
 	}
@@ -59,13 +90,16 @@ func (o *outBuf) PrepareFrameData(
 		Len:  dataLen + 8,
 		Addr: 0,
 	}
-	offset := queueEntryOffset + uint32(unsafe.Sizeof(framing.QueueEntry{}))
+	offset = queueEntryOffset + uint32(unsafe.Sizeof(framing.QueueEntry{}))
 	*(*uint32)(o.Ptr(offset + dataLen)) = progID
 	*(*uint32)(o.Ptr(offset + dataLen + 4)) = depth
-	return frameHeader, offset, true
+	return frameHeaderOffset, offset, true
 }
 
-func (o *outBuf) ConcludeFrameData(frameHeader *framing.FrameHeader) {
+// ConcludeFrameData computes the length of the frame data for the frame
+// header at the given offset, as returned by PrepareFrameData.
+func (o *outBuf) ConcludeFrameData(frameHeaderOffset uint32) {
+	frameHeader := (*framing.FrameHeader)(o.Ptr(frameHeaderOffset))
 	frameHeader.DataByteLen = o.Len() - frameHeader.DataByteLen
 }
 
@@ -114,11 +148,8 @@ func (o *outBuf) Dereference(offset uint32, ptr uintptr, dereferenceLen uint32)
 	if offset+dereferenceLen > uint32(cap(o.out)) {
 		return false
 	}
-	if !stoptheworld.Dereference(
-		o.Ptr(offset),
-		unsafe.Pointer(ptr),
-		int(dereferenceLen),
-	) {
+	if !o.mem.Dereference(o.Ptr(offset), ptr, int(dereferenceLen)) {
+		o.trace.dereferenceFailed(ptr, dereferenceLen)
 		o.Zero(offset, dereferenceLen)
 		return false
 	}
@@ -130,11 +161,14 @@ func (o *outBuf) Len() uint32 {
 	return uint32(len(o.out))
 }
 
+// EnsureLen extends the outBuf to be at least minLen bytes long. If that
+// exceeds the maximum length or the capacity of the buffer, false is returned
+// and the outBuf is marked as full.
 func (o *outBuf) EnsureLen(minLen uint32) (ok bool) {
 	if minLen < o.Len() {
 		return true
 	}
-	if minLen > uint32(cap(o.out)) {
+	if minLen > o.maxLen || minLen > uint32(cap(o.out)) {
 		o.isFull = true
 		return false
 	}
@@ -146,26 +180,90 @@ func (o *outBuf) data() []byte {
 	return o.out
 }
 
-func (o *outBuf) writeSnapshotHeader() (*framing.SnapshotHeader, bool) {
+// reserveSnapshotHeader extends the outBuf to include room for the snapshot
+// header, which is written at the very beginning of the buffer by
+// writeSnapshotHeader once the snapshot is complete.
+func (o *outBuf) reserveSnapshotHeader() bool {
+	return o.EnsureLen(o.Len() + framing.SnapshotHeaderByteLen(o.version))
+}
+
+// writeSnapshotHeader writes the snapshot header into the space reserved by
+// reserveSnapshotHeader. Only the prefix of the header that is part of the
+// framing version is written.
+func (o *outBuf) writeSnapshotHeader(h *framing.SnapshotHeader) {
+	copy(o.out, unsafe.Slice((*byte)(unsafe.Pointer(h)), framing.SnapshotHeaderByteLen(o.version)))
+}
+
+// reserveGoroutineHeader extends the outBuf to include a new goroutine header
+// and returns its offset. If there is not enough room, false is returned.
+func (o *outBuf) reserveGoroutineHeader() (offset uint32, ok bool) {
+	offset = o.Len()
+	newLen := offset + framing.GoroutineHeaderByteLen(o.version)
+	if !o.EnsureLen(newLen) {
+		return 0, false
+	}
+	return offset, true
+}
+
+// writeGoroutineHeader writes the header of the goroutine whose header was
+// reserved at offset, filling in its data length from the length of the
+// outBuf. Only the prefix of the header that is part of the framing version is
+// written.
+func (o *outBuf) writeGoroutineHeader(offset uint32, h *framing.GoroutineHeader) {
+	headerLen := framing.GoroutineHeaderByteLen(o.version)
+	h.DataByteLen = o.Len() - offset - headerLen
+	copy(o.out[offset:], unsafe.Slice((*byte)(unsafe.Pointer(h)), headerLen))
+}
+
+// maxLabelsByteLen bounds the bytes spent on the pprof labels of a single
+// goroutine. Labels that do not fit are dropped.
+const maxLabelsByteLen = 4 << 10
+
+// writeLabel appends a pprof label to the labels of a goroutine, which start
+// at labelsOffset. It returns false if the label was not written, either
+// because the labels would exceed maxLabelsByteLen or because the outBuf is
+// full.
+func (o *outBuf) writeLabel(labelsOffset uint32, key, value string) bool {
+	labelLen := uint64(unsafe.Sizeof(framing.LabelHeader{})) + uint64(len(key)) + uint64(len(value))
+	if uint64(o.Len()-labelsOffset)+labelLen > maxLabelsByteLen {
+		return false
+	}
 	offset := o.Len()
-	newLen := len(o.out) + int(unsafe.Sizeof(framing.SnapshotHeader{}))
-	if newLen > cap(o.out) {
-		o.isFull = true
-		return nil, false
+	if !o.EnsureLen(offset + uint32(labelLen)) {
+		return false
+	}
+	*(*framing.LabelHeader)(o.Ptr(offset)) = framing.LabelHeader{
+		KeyLen:   uint32(len(key)),
+		ValueLen: uint32(len(value)),
 	}
-	o.out = o.out[:newLen]
-	return (*framing.SnapshotHeader)(o.Ptr(offset)), true
+	offset += uint32(unsafe.Sizeof(framing.LabelHeader{}))
+	offset += uint32(copy(o.out[offset:], key))
+	copy(o.out[offset:], value)
+	return true
 }
 
-// writeGoroutineHeader extends the outBuf to include a new goroutine header.
-// If there is not enough room, false is returned.
-func (o *outBuf) writeGoroutineHeader() (*framing.GoroutineHeader, bool) {
+// concludeLabels pads the labels of a goroutine, which start at labelsOffset,
+// to a multiple of 8 bytes and returns their unpadded length.
+func (o *outBuf) concludeLabels(labelsOffset uint32) (byteLen uint32, ok bool) {
+	byteLen = o.Len() - labelsOffset
+	end := o.Len()
+	if rem := byteLen % 8; rem != 0 {
+		if !o.EnsureLen(end + 8 - rem) {
+			return 0, false
+		}
+		o.Zero(end, 8-rem)
+	}
+	return byteLen, true
+}
+
+// writeWaitingOn appends a WaitingOn entry to the goroutine being written.
+func (o *outBuf) writeWaitingOn(w framing.WaitingOn) bool {
 	offset := o.Len()
-	newLen := offset + uint32(unsafe.Sizeof(framing.GoroutineHeader{}))
-	if !o.EnsureLen(newLen) {
-		return nil, false
+	if !o.EnsureLen(offset + uint32(unsafe.Sizeof(framing.WaitingOn{}))) {
+		return false
 	}
-	return (*framing.GoroutineHeader)(o.Ptr(offset)), true
+	*(*framing.WaitingOn)(o.Ptr(offset)) = w
+	return true
 }
 
 func (o *outBuf) full() bool {
@@ -198,6 +296,9 @@ func (o *outBuf) reserveQueueEntry(entry framing.QueueEntry) (dataOffset uint32,
 // the buffer is unmodified.
 func (o *outBuf) writeQueueEntry(entry framing.QueueEntry) (dataOffset uint32, ok bool) {
 	dataOffset, ok = o.reserveQueueEntry(entry)
+	if !ok {
+		return 0, false
+	}
 	if !o.Dereference(dataOffset, uintptr(entry.Addr), entry.Len) {
 		headerOffset := dataOffset - uint32(unsafe.Sizeof(framing.QueueEntry{}))
 		(*framing.QueueEntry)(o.Ptr(headerOffset)).Type |= (1 << 31)
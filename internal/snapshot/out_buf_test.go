@@ -0,0 +1,77 @@
+package snapshot
+
+import (
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataExMachina-dev/side-eye-go/internal/framing"
+)
+
+func TestOutBufFull(t *testing.T) {
+	o := makeOutBuf(1024)
+	require.Equal(t, 1024, cap(o.out))
+	require.True(t, o.reserveSnapshotHeader())
+
+	frameHeaderOffset, offset, ok := o.PrepareFrameData(1, 2, 8, 3)
+	require.True(t, ok)
+	*(*uint64)(o.Ptr(offset)) = 0xdeadbeef
+	stack := make([]uintptr, 64)
+	for i := range stack {
+		stack[i] = uintptr(i)
+	}
+	n, ok := o.writeStack(stack)
+	require.True(t, ok)
+	require.Equal(t, uint32(len(stack)*8), n)
+	o.ConcludeFrameData(frameHeaderOffset)
+	require.False(t, o.full())
+	require.Equal(t, uint64(0xdeadbeef), *(*uint64)(o.Ptr(offset)))
+	frameHeader := (*framing.FrameHeader)(o.Ptr(frameHeaderOffset))
+	require.Equal(t, o.Len()-frameHeaderOffset-uint32(unsafe.Sizeof(framing.FrameHeader{})), frameHeader.DataByteLen)
+
+	// Exceeding the maximum length marks the buffer as full and leaves it
+	// untouched.
+	before := o.Len()
+	_, ok = o.writeStack(make([]uintptr, 128))
+	require.False(t, ok)
+	require.True(t, o.full())
+	require.Equal(t, before, o.Len())
+	require.Equal(t, 1024, cap(o.out))
+
+	// A buffer with enough capacity is reused for a smaller maximum length.
+	o.reset(512)
+	require.False(t, o.full())
+	require.Equal(t, 1024, cap(o.out))
+	_, ok = o.writeStack(make([]uintptr, 65))
+	require.False(t, ok)
+}
+
+func TestOutBufGrows(t *testing.T) {
+	const maxLen = 4 * initialOutBufLen
+	o := makeOutBuf(maxLen)
+	require.Equal(t, initialOutBufLen, cap(o.out))
+
+	// Filling the buffer marks it as full below its maximum length, and the
+	// next reset doubles it.
+	require.False(t, o.EnsureLen(initialOutBufLen+1))
+	require.True(t, o.full())
+	require.Equal(t, initialOutBufLen, cap(o.out))
+	o.reset(maxLen)
+	require.False(t, o.full())
+	require.Equal(t, 2*initialOutBufLen, cap(o.out))
+
+	// It is grown to twice what the previous snapshot used.
+	require.True(t, o.EnsureLen(3*initialOutBufLen/2))
+	o.reset(maxLen)
+	require.Equal(t, 3*initialOutBufLen, cap(o.out))
+	// But it does not shrink, and it is not grown past the maximum length.
+	o.reset(maxLen)
+	require.Equal(t, 3*initialOutBufLen, cap(o.out))
+	require.True(t, o.EnsureLen(3*initialOutBufLen))
+	o.reset(maxLen)
+	require.Equal(t, maxLen, cap(o.out))
+	require.False(t, o.EnsureLen(maxLen+1))
+	o.reset(maxLen)
+	require.Equal(t, maxLen, cap(o.out))
+}
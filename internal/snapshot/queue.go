@@ -1,8 +1,8 @@
 package snapshot
 
 import (
-	"github.com/DataExMachina-dev/side-eye-go/internal/fifo"
 	"github.com/DataExMachina-dev/side-eye-go/internal/framing"
+	"github.com/DataExMachina-dev/side-eye-go/internal/snapshotpb"
 )
 
 type queueEntryKey struct {
@@ -10,50 +10,284 @@ type queueEntryKey struct {
 	t    uint32
 }
 
+// queueEntry is a pointee waiting to be captured, along with the root it was
+// reached from and the number of pointer hops from that root.
+type queueEntry struct {
+	framing.QueueEntry
+	root  uint32
+	depth uint32
+}
+
+// queueSlot is a node in the linked list of a root's pending entries.
+type queueSlot struct {
+	entry queueEntry
+	next  int32
+}
+
+// rootQueue tracks the pending entries of a root and the number of bytes that
+// capturing its pointees has used.
+type rootQueue struct {
+	head, tail int32
+	bytes      uint32
+}
+
+// skippedStats accumulates the pointees of a type that were not captured.
+type skippedStats struct {
+	entries uint32
+	bytes   uint64
+}
+
+// queue holds the pointees that remain to be captured. Every stack frame and
+// static variable is a root, and each root has its own FIFO of entries. Pop
+// alternates between the roots round-robin, so that a single large data
+// structure cannot starve the pointees of the other roots.
+//
+// The queue's memory is retained across snapshots as part of an Arena, and is
+// never grown while a snapshot is taken. Pointees that do not fit in seen or
+// in slots are skipped, and once roots is full, new roots share the last one;
+// overflowed reports either. The queue is sized by reset, before the world is
+// stopped.
 type queue struct {
-	seen map[queueEntryKey]struct{}
-	q    fifo.Queue[framing.QueueEntry]
+	seen seenSet
+
+	// slots holds the entries of all the roots; free is the head of the list
+	// of unused slots, or -1.
+	slots []queueSlot
+	free  int32
+	roots []rootQueue
+	// slotsFull and rootsFull are set if slots or roots, respectively,
+	// overflowed.
+	slotsFull, rootsFull bool
+	// active holds the roots with pending entries, in the order in which they
+	// will be popped from.
+	active ring
+	len    int
+
+	// curRoot and curDepth are assigned to the entries pushed until the next
+	// call to beginRoot or setContext.
+	curRoot  uint32
+	curDepth uint32
+
+	typeInfo map[uint32]*snapshotpb.TypeInfo
+	// skipped has an entry for every type in typeInfo, added by reset, so
+	// that recording a skipped pointee does not allocate.
+	skipped map[uint32]skippedStats
+
+	// trace, if set, records the entries that are not recorded.
+	trace *tracer
 }
 
-func makeQueue() queue {
-	return queue{
-		seen: make(map[queueEntryKey]struct{}, 16<<10),
-		q:    fifo.MakeQueue[framing.QueueEntry](),
+func makeQueue(typeInfo map[uint32]*snapshotpb.TypeInfo) queue {
+	q := queue{
+		seen:  makeSeenSet(32 << 10),
+		slots: make([]queueSlot, 0, 1<<10),
+		roots: make([]rootQueue, 0, 1<<10),
+	}
+	q.reset(typeInfo, 0 /* minRoots */)
+	return q
+}
+
+// reset empties the queue, retaining its memory. Every part of the queue is
+// grown to twice what the previous snapshot used, so that a snapshot that is
+// somewhat larger than the previous one still fits, and to at least minRoots
+// roots with a pending pointee each. The parts that overflowed used all of
+// their capacity, and are thus doubled.
+func (q *queue) reset(typeInfo map[uint32]*snapshotpb.TypeInfo, minRoots int) {
+	q.seen.reset(max(2*q.seen.maxLen, minRoots))
+	if n := max(2*len(q.slots), minRoots); n > cap(q.slots) {
+		q.slots = make([]queueSlot, 0, n)
+	}
+	q.slots = q.slots[:0]
+	q.free = -1
+	q.slotsFull = false
+	if n := max(2*len(q.roots), minRoots); n > cap(q.roots) {
+		q.roots = make([]rootQueue, 0, n)
+	}
+	q.roots = q.roots[:0]
+	q.rootsFull = false
+	// A root is active at most once.
+	q.active.reset(cap(q.roots))
+	q.len = 0
+	q.curRoot, q.curDepth = 0, 0
+	q.typeInfo = typeInfo
+	if q.skipped == nil {
+		q.skipped = make(map[uint32]skippedStats, len(typeInfo))
+	}
+	clear(q.skipped)
+	for t := range typeInfo {
+		q.skipped[t] = skippedStats{}
 	}
 }
 
 func (q *queue) Len() int {
-	return q.q.Len()
+	return q.len
 }
 
-func (q *queue) Pop() (r framing.QueueEntry, ok bool) {
-	if q.Len() == 0 {
-		return framing.QueueEntry{}, false
+// overflowed returns whether pointees were dropped, or roots merged, because
+// a part of the queue was full.
+func (q *queue) overflowed() bool {
+	return q.slotsFull || q.rootsFull || q.seen.full
+}
+
+// beginRoot starts a new root; subsequently pushed entries are attributed to
+// it, with the given depth.
+func (q *queue) beginRoot(depth uint32) {
+	if len(q.roots) == cap(q.roots) && len(q.roots) > 0 {
+		// Growing roots would allocate; the root shares the last one.
+		q.rootsFull = true
+		q.setContext(uint32(len(q.roots)-1), depth)
+		return
 	}
-	r = *q.q.PeekFront()
-	q.q.PopFront()
+	q.roots = append(q.roots, rootQueue{head: -1, tail: -1})
+	q.setContext(uint32(len(q.roots)-1), depth)
+}
+
+// setContext sets the root and the depth of subsequently pushed entries.
+func (q *queue) setContext(root uint32, depth uint32) {
+	q.curRoot = root
+	q.curDepth = depth
+}
+
+// rootBytes returns the number of bytes charged to the root so far.
+func (q *queue) rootBytes(root uint32) uint32 {
+	return q.roots[root].bytes
+}
+
+// chargeRoot adds to the bytes used by the root.
+func (q *queue) chargeRoot(root uint32, bytes uint32) {
+	q.roots[root].bytes += bytes
+}
+
+func (q *queue) Pop() (r queueEntry, ok bool) {
+	if q.len == 0 {
+		return queueEntry{}, false
+	}
+	root := q.active.popFront()
+	rq := &q.roots[root]
+	idx := rq.head
+	slot := &q.slots[idx]
+	r = slot.entry
+	rq.head = slot.next
+	if rq.head == -1 {
+		rq.tail = -1
+	} else {
+		q.active.pushBack(root)
+	}
+	slot.next = q.free
+	q.free = idx
+	q.len--
 	return r, true
 }
 
+// ShouldRecord marks the pointee at addr of type t as seen, and returns
+// whether it should be captured, i.e. it had not been seen before.
 func (q *queue) ShouldRecord(addr uintptr, t uint32) bool {
 	if addr == 0 {
+		q.trace.enqueueSkipped(addr, t)
 		return false
 	}
-	if _, ok := q.seen[queueEntryKey{addr: addr, t: t}]; ok {
+	added, ok := q.seen.insert(queueEntryKey{addr: addr, t: t})
+	if !ok {
+		q.recordSkipped(t, 0 /* dataLen */)
+	}
+	if !added {
+		q.trace.enqueueSkipped(addr, t)
 		return false
 	}
-	q.seen[queueEntryKey{addr: addr, t: t}] = struct{}{}
 	return true
 }
 
+// forget removes the pointee at addr of type t from the seen set, after it
+// was dropped without being captured, so that it can still be captured if it
+// is reached again.
+func (q *queue) forget(addr uintptr, t uint32) {
+	q.seen.remove(queueEntryKey{addr: addr, t: t})
+}
+
+// Push enqueues the pointee at addr of type t, unless it was already seen. A
+// pointee that is deeper than its type's maximum depth, or that does not fit
+// in the queue, is skipped without being marked as seen, so that it is still
+// captured if it is reached again at a shallower depth or once there is room.
+//
 // TODO: rethink this boolean return
 func (q *queue) Push(addr uintptr, t uint32, dataLen uint32) bool {
-	if q.ShouldRecord(addr, t) {
-		q.q.PushBack(framing.QueueEntry{
-			Addr: uint64(addr),
-			Type: t,
-			Len:  dataLen,
-		})
+	k := queueEntryKey{addr: addr, t: t}
+	if addr == 0 || q.seen.contains(k) {
+		q.trace.enqueueSkipped(addr, t)
+		return true
+	}
+	if ti, ok := q.typeInfo[t]; ok && ti.MaxDepth != 0 && q.curDepth > ti.MaxDepth {
+		q.recordSkipped(t, dataLen)
+		return true
+	}
+	if q.free == -1 && len(q.slots) == cap(q.slots) {
+		// Growing slots would allocate.
+		q.slotsFull = true
+		q.recordSkipped(t, dataLen)
+		return true
+	}
+	if _, ok := q.seen.insert(k); !ok {
+		q.recordSkipped(t, dataLen)
+		return true
 	}
+	if len(q.roots) == 0 {
+		q.beginRoot(q.curDepth)
+	}
+	idx := q.free
+	if idx == -1 {
+		idx = int32(len(q.slots))
+		q.slots = append(q.slots, queueSlot{})
+	} else {
+		q.free = q.slots[idx].next
+	}
+	q.slots[idx] = queueSlot{
+		entry: queueEntry{
+			QueueEntry: framing.QueueEntry{
+				Addr: uint64(addr),
+				Type: t,
+				Len:  dataLen,
+			},
+			root:  q.curRoot,
+			depth: q.curDepth,
+		},
+		next: -1,
+	}
+	rq := &q.roots[q.curRoot]
+	if rq.tail == -1 {
+		rq.head = idx
+		q.active.pushBack(q.curRoot)
+	} else {
+		q.slots[rq.tail].next = idx
+	}
+	rq.tail = idx
+	q.len++
 	return true
 }
+
+// recordSkipped records that a pointee of type t was not captured. If dataLen
+// is zero, the size of the type is used. Pointees of types that are not in
+// typeInfo are not recorded, as they would not be captured anyway.
+func (q *queue) recordSkipped(t uint32, dataLen uint32) {
+	s, ok := q.skipped[t]
+	if !ok {
+		return
+	}
+	if dataLen == 0 {
+		dataLen = q.typeInfo[t].GetByteLen()
+	}
+	s.entries++
+	s.bytes += uint64(dataLen)
+	q.skipped[t] = s
+}
+
+// skipRemaining records all the pending entries as skipped and empties the
+// queue.
+func (q *queue) skipRemaining() {
+	for {
+		e, ok := q.Pop()
+		if !ok {
+			return
+		}
+		q.recordSkipped(e.Type, e.Len)
+	}
+}
@@ -0,0 +1,146 @@
+package snapshot
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataExMachina-dev/side-eye-go/internal/snapshotpb"
+)
+
+func TestQueueRoundRobin(t *testing.T) {
+	q := makeQueue(map[uint32]*snapshotpb.TypeInfo{
+		1: {ByteLen: 8},
+		2: {ByteLen: 16, MaxDepth: 1},
+	})
+	q.beginRoot(1)
+	for addr := uintptr(0x100); addr < 0x104; addr++ {
+		q.Push(addr, 1, 0)
+	}
+	q.Push(0x100, 1, 0) // already seen
+	q.beginRoot(1)
+	q.Push(0x200, 1, 0)
+	q.Push(0x201, 1, 0)
+	require.Equal(t, 6, q.Len())
+
+	// Entries alternate between the roots.
+	var addrs []uint64
+	for i := 0; i < 4; i++ {
+		e, ok := q.Pop()
+		require.True(t, ok)
+		addrs = append(addrs, e.Addr)
+	}
+	require.Equal(t, []uint64{0x100, 0x200, 0x101, 0x201}, addrs)
+
+	// Entries pushed while processing an entry go to the entry's root, one
+	// level deeper. Type 2 is limited to depth 1.
+	q.setContext(1, 1)
+	q.Push(0x300, 2, 0)
+	q.setContext(1, 2)
+	q.Push(0x301, 2, 0)
+	require.Equal(t, skippedStats{entries: 1, bytes: 16}, q.skipped[2])
+
+	e, ok := q.Pop()
+	require.True(t, ok)
+	require.Equal(t, uint64(0x102), e.Addr)
+	e, ok = q.Pop()
+	require.True(t, ok)
+	require.Equal(t, uint64(0x300), e.Addr)
+	require.Equal(t, uint32(1), e.root)
+	require.Equal(t, uint32(1), e.depth)
+
+	q.skipRemaining()
+	require.Equal(t, 0, q.Len())
+	require.Equal(t, skippedStats{entries: 1, bytes: 8}, q.skipped[1])
+	_, ok = q.Pop()
+	require.False(t, ok)
+}
+
+func TestQueueDepths(t *testing.T) {
+	q := makeQueue(map[uint32]*snapshotpb.TypeInfo{
+		1: {ByteLen: 8, MaxDepth: 2},
+	})
+	// The pointee is first reached too deep, and skipped.
+	q.beginRoot(3)
+	q.Push(0x100, 1, 0)
+	require.Equal(t, 0, q.Len())
+	require.Equal(t, skippedStats{entries: 1, bytes: 8}, q.skipped[1])
+
+	// It is captured when it is reached again at a shallower depth.
+	q.beginRoot(1)
+	q.Push(0x100, 1, 0)
+	require.Equal(t, 1, q.Len())
+	e, ok := q.Pop()
+	require.True(t, ok)
+	require.Equal(t, uint64(0x100), e.Addr)
+	require.Equal(t, uint32(1), e.depth)
+
+	// Once captured, it is not enqueued again.
+	q.Push(0x100, 1, 0)
+	require.Equal(t, 0, q.Len())
+	require.Equal(t, skippedStats{entries: 1, bytes: 8}, q.skipped[1])
+}
+
+func TestQueueForget(t *testing.T) {
+	q := makeQueue(map[uint32]*snapshotpb.TypeInfo{1: {ByteLen: 8}})
+	q.beginRoot(1)
+	q.Push(0x100, 1, 0)
+	e, ok := q.Pop()
+	require.True(t, ok)
+
+	// A pointee that is dropped after being popped, e.g. because its root
+	// exhausted its budget, can be enqueued by another root.
+	q.forget(uintptr(e.Addr), e.Type)
+	q.beginRoot(1)
+	q.Push(0x100, 1, 0)
+	require.Equal(t, 1, q.Len())
+}
+
+func TestSeenSetRemove(t *testing.T) {
+	s := makeSeenSet(64)
+	want := make(map[queueEntryKey]bool)
+	rng := rand.New(rand.NewPCG(1, 2))
+	for i := 0; i < 10000; i++ {
+		// Up to 64 distinct keys fill half of the set, so that probe
+		// sequences collide and removals shift entries back.
+		k := queueEntryKey{addr: uintptr(1 + rng.IntN(32)), t: uint32(rng.IntN(2))}
+		if rng.IntN(2) == 0 {
+			added, ok := s.insert(k)
+			require.True(t, ok)
+			require.Equal(t, !want[k], added)
+			want[k] = true
+		} else {
+			s.remove(k)
+			delete(want, k)
+			require.False(t, s.contains(k))
+		}
+		require.Equal(t, len(want), s.len)
+		for k := range want {
+			require.True(t, s.contains(k))
+		}
+	}
+}
+
+func TestSeenSetFull(t *testing.T) {
+	q := makeQueue(map[uint32]*snapshotpb.TypeInfo{1: {ByteLen: 8}})
+	q.seen = makeSeenSet(8)
+	n := len(q.seen.slots) / 2
+	for i := 0; i < n; i++ {
+		added, ok := q.seen.insert(queueEntryKey{addr: uintptr(i + 1), t: 1})
+		require.True(t, added)
+		require.True(t, ok)
+	}
+	require.False(t, q.overflowed())
+	// The set is at its maximum load: the pointee is dropped, and the queue
+	// reports that it overflowed.
+	q.beginRoot(1)
+	q.Push(uintptr(n+1), 1, 0)
+	require.Equal(t, 0, q.Len())
+	require.True(t, q.overflowed())
+
+	// The next snapshot has room for twice as many pointees.
+	q.reset(q.typeInfo, 0 /* minRoots */)
+	require.False(t, q.overflowed())
+	require.Equal(t, 4*n, len(q.seen.slots))
+}
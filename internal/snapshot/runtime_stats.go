@@ -0,0 +1,114 @@
+package snapshot
+
+import (
+	"runtime/metrics"
+	"unsafe"
+
+	"github.com/DataExMachina-dev/side-eye-go/internal/machinapb"
+	"github.com/DataExMachina-dev/side-eye-go/internal/snapshotpb"
+)
+
+// sampledMetrics are the runtime/metrics reported with a snapshot. Those not
+// supported by the runtime are left out.
+var sampledMetrics = []string{
+	// Heap sizes.
+	"/gc/heap/live:bytes",
+	"/gc/heap/goal:bytes",
+	"/gc/heap/objects:objects",
+	"/memory/classes/heap/objects:bytes",
+	"/memory/classes/total:bytes",
+	// GC cycles.
+	"/gc/cycles/total:gc-cycles",
+	"/gc/cycles/automatic:gc-cycles",
+	"/gc/cycles/forced:gc-cycles",
+	// Scheduler.
+	"/sched/goroutines:goroutines",
+	"/sched/gomaxprocs:threads",
+	"/sched/latencies:seconds",
+	// Mutex wait.
+	"/sync/mutex/wait/total:seconds",
+}
+
+// readMetrics samples the sampledMetrics. It allocates, so it must be called
+// before the world is stopped.
+func readMetrics() []*machinapb.RuntimeStats_Metric {
+	samples := make([]metrics.Sample, len(sampledMetrics))
+	for i, name := range sampledMetrics {
+		samples[i].Name = name
+	}
+	metrics.Read(samples)
+	res := make([]*machinapb.RuntimeStats_Metric, 0, len(samples))
+	for _, s := range samples {
+		m := &machinapb.RuntimeStats_Metric{Name: s.Name}
+		switch s.Value.Kind() {
+		case metrics.KindUint64:
+			m.Value = &machinapb.RuntimeStats_Metric_Uint64Value{Uint64Value: s.Value.Uint64()}
+		case metrics.KindFloat64:
+			m.Value = &machinapb.RuntimeStats_Metric_Float64Value{Float64Value: s.Value.Float64()}
+		case metrics.KindFloat64Histogram:
+			h := s.Value.Float64Histogram()
+			m.Value = &machinapb.RuntimeStats_Metric_Float64Histogram{
+				Float64Histogram: &machinapb.RuntimeStats_Float64Histogram{
+					Counts:  h.Counts,
+					Buckets: h.Buckets,
+				},
+			}
+		default:
+			// The metric is not supported by the runtime.
+			continue
+		}
+		res = append(res, m)
+	}
+	return res
+}
+
+// memStats are the fields of runtime.memstats reported with a snapshot.
+type memStats struct {
+	lastGcUnix     uint64
+	lastGcNanotime uint64
+	pauseTotalNs   uint64
+	numGc          uint32
+	numForcedGc    uint32
+	gcCpuFraction  float64
+}
+
+// readMemStats reads the fields of the runtime.memstats at the given address
+// whose offsets the runtime config provides. The address is not derived from a
+// Go pointer, so it is exempt from the checkptr instrumentation of race builds.
+//
+//go:nocheckptr
+func readMemStats(cfg *snapshotpb.RuntimeConfig, memstats uintptr) memStats {
+	field := func(offset uint32) unsafe.Pointer {
+		return unsafe.Pointer(memstats + uintptr(offset))
+	}
+	ms := memStats{
+		lastGcUnix: *(*uint64)(field(cfg.MstatsLastGcUnixOffset)),
+	}
+	if cfg.MstatsLastGcNanotimeOffset != 0 {
+		ms.lastGcNanotime = *(*uint64)(field(cfg.MstatsLastGcNanotimeOffset))
+	}
+	if cfg.MstatsPauseTotalNsOffset != 0 {
+		ms.pauseTotalNs = *(*uint64)(field(cfg.MstatsPauseTotalNsOffset))
+	}
+	if cfg.MstatsNumgcOffset != 0 {
+		ms.numGc = *(*uint32)(field(cfg.MstatsNumgcOffset))
+	}
+	if cfg.MstatsNumforcedgcOffset != 0 {
+		ms.numForcedGc = *(*uint32)(field(cfg.MstatsNumforcedgcOffset))
+	}
+	if cfg.MstatsGcCpuFractionOffset != 0 {
+		ms.gcCpuFraction = *(*float64)(field(cfg.MstatsGcCpuFractionOffset))
+	}
+	return ms
+}
+
+func (ms memStats) proto() *machinapb.RuntimeStats_MemStats {
+	return &machinapb.RuntimeStats_MemStats{
+		LastGcUnixNs:   ms.lastGcUnix,
+		LastGcNanotime: ms.lastGcNanotime,
+		PauseTotalNs:   ms.pauseTotalNs,
+		NumGc:          ms.numGc,
+		NumForcedGc:    ms.numForcedGc,
+		GcCpuFraction:  ms.gcCpuFraction,
+	}
+}
@@ -0,0 +1,68 @@
+package snapshot
+
+import (
+	"runtime"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataExMachina-dev/side-eye-go/internal/machinapb"
+	"github.com/DataExMachina-dev/side-eye-go/internal/snapshotpb"
+)
+
+func TestReadMetrics(t *testing.T) {
+	byName := make(map[string]*machinapb.RuntimeStats_Metric)
+	for _, m := range readMetrics() {
+		byName[m.Name] = m
+	}
+	goroutines := byName["/sched/goroutines:goroutines"]
+	require.NotNil(t, goroutines)
+	require.NotZero(t, goroutines.GetUint64Value())
+	gomaxprocs := byName["/sched/gomaxprocs:threads"]
+	require.Equal(t, uint64(runtime.GOMAXPROCS(0)), gomaxprocs.GetUint64Value())
+	latencies := byName["/sched/latencies:seconds"].GetFloat64Histogram()
+	require.NotNil(t, latencies)
+	require.Len(t, latencies.Buckets, len(latencies.Counts)+1)
+	require.IsType(t, &machinapb.RuntimeStats_Metric_Float64Value{}, byName["/sync/mutex/wait/total:seconds"].GetValue())
+	require.NotZero(t, byName["/gc/heap/goal:bytes"].GetUint64Value())
+	require.NotNil(t, byName["/gc/cycles/total:gc-cycles"])
+
+	// Only the selected metrics are sampled.
+	require.Len(t, byName, len(sampledMetrics))
+	require.Nil(t, byName["/gc/heap/allocs-by-size:bytes"])
+}
+
+func TestReadMemStats(t *testing.T) {
+	var fake struct {
+		lastGcUnix     uint64
+		lastGcNanotime uint64
+		pauseTotalNs   uint64
+		numGc          uint32
+		numForcedGc    uint32
+		gcCpuFraction  float64
+	}
+	fake.lastGcUnix, fake.lastGcNanotime, fake.pauseTotalNs = 1, 2, 3
+	fake.numGc, fake.numForcedGc, fake.gcCpuFraction = 4, 5, 0.5
+	cfg := &snapshotpb.RuntimeConfig{
+		MstatsLastGcUnixOffset:     uint32(unsafe.Offsetof(fake.lastGcUnix)),
+		MstatsLastGcNanotimeOffset: uint32(unsafe.Offsetof(fake.lastGcNanotime)),
+		MstatsPauseTotalNsOffset:   uint32(unsafe.Offsetof(fake.pauseTotalNs)),
+		MstatsNumgcOffset:          uint32(unsafe.Offsetof(fake.numGc)),
+		MstatsNumforcedgcOffset:    uint32(unsafe.Offsetof(fake.numForcedGc)),
+		MstatsGcCpuFractionOffset:  uint32(unsafe.Offsetof(fake.gcCpuFraction)),
+	}
+	addr := uintptr(unsafe.Pointer(&fake))
+	require.Equal(t, &machinapb.RuntimeStats_MemStats{
+		LastGcUnixNs:   1,
+		LastGcNanotime: 2,
+		PauseTotalNs:   3,
+		NumGc:          4,
+		NumForcedGc:    5,
+		GcCpuFraction:  0.5,
+	}, readMemStats(cfg, addr).proto())
+
+	// Fields whose offsets are not provided are not read.
+	cfg = &snapshotpb.RuntimeConfig{MstatsLastGcUnixOffset: cfg.MstatsLastGcUnixOffset}
+	require.Equal(t, memStats{lastGcUnix: 1}, readMemStats(cfg, addr))
+}
@@ -3,6 +3,7 @@ package snapshot
 import (
 	"errors"
 	"fmt"
+	"runtime"
 	"sort"
 	"time"
 	"unsafe"
@@ -18,71 +19,122 @@ import (
 	"github.com/DataExMachina-dev/side-eye-go/internal/stoptheworld"
 )
 
-type moduledataTypeRange struct {
-	start uint64
-	end   uint64
+// DefaultMaxBytes is the default upper bound on the size of the snapshot data.
+const DefaultMaxBytes = 64 << 20
+
+// DefaultMaxBytesPerRoot is the default upper bound on the size of the
+// pointees captured from a single root.
+const DefaultMaxBytesPerRoot = 8 << 20
+
+// Options configure the execution of a snapshot.
+type Options struct {
+	// MaxBytes is the upper bound on the size of the snapshot data. If the
+	// data does not fit, the snapshot is truncated and marked as such. The
+	// buffer of an Arena only grows towards MaxBytes from one snapshot to the
+	// next, so a snapshot can be truncated below it until the buffer has
+	// grown to fit the process. If zero, DefaultMaxBytes is used.
+	MaxBytes uint32
+
+	// MaxBytesPerRoot bounds the bytes spent capturing the pointees reachable
+	// from a single root (a stack frame or a static variable). If zero,
+	// DefaultMaxBytesPerRoot is used.
+	MaxBytesPerRoot uint32
+
+	// GoroutineFilter, if set, restricts the goroutines that are captured.
+	GoroutineFilter *machinapb.GoroutineFilter
+
+	// Arena, if set, provides the memory for the snapshot, and the response's
+	// data aliases it. If nil, a new Arena is allocated.
+	Arena *Arena
+
+	// MaxPause, if non-zero, bounds the time for which the world is stopped.
+	// Once it elapses, the capture stops and the snapshot is marked as
+	// partial in its header.
+	MaxPause time.Duration
+	// MaxStackPause bounds the time spent capturing goroutine stacks, as a
+	// part of MaxPause, so that some of the pause is left for chasing
+	// pointers. If zero, half of MaxPause is used. It has no effect if
+	// MaxPause is zero.
+	MaxStackPause time.Duration
+
+	// Trace enables the tracing of the stack machine. The trace is returned
+	// in the response.
+	Trace bool
+
+	// FramingVersion is the newest layout of the snapshot data that the
+	// consumer can decode. The zero value is the layout of framing.h. The
+	// version that was written is reported in the response. Running
+	// goroutines that have no context to unwind their stack from are only
+	// written, with an empty stack and StackSourceNone, from Version1.
+	FramingVersion uint32
 }
 
-type moduledataConfig struct {
-	runtimeDotFirstmoduledata unsafe.Pointer
-	typesOffset               uintptr
-	etypesOffset              uintptr
-}
-
-type goRuntimeTypeResolver struct {
-	cfg                        moduledataConfig
-	cachedFirstmoduledataRange moduledataTypeRange
-	// TODO: Handle more than one moduledata. Go dynamically loaded libraries seem pretty rare.
+// pauseDeadlines returns the runtime clock readings after which stack capture
+// and the snapshot as a whole, respectively, must stop. Zero means no deadline.
+func (o Options) pauseDeadlines(pauseStart int64) (stacks, total int64) {
+	if o.MaxPause <= 0 {
+		return 0, 0
+	}
+	stackPause := o.MaxStackPause
+	if stackPause <= 0 || stackPause > o.MaxPause {
+		stackPause = o.MaxPause / 2
+	}
+	return pauseStart + int64(stackPause), pauseStart + int64(o.MaxPause)
 }
 
-func makeGoRuntimeTypeResolver(p *snapshotpb.RuntimeConfig, firstModuledata unsafe.Pointer) goRuntimeTypeResolver {
-	return goRuntimeTypeResolver{
-		cfg: moduledataConfig{
-			runtimeDotFirstmoduledata: firstModuledata,
-			typesOffset:               uintptr(p.ModuledataTypesOffset),
-			etypesOffset:              uintptr(p.ModuledataEtypesOffset),
-		},
+func (o Options) maxBytesPerRoot() uint32 {
+	if o.MaxBytesPerRoot == 0 {
+		return DefaultMaxBytesPerRoot
 	}
+	return o.MaxBytesPerRoot
 }
 
-func (m *goRuntimeTypeResolver) maybeResolveFirstmoduledataRange() {
-	if m.cachedFirstmoduledataRange.start != 0 {
-		return
+func (o Options) framingVersion() uint32 {
+	if o.FramingVersion > framing.CurrentVersion {
+		return framing.CurrentVersion
 	}
-
-	moduledataPtr := moduledata.GetFirstmoduledata()
-	types := *(*uint64)(unsafe.Pointer(uintptr(moduledataPtr) + m.cfg.typesOffset))
-	etypes := *(*uint64)(unsafe.Pointer(uintptr(moduledataPtr) + m.cfg.etypesOffset))
-	m.cachedFirstmoduledataRange = moduledataTypeRange{start: types, end: etypes}
+	return o.FramingVersion
 }
 
-func (m *goRuntimeTypeResolver) ResolveTypeAddressToGoRuntimeTypeId(addr uint64) uint64 {
-	m.maybeResolveFirstmoduledataRange()
-	r := m.cachedFirstmoduledataRange
-	if addr < r.start || addr >= r.end {
-		return 0
+func (o Options) maxBytes() uint32 {
+	if o.MaxBytes == 0 {
+		return DefaultMaxBytes
 	}
-	return addr - r.start
+	return o.MaxBytes
 }
 
-func Snapshot(p *snapshotpb.SnapshotProgram) (*machinapb.SnapshotResponse, error) {
+func Snapshot(p *snapshotpb.SnapshotProgram, opts Options) (*machinapb.SnapshotResponse, error) {
 	if err := stoptheworld.PlatformSupported(); err != nil {
 		return nil, err
 	}
 
+	if p.RuntimeConfig == nil {
+		return nil, fmt.Errorf("invalid snapshot program: missing runtime config")
+	}
 	if p.RuntimeConfig.StopTheWorldStartAddr == 0 ||
 		p.RuntimeConfig.StartTheWorldStartAddr == 0 {
 		return nil, fmt.Errorf("invalid runtime config: missing stoptheworld or starttheworld addresses")
 	}
-	b := newSnapshotter(p)
+	filter, err := makeGoroutineFilter(opts.GoroutineFilter, p.RuntimeConfig)
+	if err != nil {
+		return nil, err
+	}
+	b := newSnapshotter(p, opts, liveMemory{}, runtime.NumGoroutine())
+	b.filter = filter
+	b.unwinder = newUnwinder(stoptheworld.ComputeTextSectionBaseOffset(p.RuntimeConfig))
+	b.goRuntimeTypeResolver = makeGoRuntimeTypeResolver(p, moduledata.GetFirstmoduledata())
 	start := time.Now()
-	snapshotHeader, ok := b.out.writeSnapshotHeader()
-	if !ok {
+	if !b.out.reserveSnapshotHeader() {
 		return nil, fmt.Errorf("failed to write snapshot header")
 	}
+	snapshotHeader := &b.header
 
 	var iteratorErr error
 	var bssAddrShift uint64
+	var ms memStats
+	b.runtimeStats = &machinapb.RuntimeStats{Metrics: readMetrics()}
+	stackDeadline, deadline := opts.pauseDeadlines(boottime.Nanotime())
+	b.deadline = deadline
 	if !stoptheworld.StopTheWorld(p.RuntimeConfig, func() {
 		md := moduledata.GetFirstmoduledata()
 		// Calculate potential shift between virtual addresses according to debug information
@@ -90,8 +142,12 @@ func Snapshot(p *snapshotpb.SnapshotProgram) (*machinapb.SnapshotResponse, error
 		bssAddr := *(*uintptr)(unsafe.Pointer(uintptr(md) + uintptr(p.RuntimeConfig.ModuledataBssOffset)))
 		bssAddrShift = uint64(bssAddr) - p.RuntimeConfig.GoRuntimeBssAddress
 		b.sm.bssAddrShift = &bssAddrShift
+		// Record the time on the runtime's clock so that the goroutines' wait
+		// durations can be computed from their WaitSinceNanos.
+		snapshotHeader.KTimeNS = uint64(boottime.Nanotime())
 
 		for _, v := range p.RuntimeConfig.StaticVariables {
+			b.queue.beginRoot(0 /* depth */)
 			b.queue.Push(uintptr(v.Address+bssAddrShift), v.Type, 0)
 		}
 
@@ -101,24 +157,26 @@ func Snapshot(p *snapshotpb.SnapshotProgram) (*machinapb.SnapshotResponse, error
 			return
 		}
 		it.Iterate(func(g allgs.Goroutine) {
+			if b.partial || pastDeadline(stackDeadline) {
+				b.partial = true
+				return
+			}
 			before := b.out.Len()
-			b.snapshotGoroutine(snapshotHeader, g)
-			if b.out.full() {
+			if !b.snapshotGoroutine(snapshotHeader, g) || b.out.full() {
 				b.out.truncate(before)
+				return
 			}
+			snapshotHeader.Statistics.NumGoroutines++
 		})
 
 		afterStacks := time.Now()
 		snapshotHeader.Statistics.StacksDurationNs = uint64(afterStacks.Sub(start).Nanoseconds())
-		snapshotHeader.GoroutinesByteLen = b.out.Len() - uint32(unsafe.Sizeof(framing.SnapshotHeader{}))
+		snapshotHeader.GoroutinesByteLen = b.out.Len() - framing.SnapshotHeaderByteLen(b.out.version)
 		b.processQueue()
 
 		memstatsBssOffset := p.RuntimeConfig.VariableRuntimeDotMemstats - p.RuntimeConfig.GoRuntimeBssAddress
-		snapshotHeader.LastGcUnix = *(*uint64)(unsafe.Pointer(
-			bssAddr +
-				uintptr(memstatsBssOffset) +
-				uintptr(p.RuntimeConfig.MstatsLastGcUnixOffset),
-		))
+		ms = readMemStats(p.RuntimeConfig, bssAddr+uintptr(memstatsBssOffset))
+		snapshotHeader.LastGcUnix = ms.lastGcUnix
 		snapshotHeader.Statistics.PointerDurationNs = uint64(time.Since(afterStacks).Nanoseconds())
 	}) {
 		return nil, fmt.Errorf("failed to execute snapshot")
@@ -126,8 +184,26 @@ func Snapshot(p *snapshotpb.SnapshotProgram) (*machinapb.SnapshotResponse, error
 	if iteratorErr != nil {
 		return nil, fmt.Errorf("failed to construct goroutine iterator: %w", iteratorErr)
 	}
-	snapshotHeader.DataByteLen = b.out.Len()
+	b.runtimeStats.MemStats = ms.proto()
+	return b.response(start, bssAddrShift)
+}
+
+// response completes the snapshot header and returns the response for a
+// snapshot that started at the given time.
+func (s *snapshotter) response(start time.Time, bssAddrShift uint64) (*machinapb.SnapshotResponse, error) {
+	snapshotHeader := &s.header
+	if s.out.full() {
+		snapshotHeader.Flags |= framing.SnapshotFlagTruncated
+	}
+	if s.partial {
+		snapshotHeader.Flags |= framing.SnapshotFlagPartial
+	}
+	if s.queue.overflowed() {
+		snapshotHeader.Flags |= framing.SnapshotFlagDropped
+	}
+	snapshotHeader.DataByteLen = s.out.Len()
 	snapshotHeader.Statistics.TotalDurationNs = uint64(time.Since(start).Nanoseconds())
+	s.out.writeSnapshotHeader(snapshotHeader)
 	var approximateBootTime *timestamppb.Timestamp
 	if bootTime, err := boottime.BootTime(); err == nil {
 		approximateBootTime = timestamppb.New(bootTime)
@@ -135,81 +211,280 @@ func Snapshot(p *snapshotpb.SnapshotProgram) (*machinapb.SnapshotResponse, error
 		return nil, fmt.Errorf("failed to get boot time: %w", err)
 	}
 	return &machinapb.SnapshotResponse{
-		Data:                b.out.data(),
+		Data:                s.out.data(),
 		Timestamp:           timestamppb.New(start),
 		PauseDurationNs:     snapshotHeader.Statistics.TotalDurationNs,
 		ApproximateBootTime: approximateBootTime,
 		BssAddrShift:        bssAddrShift,
+		DataByteLen:         uint64(s.out.Len()),
+		Truncated:           snapshotHeader.Flags&framing.SnapshotFlagTruncated != 0,
+		Partial:             snapshotHeader.Flags&framing.SnapshotFlagPartial != 0,
+		Dropped:             snapshotHeader.Flags&framing.SnapshotFlagDropped != 0,
+		FilteredGoroutines:  snapshotHeader.FilteredGoroutines,
+		SkippedPointees:     s.skippedPointees(),
+		Trace:               s.trace.proto(),
+		RuntimeStats:        s.runtimeStats,
+		FramingVersion:      s.out.version,
 	}, nil
 }
 
-func newSnapshotter(p *snapshotpb.SnapshotProgram) *snapshotter {
+// skippedPointees returns the statistics of the pointees that were not
+// captured, keyed by type id.
+func (s *snapshotter) skippedPointees() map[uint32]*machinapb.SkippedPointees {
+	var m map[uint32]*machinapb.SkippedPointees
+	for t, st := range s.queue.skipped {
+		if st.entries == 0 {
+			continue
+		}
+		if m == nil {
+			m = make(map[uint32]*machinapb.SkippedPointees)
+		}
+		m[t] = &machinapb.SkippedPointees{
+			Count:   st.entries,
+			ByteLen: st.bytes,
+		}
+	}
+	return m
+}
+
+// newSnapshotter returns a snapshotter whose stack machine reads from mem, and
+// whose arena is sized for the given number of goroutines. The unwinder and
+// the goRuntimeTypeResolver are left for the caller to set up.
+func newSnapshotter(
+	p *snapshotpb.SnapshotProgram, opts Options, mem Memory, numGoroutines int,
+) *snapshotter {
 	var b snapshotter
 	b.p = p
-	b.stacks = make(map[uint64][]frameOfInterest, 512 /* arbitrary */)
-	b.out = makeOutBuf(1 << 20)
-	b.queue = makeQueue()
-	base := stoptheworld.ComputeTextSectionBaseOffset(p.RuntimeConfig)
-	b.unwinder = newUnwinder(base)
-	b.goRuntimeTypeResolver = makeGoRuntimeTypeResolver(p.RuntimeConfig, moduledata.GetFirstmoduledata())
+	b.arena = opts.Arena
+	if b.arena == nil {
+		b.arena = NewArena()
+	}
+	b.arena.reset(opts.maxBytes(), p.TypeInfo, numGoroutines, len(p.GetRuntimeConfig().GetStaticVariables()))
+	b.out = &b.arena.out
+	b.out.mem = mem
+	b.out.version = opts.framingVersion()
+	b.queue = &b.arena.queue
+	if opts.Trace {
+		b.trace = newTracer(p)
+	}
+	b.out.trace = b.trace
+	b.queue.trace = b.trace
+	b.maxBytesPerRoot = opts.maxBytesPerRoot()
 	b.typeIdResolver = typeIdResolver{types: p.GoRuntimeTypeToTypeId}
-	b.sm = newStackMachine(b.p, &b.queue, &b.out, &b.goRuntimeTypeResolver, &b.typeIdResolver)
+	b.sm = newStackMachine(b.p, b.queue, b.out, &b.goRuntimeTypeResolver, &b.typeIdResolver)
+	b.sm.trace = b.trace
 	return &b
 }
 
 const maxStackFrames = 512
 
 type snapshotter struct {
-	stacks                map[uint64][]frameOfInterest
+	// header is accumulated while the snapshot is taken, and written to the
+	// beginning of out once the snapshot is complete.
+	header framing.SnapshotHeader
+	// arena holds the memory used with the world stopped; out and queue
+	// point into it.
+	arena                 *Arena
 	goRuntimeTypeResolver goRuntimeTypeResolver
 	typeIdResolver        typeIdResolver
-	out                   outBuf
-	queue                 queue
+	out                   *outBuf
+	queue                 *queue
 	unwinder              *unwinder
 	p                     *snapshotpb.SnapshotProgram
 	sm                    *stackMachine
+	filter                goroutineFilter
+	maxBytesPerRoot       uint32
+	// deadline is the runtime clock reading after which pointer chasing
+	// stops, or zero.
+	deadline int64
+	// partial is set if the capture was cut short by a deadline.
+	partial bool
+	// trace is set if the stack machine is traced.
+	trace *tracer
+	// runtimeStats, if set, are reported in the response.
+	runtimeStats *machinapb.RuntimeStats
+
+	// Used while reading sudogs to avoid allocations.
+	sudogBuf struct {
+		framing.WaitingOn
+		next uint64
+	}
+}
+
+// deadlineCheckInterval is the number of queue entries processed between
+// checks of the deadline, to amortize reading the clock.
+const deadlineCheckInterval = 64
+
+func pastDeadline(deadline int64) bool {
+	return deadline != 0 && boottime.Nanotime() > deadline
 }
 
-func (s *snapshotter) snapshotGoroutine(snapshotHeader *framing.SnapshotHeader, g allgs.Goroutine) {
+// snapshotGoroutine writes g to the snapshot, unless it is dead, filtered out
+// or cannot be unwound, or the buffer is full. It returns whether g was
+// written; the caller counts it and truncates what was left of it otherwise.
+func (s *snapshotter) snapshotGoroutine(snapshotHeader *framing.SnapshotHeader, g allgs.Goroutine) bool {
 	if s.out.full() {
-		return
+		return false
 	}
 
 	status := g.Status() & (^allgs.Status(allgs.Status_Gscan))
-	if status == allgs.Status_Gdead {
+	if status.IsDead() {
 		snapshotHeader.Statistics.NonLiveGoroutines++
-		return
+		return false
 	}
 	// This is our goroutine, we can't unwind it because we don't have the context.
 	// Also, we don't care to.
-	if status == allgs.Status_Grunning {
-		return
+	if g.Ptr() == stoptheworld.CurrentG() {
+		return false
 	}
 
-	snapshotHeader.Statistics.NumGoroutines++
+	if !s.filter.matchesGoroutine(g, status) {
+		snapshotHeader.FilteredGoroutines++
+		return false
+	}
+
+	pc, bp, stackSource := goroutineContext(g, status)
+	var pcs, fps []uintptr
+	if stackSource == framing.StackSourceNone {
+		// The consumers of framing.h expect every goroutine to have a
+		// stack.
+		if s.out.version == framing.Version0 {
+			return false
+		}
+	} else {
+		pcs, fps = s.unwinder.walkStack(pc, bp, g.Stktopsp())
+	}
+	if !s.filter.matchesStack(pcs) {
+		snapshotHeader.FilteredGoroutines++
+		return false
+	}
 
-	// TODO(https://github.com/DataExMachina-dev/side-eye/issues/756): This
-	// should use syscallpc and the syscall frame, but in go versions before
-	// 1.23, the syscall base pointer is not recorded. Some degree of unwinding
-	// is needed.
-	pcs, fps := s.unwinder.walkStack(g.PC(), g.BP(), g.Stktopsp())
-	stackHash := murmur2(pcs, 0 /* seed */)
-	goroutineHeader, ok := s.out.writeGoroutineHeader()
+	headerOffset, ok := s.out.reserveGoroutineHeader()
 	if !ok {
-		return
+		return false
+	}
+	// The labels and the WaitingOn entries are only part of the newer
+	// framing versions.
+	var labelsByteLen, numWaitingOn uint32
+	if s.out.version != framing.Version0 {
+		labelsOffset := s.out.Len()
+		g.RangeLabels(func(key, value string) bool {
+			return s.out.writeLabel(labelsOffset, key, value)
+		})
+		if labelsByteLen, ok = s.out.concludeLabels(labelsOffset); !ok {
+			return false
+		}
+		if status == allgs.Status_Gwaiting {
+			if numWaitingOn, ok = s.writeWaitingOn(g.Waiting()); !ok {
+				return false
+			}
+		}
+	}
+	// The creator pc is made relative to the same base as the stack's pcs.
+	var creatorPc uint64
+	if gopc := g.Gopc(); gopc != 0 {
+		creatorPc = uint64(gopc - s.unwinder.base)
+	}
+	return s.writeGoroutine(headerOffset, framing.GoroutineHeader{
+		Goid:           g.Goid(),
+		Status:         uint32(status),
+		WaitReason:     uint8(g.WaitReason()),
+		StackSource:    stackSource,
+		WaitSinceNanos: g.WaitSince(),
+		LabelsByteLen:  labelsByteLen,
+		NumWaitingOn:   numWaitingOn,
+		CreatorPc:      creatorPc,
+		ParentGoid:     g.ParentGoid(),
+	}, pcs, fps)
+}
+
+// writeGoroutine completes a goroutine whose header was reserved at
+// headerOffset and whose labels follow it: it captures the stack, unless it is
+// empty, and then writes the header, with its stack hash and data length
+// filled in. It returns whether the stack could be captured.
+func (s *snapshotter) writeGoroutine(
+	headerOffset uint32, h framing.GoroutineHeader, pcs []uintptr, fps []uintptr,
+) bool {
+	if len(pcs) > 0 {
+		stackHash, stackBytes, ok := s.captureStack(pcs, fps)
+		if !ok {
+			return false
+		}
+		h.StackHash = stackHash
+		h.StackBytes = stackBytes
+	}
+	s.out.writeGoroutineHeader(headerOffset, &h)
+	return true
+}
+
+// maxWaitingOn bounds the sudogs recorded for a goroutine, as a select may
+// wait on any number of channels.
+const maxWaitingOn = 64
+
+// writeWaitingOn writes a WaitingOn entry for every sudog in the g.waiting list
+// that starts at sudog, and enqueues the channels for chasing. It returns the
+// number of entries written.
+func (s *snapshotter) writeWaitingOn(sudog uintptr) (n uint32, ok bool) {
+	cfg := s.p.GetRuntimeConfig()
+	if cfg.GetSudogWaitlinkOffset() == 0 {
+		return 0, true
+	}
+	// The channels of a goroutine form a root of their own, which is only
+	// started once there is a channel to chase.
+	haveRoot := false
+	for sudog != 0 && n < maxWaitingOn {
+		w, next := &s.sudogBuf.WaitingOn, &s.sudogBuf.next
+		if !s.out.mem.Dereference(unsafe.Pointer(&w.Chan), sudog+uintptr(cfg.SudogCOffset), 8) ||
+			!s.out.mem.Dereference(unsafe.Pointer(&w.Elem), sudog+uintptr(cfg.SudogElemOffset), 8) ||
+			!s.out.mem.Dereference(unsafe.Pointer(next), sudog+uintptr(cfg.SudogWaitlinkOffset), 8) {
+			break
+		}
+		if !s.out.writeWaitingOn(*w) {
+			return 0, false
+		}
+		n++
+		if w.Chan == 0 {
+			// The waitlink of a semaphore's sudog links it to the other
+			// waiters on the semaphore, not to more sudogs of this
+			// goroutine.
+			break
+		}
+		if cfg.HchanType != 0 {
+			if !haveRoot {
+				s.queue.beginRoot(1 /* depth */)
+				haveRoot = true
+			}
+			s.queue.Push(uintptr(w.Chan), cfg.HchanType, 0)
+		}
+		sudog = uintptr(*next)
 	}
-	afterHeader := s.out.Len()
-	framesOfInterest, haveStack := s.stacks[stackHash]
-	var stackBytes uint32
+	return n, true
+}
+
+// captureStack writes the stack to the output, unless a stack with the same
+// hash has already been written, and runs the stack machine for the frames of
+// interest. stackBytes is zero if the stack was already in the output.
+func (s *snapshotter) captureStack(
+	pcs []uintptr, fps []uintptr,
+) (stackHash uint64, stackBytes uint32, ok bool) {
+	stackHash = murmur2(pcs, 0 /* seed */)
+	start, end, haveStack := s.arena.stacks.get(stackHash)
 
 	// If the stack with this hash isn't in the output, write it, and
 	// classify the frames of interest.
-	if !haveStack {
+	var framesOfInterest []frameOfInterest
+	if haveStack {
+		framesOfInterest = s.arena.frames[start:end]
+	} else {
 		stackBytes, ok = s.out.writeStack(pcs)
 		if !ok {
-			return
+			return 0, 0, false
 		}
+		framesOfInterest = s.arena.classified[:0]
 		for i := range pcs {
+			if len(framesOfInterest) == cap(framesOfInterest) {
+				break
+			}
 			pc := uint64(pcs[i])
 			j := sort.Search(len(s.p.PcClassifier.TargetPc), func(j int) bool {
 				return pc <= s.p.PcClassifier.TargetPc[j]
@@ -221,7 +496,7 @@ func (s *snapshotter) snapshotGoroutine(snapshotHeader *framing.SnapshotHeader,
 				})
 			}
 		}
-		s.stacks[stackHash] = framesOfInterest
+		s.arena.cacheFrames(stackHash, framesOfInterest)
 	}
 
 	// Run the stack machine program to write out the data from the stack frames
@@ -232,54 +507,89 @@ func (s *snapshotter) snapshotGoroutine(snapshotHeader *framing.SnapshotHeader,
 		framesOfInterest, foi =
 			framesOfInterest[:len(framesOfInterest)-1],
 			framesOfInterest[len(framesOfInterest)-1]
+		// Each frame is a root of its own, so that all frames get a share of
+		// the pointer chasing.
+		s.queue.beginRoot(1 /* depth */)
 		if !s.sm.Run(foi.pc, fps[foi.idx], foi.idx, s.out.Len()) {
 			break
 		}
 	}
+	return stackHash, stackBytes, true
+}
 
-	*goroutineHeader = framing.GoroutineHeader{
-		Goid:           g.Goid(),
-		StackHash:      stackHash,
-		Status:         uint32(status),
-		WaitReason:     0,
-		WaitSinceNanos: 0,
-		StackBytes:     stackBytes,
-		DataByteLen:    s.out.Len() - afterHeader,
+// goroutineContext returns the pc and frame pointer from which to unwind the
+// stack of g. Goroutines in a syscall have their context saved in g.syscallpc
+// and g.syscallbp; before go1.23 the latter is not recorded, and we fall back
+// to g.sched. Other goroutines that are not running have their context saved
+// in g.sched. A goroutine that is still marked running after the world has
+// been stopped has its context in g.sched if it was preempted; otherwise, the
+// source is StackSourceNone: the registers of a running goroutine are not
+// saved anywhere that can be read while the world is stopped.
+func goroutineContext(
+	g allgs.Goroutine, status allgs.Status,
+) (pc uintptr, bp unsafe.Pointer, source framing.StackSource) {
+	if status == allgs.Status_Gsyscall && g.SyscallSP() != 0 {
+		if bp := g.SyscallBP(); bp != nil {
+			return g.SyscallPC(), bp, framing.StackSourceSyscall
+		}
+	}
+	if status != allgs.Status_Grunning || g.SchedSP() != 0 {
+		return g.PC(), g.BP(), framing.StackSourceSched
 	}
+	return 0, nil, framing.StackSourceNone
 }
 
 func (s *snapshotter) processQueue() {
-	for !s.out.full() {
-		entry, ok := s.queue.Pop()
-		if !ok {
+	for i := 0; !s.out.full(); i++ {
+		if i%deadlineCheckInterval == 0 && pastDeadline(s.deadline) {
+			s.partial = true
 			break
 		}
-		ti, ok := s.p.TypeInfo[entry.Type]
+		entry, ok := s.queue.Pop()
 		if !ok {
-			continue
-		}
-		if entry.Len == 0 {
-			entry.Len = ti.ByteLen
-		}
-		if entry.Len > ti.ByteLen {
-			entry.Len = ti.ByteLen
+			break
 		}
-		if entry.Len == 0 {
+		if s.queue.rootBytes(entry.root) >= s.maxBytesPerRoot {
+			// Another root may still capture the pointee.
+			s.queue.recordSkipped(entry.Type, entry.Len)
+			s.queue.forget(uintptr(entry.Addr), entry.Type)
 			continue
 		}
-		offset := uint32(0)
-		if ti.SerializeBeforeEnqueue {
-			offset, ok = s.out.writeQueueEntry(entry)
-			if !ok {
-				continue
-			}
-		}
-		if ti.EnqueuePc == 0 {
-			continue
+		before := s.out.Len()
+		s.queue.setContext(entry.root, entry.depth+1)
+		s.processEntry(entry.QueueEntry)
+		s.queue.chargeRoot(entry.root, s.out.Len()-before)
+	}
+	// Whatever remains did not fit in the buffer or in the pause.
+	s.queue.skipRemaining()
+}
+
+func (s *snapshotter) processEntry(entry framing.QueueEntry) {
+	ti, ok := s.p.TypeInfo[entry.Type]
+	if !ok {
+		return
+	}
+	if entry.Len == 0 {
+		entry.Len = ti.ByteLen
+	}
+	if entry.Len > ti.ByteLen {
+		entry.Len = ti.ByteLen
+	}
+	if entry.Len == 0 {
+		return
+	}
+	offset := uint32(0)
+	if ti.SerializeBeforeEnqueue {
+		offset, ok = s.out.writeQueueEntry(entry)
+		if !ok {
+			return
 		}
-		s.sm.chasedEntry = entry
-		s.sm.Run(ti.EnqueuePc, 0, 0, offset)
 	}
+	if ti.EnqueuePc == 0 {
+		return
+	}
+	s.sm.chasedEntry = entry
+	s.sm.Run(ti.EnqueuePc, 0, 0, offset)
 }
 
 type typeIdResolver struct {
@@ -0,0 +1,73 @@
+package snapshot
+
+import (
+	"runtime"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataExMachina-dev/side-eye-go/internal/allgs"
+	"github.com/DataExMachina-dev/side-eye-go/internal/framing"
+	"github.com/DataExMachina-dev/side-eye-go/internal/snapshotpb"
+)
+
+// fakeMemory returns words of heap memory, whose address does not change
+// while it is referenced as a uintptr. It is not inlined so that the memory
+// cannot be allocated on the stack of the caller, which moves when it grows.
+//
+//go:noinline
+func fakeMemory(words int) []uintptr {
+	return make([]uintptr, words)
+}
+
+func TestGoroutineContext(t *testing.T) {
+	cfg := &snapshotpb.RuntimeConfig{
+		GSchedOffset:     0x40,
+		GGoBufPcOffset:   0x48,
+		GGoBufBpOffset:   0x58,
+		GSyscallSpOffset: 0x60,
+		GSyscallPcOffset: 0x68,
+		GSyscallBpOffset: 0x70,
+	}
+	g := fakeMemory(16)
+	set := func(words []uintptr, offset uint32, v uintptr) {
+		words[offset/8] = v
+	}
+	set(g, cfg.GGoBufPcOffset, 0x1000)
+	set(g, cfg.GGoBufBpOffset, 0x2000)
+	defer runtime.KeepAlive(g)
+
+	check := func(
+		cfg *snapshotpb.RuntimeConfig, status allgs.Status,
+		wantPc uintptr, wantBp unsafe.Pointer, wantSource framing.StackSource,
+	) {
+		t.Helper()
+		pc, bp, source := goroutineContext(allgs.MakeGoroutine(unsafe.Pointer(&g[0]), cfg), status)
+		require.Equal(t, wantPc, pc)
+		require.Equal(t, wantBp, bp)
+		require.Equal(t, wantSource, source)
+	}
+
+	// A waiting goroutine is unwound from g.sched.
+	check(cfg, allgs.Status_Gwaiting, 0x1000, unsafe.Pointer(uintptr(0x2000)), framing.StackSourceSched)
+	// A goroutine in a syscall is unwound from g.syscallpc and g.syscallbp,
+	// and from g.sched if the config does not provide their offsets.
+	set(g, cfg.GSyscallSpOffset, 0x9000)
+	set(g, cfg.GSyscallPcOffset, 0x4000)
+	set(g, cfg.GSyscallBpOffset, 0x5000)
+	check(cfg, allgs.Status_Gsyscall, 0x4000, unsafe.Pointer(uintptr(0x5000)), framing.StackSourceSyscall)
+	noSyscall := &snapshotpb.RuntimeConfig{
+		GSchedOffset:   cfg.GSchedOffset,
+		GGoBufPcOffset: cfg.GGoBufPcOffset,
+		GGoBufBpOffset: cfg.GGoBufBpOffset,
+	}
+	check(noSyscall, allgs.Status_Gsyscall, 0x1000, unsafe.Pointer(uintptr(0x2000)), framing.StackSourceSched)
+	// A running goroutine that was preempted is unwound from g.sched too.
+	set(g, cfg.GSchedOffset, 0x8000)
+	check(cfg, allgs.Status_Grunning, 0x1000, unsafe.Pointer(uintptr(0x2000)), framing.StackSourceSched)
+	// A running goroutine that was not preempted has no context to be
+	// unwound from.
+	set(g, cfg.GSchedOffset, 0)
+	check(cfg, allgs.Status_Grunning, 0, nil, framing.StackSourceNone)
+}
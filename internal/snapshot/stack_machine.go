@@ -26,7 +26,8 @@ type stackMachine struct {
 	cfa     uintptr
 	decoder OpDecoder
 
-	frameHeader *framing.FrameHeader
+	// Offset of the frame header of the frame being processed.
+	frameHeaderOffset uint32
 
 	exprResultsOffset    uint32
 	exprResultsEndOffset uint32
@@ -40,6 +41,9 @@ type stackMachine struct {
 
 	bssAddrShift *uint64
 
+	// trace, if set, records the execution.
+	trace *tracer
+
 	q *queue
 	b *outBuf
 	g *goRuntimeTypeResolver
@@ -56,8 +60,8 @@ type OutBuf interface {
 	// Ptr returns a pointer to the memory at the given offset.
 	Ptr(offset uint32) unsafe.Pointer
 	// PrepareFrameData writes the frame header and queue entry to the outBuf and returns
-	// the offset to the data location for the queue entry.
-	PrepareFrameData(typeID uint32, progID uint32, dataLen uint32, depth uint32) (offset uint32, ok bool)
+	// the offsets of the frame header and of the data location for the queue entry.
+	PrepareFrameData(typeID uint32, progID uint32, dataLen uint32, depth uint32) (frameHeaderOffset uint32, offset uint32, ok bool)
 	// GetEntryLen assumes that the passed offset is immediately following a queue entry
 	// and it extracts the length from that queue entry.
 	GetEntryLen(entryOffset uint32) uint32
@@ -132,7 +136,7 @@ func (s *stackMachine) resolveAnyType() *ResolvedAnyType {
 	if e == nil || e.goRuntimeType == 0 {
 		return nil
 	}
-	typeId := s.t.ResolveGoRuntimeTypeToTypeId(e.goRuntimeType)
+	typeId := s.resolveTypeId(e.goRuntimeType)
 	if typeId == 0 {
 		return nil
 	}
@@ -188,11 +192,38 @@ func (s *stackMachine) ExecuteReturn() *bool {
 	return nil
 }
 
+// resolveTypeId returns the type id of a go runtime type, or 0 if the program
+// does not know the type.
+func (s *stackMachine) resolveTypeId(goRuntimeType uint64) uint32 {
+	typeId := s.t.ResolveGoRuntimeTypeToTypeId(goRuntimeType)
+	if typeId == 0 {
+		s.trace.typeResolutionMiss(goRuntimeType)
+	}
+	return typeId
+}
+
+// Run runs the program at pc, for a frame with the given CFA and depth or for
+// a pointee, starting at the given offset of the output.
 func (s *stackMachine) Run(
 	pc uint32,
 	cfa uintptr,
 	depth uint32,
 	offset uint32,
+) bool {
+	if s.trace == nil {
+		return s.run(pc, cfa, depth, offset)
+	}
+	start := s.trace.beginProgram(pc)
+	ok := s.run(pc, cfa, depth, offset)
+	s.trace.endProgram(pc, start, ok)
+	return ok
+}
+
+func (s *stackMachine) run(
+	pc uint32,
+	cfa uintptr,
+	depth uint32,
+	offset uint32,
 ) bool {
 	if !s.decoder.SetPC(pc) {
 		return false
@@ -201,7 +232,9 @@ func (s *stackMachine) Run(
 	s.offset = offset
 
 	for i := 0; i < 100000; i++ {
+		opPc := s.decoder.PC()
 		op := s.decoder.PopOpCode()
+		s.trace.op(opPc, op)
 		switch op {
 		case OpCodeInvalid:
 			return false
@@ -232,7 +265,7 @@ func (s *stackMachine) Run(
 			}
 			ptr := s.b.Ptr(s.offset)
 			*(*uint64)(ptr) = e.goRuntimeType
-			typeId := s.t.ResolveGoRuntimeTypeToTypeId(e.goRuntimeType)
+			typeId := s.resolveTypeId(e.goRuntimeType)
 			if typeId == 0 {
 				continue
 			}
@@ -256,7 +289,7 @@ func (s *stackMachine) Run(
 			}
 			goRuntimeType := s.g.ResolveTypeAddressToGoRuntimeTypeId(uint64(e.itab))
 			*(*uint64)(ptr) = goRuntimeType
-			typeId := s.t.ResolveGoRuntimeTypeToTypeId(goRuntimeType)
+			typeId := s.resolveTypeId(goRuntimeType)
 			if typeId == 0 {
 				continue
 			}
@@ -484,7 +517,7 @@ func (s *stackMachine) Run(
 
 		case OpCodePrepareFrameData:
 			prepareFrameData := s.decoder.DecodePrepareFrameData()
-			frameHeader, offset, ok := s.b.PrepareFrameData(
+			frameHeaderOffset, offset, ok := s.b.PrepareFrameData(
 				prepareFrameData.TypeID,
 				prepareFrameData.ProgID,
 				prepareFrameData.DataByteLen,
@@ -494,13 +527,13 @@ func (s *stackMachine) Run(
 				// TODO: handle this error
 				return false
 			}
-			s.frameHeader = frameHeader
+			s.frameHeaderOffset = frameHeaderOffset
 			s.exprType = Frame
 			s.exprResultsOffset = offset
 			s.offset = offset
 
 		case OpCodeConcludeFrameData:
-			s.b.ConcludeFrameData(s.frameHeader)
+			s.b.ConcludeFrameData(s.frameHeaderOffset)
 
 		case OpCodePrepareGoContext:
 			c := s.decoder.DecodePrepareGoContext()
@@ -526,8 +559,9 @@ func (s *stackMachine) Run(
 			s.goContextOffset = o
 			s.goContextCaptureBitmask = (uint64(1) << uint64(c.CaptureCount)) - 1
 			// We will need to fetch some data into the buf, that we will
-			// later truncate.
+			// later truncate, and to restore the offset of the context.
 			truncateTarget := s.b.Len()
+			contextOffset := s.offset
 			// Iterate over go context implementation stack.
 			for {
 				if s.goContextCaptureBitmask == 0 {
@@ -542,7 +576,7 @@ func (s *stackMachine) Run(
 					break
 				}
 				goRuntimeType := s.g.ResolveTypeAddressToGoRuntimeTypeId(uint64(e.itab))
-				typeId := s.t.ResolveGoRuntimeTypeToTypeId(goRuntimeType)
+				typeId := s.resolveTypeId(goRuntimeType)
 				if typeId == 0 {
 					break
 				}
@@ -589,6 +623,7 @@ func (s *stackMachine) Run(
 				s.offset += *cti.GoContextImpl.ContextOffset
 			}
 			s.b.truncate(truncateTarget)
+			s.offset = contextOffset
 
 		case OpCodeTraverseGoContext:
 		case OpCodeConcludeGoContext:
@@ -0,0 +1,237 @@
+package snapshot
+
+import (
+	"github.com/DataExMachina-dev/side-eye-go/internal/boottime"
+	"github.com/DataExMachina-dev/side-eye-go/internal/machinapb"
+	"github.com/DataExMachina-dev/side-eye-go/internal/snapshotpb"
+	"github.com/DataExMachina-dev/side-eye-go/internal/stackmachine"
+)
+
+const (
+	// maxTraceOps bounds the number of OP events recorded by a trace.
+	maxTraceOps = 1 << 15
+	// maxTraceDiagnostics bounds the number of the other events recorded by a
+	// trace. They are bounded separately from the OP events, which are far
+	// more numerous, so that they are still recorded once those are dropped.
+	maxTraceDiagnostics = 1 << 12
+)
+
+type traceEventKind = machinapb.StackMachineTrace_Event_Kind
+
+type traceEvent struct {
+	kind    traceEventKind
+	opCode  stackmachine.OpCode
+	progPc  uint32
+	pc      uint32
+	typ     uint32
+	byteLen uint32
+	addr    uint64
+}
+
+type programStats struct {
+	runs, failures uint64
+	durationNs     uint64
+}
+
+type opStats struct {
+	runs       uint64
+	durationNs uint64
+	code       stackmachine.OpCode
+}
+
+// tracer records the execution of the stack machine for a snapshot. All of its
+// memory is allocated up front, so that it can be used with the world stopped.
+// The methods of a nil *tracer do nothing, which is how tracing is disabled.
+type tracer struct {
+	events  []traceEvent
+	dropped uint64
+	// numOps and numDiagnostics are the number of OP events and of other
+	// events in events.
+	numOps, numDiagnostics int
+
+	opCounts [256]uint64
+	// programs holds the statistics of every program of the snapshot
+	// program, keyed by entry pc.
+	programs map[uint32]*programStats
+	// ops holds the statistics of every operation of the snapshot program,
+	// indexed by pc.
+	ops []opStats
+
+	// progPc, pc and code identify the operation being executed, if any.
+	progPc, pc uint32
+	code       stackmachine.OpCode
+	// inOp is set while an operation is executed, since opStart, the time at
+	// which it started.
+	inOp    bool
+	opStart int64
+}
+
+func newTracer(p *snapshotpb.SnapshotProgram) *tracer {
+	t := &tracer{
+		events:   make([]traceEvent, 0, maxTraceOps+maxTraceDiagnostics),
+		programs: make(map[uint32]*programStats),
+		ops:      make([]opStats, len(p.GetProg())),
+	}
+	for _, pc := range p.GetPcClassifier().GetProgPc() {
+		if pc != 0 {
+			t.programs[pc] = &programStats{}
+		}
+	}
+	for _, ti := range p.TypeInfo {
+		if ti.EnqueuePc != 0 {
+			t.programs[ti.EnqueuePc] = &programStats{}
+		}
+	}
+	return t
+}
+
+func (t *tracer) record(e traceEvent) {
+	n, limit := &t.numDiagnostics, maxTraceDiagnostics
+	if e.kind == machinapb.StackMachineTrace_Event_OP {
+		n, limit = &t.numOps, maxTraceOps
+	}
+	if *n == limit {
+		t.dropped++
+		return
+	}
+	*n++
+	e.progPc, e.pc, e.opCode = t.progPc, t.pc, t.code
+	t.events = append(t.events, e)
+}
+
+// beginProgram records the start of the program at pc and returns the start
+// time, to be passed to endProgram.
+func (t *tracer) beginProgram(pc uint32) (start int64) {
+	if t == nil {
+		return 0
+	}
+	t.progPc, t.pc, t.code = pc, 0, 0
+	t.inOp = false
+	return boottime.Nanotime()
+}
+
+func (t *tracer) endProgram(pc uint32, start int64, ok bool) {
+	if t == nil {
+		return
+	}
+	now := boottime.Nanotime()
+	t.endOp(now)
+	if ps, found := t.programs[pc]; found {
+		ps.runs++
+		if !ok {
+			ps.failures++
+		}
+		ps.durationNs += uint64(now - start)
+	}
+	t.progPc, t.pc, t.code = 0, 0, 0
+}
+
+// op records the execution of the operation at pc. The operation is timed
+// until the next one starts, or until its program ends, so that the clock is
+// read once per operation.
+func (t *tracer) op(pc uint32, code stackmachine.OpCode) {
+	if t == nil {
+		return
+	}
+	now := boottime.Nanotime()
+	t.endOp(now)
+	t.pc, t.code = pc, code
+	t.inOp, t.opStart = true, now
+	t.opCounts[code]++
+	t.record(traceEvent{kind: machinapb.StackMachineTrace_Event_OP})
+}
+
+// endOp accounts for the time spent in the operation being executed, if any.
+func (t *tracer) endOp(now int64) {
+	if !t.inOp {
+		return
+	}
+	t.inOp = false
+	if int(t.pc) < len(t.ops) {
+		s := &t.ops[t.pc]
+		s.runs++
+		s.durationNs += uint64(now - t.opStart)
+		s.code = t.code
+	}
+}
+
+func (t *tracer) dereferenceFailed(addr uintptr, byteLen uint32) {
+	if t == nil {
+		return
+	}
+	t.record(traceEvent{
+		kind:    machinapb.StackMachineTrace_Event_DEREFERENCE_FAILED,
+		addr:    uint64(addr),
+		byteLen: byteLen,
+	})
+}
+
+func (t *tracer) enqueueSkipped(addr uintptr, typ uint32) {
+	if t == nil {
+		return
+	}
+	t.record(traceEvent{
+		kind: machinapb.StackMachineTrace_Event_ENQUEUE_SKIPPED,
+		addr: uint64(addr),
+		typ:  typ,
+	})
+}
+
+func (t *tracer) typeResolutionMiss(goRuntimeType uint64) {
+	if t == nil {
+		return
+	}
+	t.record(traceEvent{
+		kind: machinapb.StackMachineTrace_Event_TYPE_RESOLUTION_MISS,
+		addr: goRuntimeType,
+	})
+}
+
+// proto returns the trace as sent in the SnapshotResponse.
+func (t *tracer) proto() *machinapb.StackMachineTrace {
+	if t == nil {
+		return nil
+	}
+	res := &machinapb.StackMachineTrace{
+		Events:        make([]*machinapb.StackMachineTrace_Event, len(t.events)),
+		DroppedEvents: t.dropped,
+		OpCounts:      make(map[uint32]uint64),
+		Programs:      make(map[uint32]*machinapb.StackMachineTrace_Program),
+		Ops:           make(map[uint32]*machinapb.StackMachineTrace_Op),
+	}
+	for i, e := range t.events {
+		res.Events[i] = &machinapb.StackMachineTrace_Event{
+			Kind:    e.kind,
+			ProgPc:  e.progPc,
+			Pc:      e.pc,
+			OpCode:  uint32(e.opCode),
+			Addr:    e.addr,
+			Type:    e.typ,
+			ByteLen: e.byteLen,
+		}
+	}
+	for code, n := range t.opCounts {
+		if n != 0 {
+			res.OpCounts[uint32(code)] = n
+		}
+	}
+	for pc, ps := range t.programs {
+		if ps.runs != 0 {
+			res.Programs[pc] = &machinapb.StackMachineTrace_Program{
+				Runs:       ps.runs,
+				Failures:   ps.failures,
+				DurationNs: ps.durationNs,
+			}
+		}
+	}
+	for pc, st := range t.ops {
+		if st.runs != 0 {
+			res.Ops[uint32(pc)] = &machinapb.StackMachineTrace_Op{
+				OpCode:     uint32(st.code),
+				Runs:       st.runs,
+				DurationNs: st.durationNs,
+			}
+		}
+	}
+	return res
+}
@@ -0,0 +1,156 @@
+package snapshot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataExMachina-dev/side-eye-go/internal/machinapb"
+	"github.com/DataExMachina-dev/side-eye-go/internal/snapshotpb"
+	. "github.com/DataExMachina-dev/side-eye-go/internal/stackmachine"
+)
+
+func TestTrace(t *testing.T) {
+	const (
+		pointeeType  = 2
+		typesStart   = 0x50000
+		unknownRType = typesStart + 0x100
+	)
+	e := newEncoder()
+	pc := e.Encode(OpPrepareFrameData{ProgID: 1, DataByteLen: 48, TypeID: 1})
+	e.Encode(OpDereferenceCFAOffset{Offset: -48, ByteLen: 48})
+	var enqueuePcs []uint32
+	for i := 0; i < 4; i++ {
+		enqueuePcs = append(enqueuePcs, e.Encode(OpEnqueuePointer{ElemType: pointeeType}))
+		e.Encode(OpAdvanceOffset{Increment: 8})
+	}
+	efacePc := e.Encode(OpEnqueueEmptyInterface{})
+	e.Encode(OpConcludeFrameData{})
+	e.Encode(OpReturn{})
+	p := frameProgram(e, pc, map[uint32]*snapshotpb.TypeInfo{
+		pointeeType: {ByteLen: 8, SerializeBeforeEnqueue: true},
+	})
+
+	res, err := DryRun(p, &SimulatedProcess{
+		Memory: SimulatedMemory{
+			// A dangling pointer, a nil pointer, the same pointer twice, and
+			// an empty interface holding a type unknown to the program.
+			0x7000 - 48: words(0xdead0000, 0, 0x9000, 0x9000, unknownRType, 0x9100),
+			0x9000:      words(1),
+		},
+		Goroutines: []SimulatedGoroutine{frameOf(1, 0x7000)},
+		TypesStart: typesStart,
+		TypesEnd:   typesStart + 0x1000,
+	}, Options{Trace: true})
+	require.NoError(t, err)
+	trace := res.Trace
+	require.NotNil(t, trace)
+	require.Zero(t, trace.DroppedEvents)
+
+	var ops []uint32
+	var other []*machinapb.StackMachineTrace_Event
+	for _, ev := range trace.Events {
+		if ev.Kind == machinapb.StackMachineTrace_Event_OP {
+			require.Equal(t, pc, ev.ProgPc)
+			ops = append(ops, ev.OpCode)
+		} else {
+			other = append(other, ev)
+		}
+	}
+	require.Len(t, ops, 13)
+	require.Equal(t, uint32(OpCodePrepareFrameData), ops[0])
+	require.Equal(t, uint32(OpCodeReturn), ops[len(ops)-1])
+	op := func(code OpCode) uint32 { return uint32(code) }
+	require.Equal(t, []*machinapb.StackMachineTrace_Event{
+		{
+			Kind:   machinapb.StackMachineTrace_Event_ENQUEUE_SKIPPED,
+			ProgPc: pc, Pc: enqueuePcs[1], OpCode: op(OpCodeEnqueuePointer),
+			Addr: 0, Type: pointeeType,
+		},
+		{
+			Kind:   machinapb.StackMachineTrace_Event_ENQUEUE_SKIPPED,
+			ProgPc: pc, Pc: enqueuePcs[3], OpCode: op(OpCodeEnqueuePointer),
+			Addr: 0x9000, Type: pointeeType,
+		},
+		{
+			Kind:   machinapb.StackMachineTrace_Event_TYPE_RESOLUTION_MISS,
+			ProgPc: pc, Pc: efacePc, OpCode: op(OpCodeEnqueueEmptyInterface),
+			Addr: unknownRType - typesStart,
+		},
+		// The dangling pointer fails to be serialized, outside of any
+		// program.
+		{
+			Kind: machinapb.StackMachineTrace_Event_DEREFERENCE_FAILED,
+			Addr: 0xdead0000, ByteLen: 8,
+		},
+	}, other)
+
+	require.Equal(t, uint64(4), trace.OpCounts[op(OpCodeEnqueuePointer)])
+	require.Equal(t, uint64(1), trace.OpCounts[op(OpCodeReturn)])
+	require.Len(t, trace.Programs, 1)
+	require.Equal(t, uint64(1), trace.Programs[pc].Runs)
+	require.Zero(t, trace.Programs[pc].Failures)
+
+	// Every operation ran once, at its own pc, and the time spent in the
+	// operations is part of the time spent in the program.
+	require.Len(t, trace.Ops, 13)
+	var opsDurationNs uint64
+	for _, o := range trace.Ops {
+		require.Equal(t, uint64(1), o.Runs)
+		opsDurationNs += o.DurationNs
+	}
+	require.LessOrEqual(t, opsDurationNs, trace.Programs[pc].DurationNs)
+	require.Equal(t, op(OpCodePrepareFrameData), trace.Ops[pc].OpCode)
+	for _, enqueuePc := range enqueuePcs {
+		require.Equal(t, op(OpCodeEnqueuePointer), trace.Ops[enqueuePc].OpCode)
+	}
+}
+
+func TestTraceBounded(t *testing.T) {
+	const (
+		iterations  = maxTraceOps
+		pointeeType = 2
+	)
+	e := newEncoder()
+	pc := e.Encode(OpPushImm{Value: iterations})
+	loop := e.Encode(OpDecrement{})
+	e.Encode(OpCondJump{Pc: loop})
+	e.Encode(OpPop{})
+	// Once the loop has filled the log of executed operations, a nil pointer
+	// is still recorded as skipped.
+	e.Encode(OpPrepareFrameData{ProgID: 1, DataByteLen: 8, TypeID: 1})
+	e.Encode(OpDereferenceCFAOffset{Offset: -8, ByteLen: 8})
+	enqueuePc := e.Encode(OpEnqueuePointer{ElemType: pointeeType})
+	e.Encode(OpConcludeFrameData{})
+	e.Encode(OpReturn{})
+	res, err := DryRun(frameProgram(e, pc, map[uint32]*snapshotpb.TypeInfo{
+		pointeeType: {ByteLen: 8},
+	}), &SimulatedProcess{
+		Memory:     SimulatedMemory{0x7000 - 8: words(0)},
+		Goroutines: []SimulatedGoroutine{frameOf(1, 0x7000)},
+	}, Options{Trace: true})
+	require.NoError(t, err)
+	trace := res.Trace
+	require.Len(t, trace.Events, maxTraceOps+1)
+	require.Equal(t, uint64(iterations+7), trace.DroppedEvents)
+	require.Equal(t, uint64(iterations), trace.OpCounts[uint32(OpCodeDecrement)])
+	// The operations are timed even once their events are dropped.
+	require.Equal(t, uint64(iterations), trace.Ops[loop].Runs)
+	require.Equal(t, uint32(OpCodeDecrement), trace.Ops[loop].OpCode)
+	require.Equal(t, uint64(1), trace.Ops[enqueuePc].Runs)
+	require.Equal(t, &machinapb.StackMachineTrace_Event{
+		Kind:   machinapb.StackMachineTrace_Event_ENQUEUE_SKIPPED,
+		ProgPc: pc, Pc: enqueuePc, OpCode: uint32(OpCodeEnqueuePointer),
+		Type: pointeeType,
+	}, trace.Events[maxTraceOps])
+}
+
+func TestTraceDisabled(t *testing.T) {
+	e := newEncoder()
+	pc := e.Encode(OpReturn{})
+	res, err := DryRun(frameProgram(e, pc, nil), &SimulatedProcess{
+		Goroutines: []SimulatedGoroutine{frameOf(1, 0x7000)},
+	}, Options{})
+	require.NoError(t, err)
+	require.Nil(t, res.Trace)
+}
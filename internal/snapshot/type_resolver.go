@@ -0,0 +1,147 @@
+package snapshot
+
+import (
+	"sort"
+	"unsafe"
+
+	"github.com/DataExMachina-dev/side-eye-go/internal/snapshotpb"
+)
+
+// maxModules bounds the number of moduledata entries that types are resolved
+// in. Go programs that load more plugins than this are exceedingly rare; types
+// from the extra modules resolve to 0.
+const maxModules = 64
+
+// moduleIdxShift is the shift applied to the index of a module (into
+// SnapshotProgram.ModuleNames, plus one) when encoding a go runtime type id for
+// a type outside of the first module.
+const moduleIdxShift = 32
+
+type moduledataTypeRange struct {
+	start uint64
+	end   uint64
+	// moduleIdx is 0 for the first moduledata, and the index into
+	// SnapshotProgram.ModuleNames plus one for others.
+	moduleIdx uint64
+}
+
+type moduledataConfig struct {
+	runtimeDotFirstmoduledata unsafe.Pointer
+	typesOffset               uintptr
+	etypesOffset              uintptr
+	nextOffset                uintptr
+	pluginpathOffset          uintptr
+	modulenameOffset          uintptr
+}
+
+// goRuntimeTypeResolver maps the address of a runtime type to the go runtime
+// type id used by the snapshot program. For types in the first moduledata, the
+// id is the offset of the type from the start of the module's types section.
+// For types in other modules (plugins and shared libraries) that are listed in
+// the program's ModuleNames, the id additionally encodes the module's index in
+// its upper 32 bits.
+type goRuntimeTypeResolver struct {
+	cfg         moduledataConfig
+	moduleNames []string
+	resolved    bool
+	// ranges[:numRanges] holds the type ranges of the known modules, sorted by
+	// start address.
+	ranges    [maxModules]moduledataTypeRange
+	numRanges int
+}
+
+func makeGoRuntimeTypeResolver(p *snapshotpb.SnapshotProgram, firstModuledata unsafe.Pointer) goRuntimeTypeResolver {
+	cfg := p.RuntimeConfig
+	return goRuntimeTypeResolver{
+		cfg: moduledataConfig{
+			runtimeDotFirstmoduledata: firstModuledata,
+			typesOffset:               uintptr(cfg.ModuledataTypesOffset),
+			etypesOffset:              uintptr(cfg.ModuledataEtypesOffset),
+			nextOffset:                uintptr(cfg.ModuledataNextOffset),
+			pluginpathOffset:          uintptr(cfg.ModuledataPluginpathOffset),
+			modulenameOffset:          uintptr(cfg.ModuledataModulenameOffset),
+		},
+		moduleNames: p.ModuleNames,
+	}
+}
+
+// maybeResolveModuledataRanges walks the moduledata chain and records the type
+// ranges of the modules that the program knows about. It is done lazily, and
+// once per snapshot, because plugins can be loaded at any time.
+func (m *goRuntimeTypeResolver) maybeResolveModuledataRanges() {
+	if m.resolved {
+		return
+	}
+	m.resolved = true
+
+	md := m.cfg.runtimeDotFirstmoduledata
+	for i := 0; md != nil && m.numRanges < maxModules; i++ {
+		moduleIdx, ok := uint64(0), i == 0
+		if !ok {
+			moduleIdx, ok = m.lookupModule(md)
+		}
+		if ok {
+			m.insertRange(moduledataTypeRange{
+				start:     *(*uint64)(unsafe.Pointer(uintptr(md) + m.cfg.typesOffset)),
+				end:       *(*uint64)(unsafe.Pointer(uintptr(md) + m.cfg.etypesOffset)),
+				moduleIdx: moduleIdx,
+			})
+		}
+		// If the config predates next being provided, only the first module
+		// is considered.
+		if m.cfg.nextOffset == 0 {
+			break
+		}
+		md = *(*unsafe.Pointer)(unsafe.Pointer(uintptr(md) + m.cfg.nextOffset))
+	}
+}
+
+// lookupModule returns the index used to encode type ids for the module
+// described by md. Plugins are identified by their plugin path, and shared
+// libraries by their module name.
+func (m *goRuntimeTypeResolver) lookupModule(md unsafe.Pointer) (moduleIdx uint64, ok bool) {
+	var name string
+	if m.cfg.pluginpathOffset != 0 {
+		name = *(*string)(unsafe.Pointer(uintptr(md) + m.cfg.pluginpathOffset))
+	}
+	if name == "" && m.cfg.modulenameOffset != 0 {
+		name = *(*string)(unsafe.Pointer(uintptr(md) + m.cfg.modulenameOffset))
+	}
+	if name == "" {
+		return 0, false
+	}
+	for i, n := range m.moduleNames {
+		if n == name {
+			return uint64(i) + 1, true
+		}
+	}
+	return 0, false
+}
+
+// insertRange inserts r into the sorted ranges. It does not allocate, so that
+// it can be used with the world stopped.
+func (m *goRuntimeTypeResolver) insertRange(r moduledataTypeRange) {
+	if r.start >= r.end {
+		return
+	}
+	i := m.numRanges
+	for i > 0 && m.ranges[i-1].start > r.start {
+		m.ranges[i] = m.ranges[i-1]
+		i--
+	}
+	m.ranges[i] = r
+	m.numRanges++
+}
+
+func (m *goRuntimeTypeResolver) ResolveTypeAddressToGoRuntimeTypeId(addr uint64) uint64 {
+	m.maybeResolveModuledataRanges()
+	ranges := m.ranges[:m.numRanges]
+	i := sort.Search(len(ranges), func(i int) bool {
+		return ranges[i].end > addr
+	})
+	if i == len(ranges) || addr < ranges[i].start {
+		return 0
+	}
+	r := ranges[i]
+	return r.moduleIdx<<moduleIdxShift | (addr - r.start)
+}
@@ -0,0 +1,53 @@
+package snapshot
+
+import (
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataExMachina-dev/side-eye-go/internal/snapshotpb"
+)
+
+// fakeModuledata mimics the fields of runtime.moduledata used by the resolver.
+type fakeModuledata struct {
+	types, etypes uint64
+	next          *fakeModuledata
+	pluginpath    string
+	modulename    string
+}
+
+func TestGoRuntimeTypeResolverModules(t *testing.T) {
+	unknown := &fakeModuledata{types: 0x5000, etypes: 0x6000, pluginpath: "example.com/unknown"}
+	shared := &fakeModuledata{types: 0x1000, etypes: 0x2000, modulename: "libshared.so", next: unknown}
+	plugin := &fakeModuledata{types: 0x9000, etypes: 0xa000, pluginpath: "example.com/plugin", next: shared}
+	first := &fakeModuledata{types: 0x3000, etypes: 0x4000, next: plugin}
+
+	p := &snapshotpb.SnapshotProgram{
+		RuntimeConfig: &snapshotpb.RuntimeConfig{
+			ModuledataTypesOffset:      uint32(unsafe.Offsetof(fakeModuledata{}.types)),
+			ModuledataEtypesOffset:     uint32(unsafe.Offsetof(fakeModuledata{}.etypes)),
+			ModuledataNextOffset:       uint32(unsafe.Offsetof(fakeModuledata{}.next)),
+			ModuledataPluginpathOffset: uint32(unsafe.Offsetof(fakeModuledata{}.pluginpath)),
+			ModuledataModulenameOffset: uint32(unsafe.Offsetof(fakeModuledata{}.modulename)),
+		},
+		ModuleNames: []string{"libshared.so", "example.com/plugin"},
+	}
+	r := makeGoRuntimeTypeResolver(p, unsafe.Pointer(first))
+	for _, tc := range []struct {
+		addr uint64
+		exp  uint64
+	}{
+		{0x3000, 0},
+		{0x3010, 0x10},
+		{0x1010, 1<<32 | 0x10},
+		{0x9ff8, 2<<32 | 0xff8},
+		{0xa000, 0},
+		{0x5010, 0}, // unknown module
+		{0x2500, 0}, // between modules
+		{0x10, 0},
+	} {
+		require.Equal(t, tc.exp, r.ResolveTypeAddressToGoRuntimeTypeId(tc.addr), "%#x", tc.addr)
+	}
+	require.Equal(t, 3, r.numRanges)
+}
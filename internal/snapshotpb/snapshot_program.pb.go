@@ -28,6 +28,8 @@ type RuntimeConfig struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
+	// Offset of sched in the g. The saved stack pointer is read at this
+	// offset, as sp is the first field of runtime.gobuf.
 	GSchedOffset uint32 `protobuf:"varint,1,opt,name=g_sched_offset,json=gSchedOffset,proto3" json:"g_sched_offset,omitempty"`
 	// Offset of pc in g.gobuf from the g.
 	GGoBufPcOffset uint32 `protobuf:"varint,2,opt,name=g_go_buf_pc_offset,json=gGoBufPcOffset,proto3" json:"g_go_buf_pc_offset,omitempty"`
@@ -37,12 +39,61 @@ type RuntimeConfig struct {
 	GSyscallPcOffset uint32 `protobuf:"varint,4,opt,name=g_syscall_pc_offset,json=gSyscallPcOffset,proto3" json:"g_syscall_pc_offset,omitempty"`
 	// Offset of syscallsp in the g.
 	GSyscallSpOffset uint32 `protobuf:"varint,5,opt,name=g_syscall_sp_offset,json=gSyscallSpOffset,proto3" json:"g_syscall_sp_offset,omitempty"`
+	// Offset of syscallbp in the g. Zero if the runtime does not record it
+	// (before go1.23).
+	GSyscallBpOffset uint32 `protobuf:"varint,30,opt,name=g_syscall_bp_offset,json=gSyscallBpOffset,proto3" json:"g_syscall_bp_offset,omitempty"`
 	// Offset of goid in the g.
 	GGoidOffset uint32 `protobuf:"varint,6,opt,name=g_goid_offset,json=gGoidOffset,proto3" json:"g_goid_offset,omitempty"`
 	// Offset of atomicstatus in the g.
 	GAtomicstatusOffset uint32 `protobuf:"varint,7,opt,name=g_atomicstatus_offset,json=gAtomicstatusOffset,proto3" json:"g_atomicstatus_offset,omitempty"`
 	// Offset of stacktopsp in the g.
 	GStktopspOffset uint32 `protobuf:"varint,16,opt,name=g_stktopsp_offset,json=gStktopspOffset,proto3" json:"g_stktopsp_offset,omitempty"`
+	// Offset of waitsince in the g. If zero, the wait durations of goroutines
+	// are not reported.
+	GWaitsinceOffset uint32 `protobuf:"varint,25,opt,name=g_waitsince_offset,json=gWaitsinceOffset,proto3" json:"g_waitsince_offset,omitempty"`
+	// Offset of waitreason in the g. If zero, the wait reasons of goroutines
+	// are not reported.
+	GWaitreasonOffset uint32 `protobuf:"varint,26,opt,name=g_waitreason_offset,json=gWaitreasonOffset,proto3" json:"g_waitreason_offset,omitempty"`
+	// Offset of labels in the g.
+	GLabelsOffset uint32 `protobuf:"varint,34,opt,name=g_labels_offset,json=gLabelsOffset,proto3" json:"g_labels_offset,omitempty"`
+	// The layout of runtime/pprof.labelMap, which g.labels points to. Since
+	// go1.24, it is a struct wrapping a slice of labels; before, it is a
+	// map[string]string and these are zero.
+	//
+	// Offset of the slice of labels in runtime/pprof.labelMap.
+	LabelMapListOffset uint32 `protobuf:"varint,35,opt,name=label_map_list_offset,json=labelMapListOffset,proto3" json:"label_map_list_offset,omitempty"`
+	// Size of a label.
+	LabelByteLen uint32 `protobuf:"varint,36,opt,name=label_byte_len,json=labelByteLen,proto3" json:"label_byte_len,omitempty"`
+	// Offset of the key in a label.
+	LabelKeyOffset uint32 `protobuf:"varint,37,opt,name=label_key_offset,json=labelKeyOffset,proto3" json:"label_key_offset,omitempty"`
+	// Offset of the value in a label.
+	LabelValueOffset uint32 `protobuf:"varint,38,opt,name=label_value_offset,json=labelValueOffset,proto3" json:"label_value_offset,omitempty"`
+	// Offset of gopc, the pc of the go statement that created the goroutine,
+	// in the g.
+	GGopcOffset uint32 `protobuf:"varint,39,opt,name=g_gopc_offset,json=gGopcOffset,proto3" json:"g_gopc_offset,omitempty"`
+	// Offset of parentGoid in the g. It is zero before go1.21, which does not
+	// record the parent of a goroutine.
+	GParentGoidOffset uint32 `protobuf:"varint,40,opt,name=g_parent_goid_offset,json=gParentGoidOffset,proto3" json:"g_parent_goid_offset,omitempty"`
+	// Offset of waiting, the list of sudogs that the goroutine is blocked on,
+	// in the g.
+	GWaitingOffset uint32 `protobuf:"varint,41,opt,name=g_waiting_offset,json=gWaitingOffset,proto3" json:"g_waiting_offset,omitempty"`
+	// Offsets of the pointers held by elem, c and waitlink in the sudog. Where
+	// the runtime wraps a pointer in a maybeTraceablePtr, this is the offset
+	// of the pointer's value in the wrapper.
+	SudogElemOffset     uint32 `protobuf:"varint,42,opt,name=sudog_elem_offset,json=sudogElemOffset,proto3" json:"sudog_elem_offset,omitempty"`
+	SudogCOffset        uint32 `protobuf:"varint,43,opt,name=sudog_c_offset,json=sudogCOffset,proto3" json:"sudog_c_offset,omitempty"`
+	SudogWaitlinkOffset uint32 `protobuf:"varint,44,opt,name=sudog_waitlink_offset,json=sudogWaitlinkOffset,proto3" json:"sudog_waitlink_offset,omitempty"`
+	// Type id of runtime.hchan in the program's type_info, with which the
+	// channels that goroutines are blocked on are chased. Zero means that they
+	// are not chased.
+	HchanType uint32 `protobuf:"varint,45,opt,name=hchan_type,json=hchanType,proto3" json:"hchan_type,omitempty"`
+	// Offsets of fields in runtime.mstats, other than last_gc_unix, that are
+	// reported in the RuntimeStats of snapshots. Zero if not available.
+	MstatsLastGcNanotimeOffset uint32 `protobuf:"varint,46,opt,name=mstats_last_gc_nanotime_offset,json=mstatsLastGcNanotimeOffset,proto3" json:"mstats_last_gc_nanotime_offset,omitempty"`
+	MstatsPauseTotalNsOffset   uint32 `protobuf:"varint,47,opt,name=mstats_pause_total_ns_offset,json=mstatsPauseTotalNsOffset,proto3" json:"mstats_pause_total_ns_offset,omitempty"`
+	MstatsNumgcOffset          uint32 `protobuf:"varint,48,opt,name=mstats_numgc_offset,json=mstatsNumgcOffset,proto3" json:"mstats_numgc_offset,omitempty"`
+	MstatsNumforcedgcOffset    uint32 `protobuf:"varint,49,opt,name=mstats_numforcedgc_offset,json=mstatsNumforcedgcOffset,proto3" json:"mstats_numforcedgc_offset,omitempty"`
+	MstatsGcCpuFractionOffset  uint32 `protobuf:"varint,50,opt,name=mstats_gc_cpu_fraction_offset,json=mstatsGcCpuFractionOffset,proto3" json:"mstats_gc_cpu_fraction_offset,omitempty"`
 	// Offset of preemptOff in the m.
 	MPreemptOffOffset uint32 `protobuf:"varint,8,opt,name=m_preempt_off_offset,json=mPreemptOffOffset,proto3" json:"m_preempt_off_offset,omitempty"`
 	// Address of runtime.firstmoduledata.
@@ -57,6 +108,12 @@ type RuntimeConfig struct {
 	ModuledataTextOffset uint32 `protobuf:"varint,14,opt,name=moduledata_text_offset,json=moduledataTextOffset,proto3" json:"moduledata_text_offset,omitempty"`
 	// Offset in runtime.moduledata of the bss field.
 	ModuledataBssOffset uint32 `protobuf:"varint,20,opt,name=moduledata_bss_offset,json=moduledataBssOffset,proto3" json:"moduledata_bss_offset,omitempty"`
+	// Offset in runtime.moduledata of the next field.
+	ModuledataNextOffset uint32 `protobuf:"varint,31,opt,name=moduledata_next_offset,json=moduledataNextOffset,proto3" json:"moduledata_next_offset,omitempty"`
+	// Offset in runtime.moduledata of the pluginpath field.
+	ModuledataPluginpathOffset uint32 `protobuf:"varint,32,opt,name=moduledata_pluginpath_offset,json=moduledataPluginpathOffset,proto3" json:"moduledata_pluginpath_offset,omitempty"`
+	// Offset in runtime.moduledata of the modulename field.
+	ModuledataModulenameOffset uint32 `protobuf:"varint,33,opt,name=moduledata_modulename_offset,json=moduledataModulenameOffset,proto3" json:"moduledata_modulename_offset,omitempty"`
 	// Address of runtime.memstats.
 	VariableRuntimeDotMemstats uint64 `protobuf:"varint,23,opt,name=variable_runtime_dot_memstats,json=variableRuntimeDotMemstats,proto3" json:"variable_runtime_dot_memstats,omitempty"`
 	// Offset in runtime.memstats of the last_gc_unix field.
@@ -146,6 +203,13 @@ func (x *RuntimeConfig) GetGSyscallSpOffset() uint32 {
 	return 0
 }
 
+func (x *RuntimeConfig) GetGSyscallBpOffset() uint32 {
+	if x != nil {
+		return x.GSyscallBpOffset
+	}
+	return 0
+}
+
 func (x *RuntimeConfig) GetGGoidOffset() uint32 {
 	if x != nil {
 		return x.GGoidOffset
@@ -167,6 +231,139 @@ func (x *RuntimeConfig) GetGStktopspOffset() uint32 {
 	return 0
 }
 
+func (x *RuntimeConfig) GetGWaitsinceOffset() uint32 {
+	if x != nil {
+		return x.GWaitsinceOffset
+	}
+	return 0
+}
+
+func (x *RuntimeConfig) GetGWaitreasonOffset() uint32 {
+	if x != nil {
+		return x.GWaitreasonOffset
+	}
+	return 0
+}
+
+func (x *RuntimeConfig) GetGLabelsOffset() uint32 {
+	if x != nil {
+		return x.GLabelsOffset
+	}
+	return 0
+}
+
+func (x *RuntimeConfig) GetLabelMapListOffset() uint32 {
+	if x != nil {
+		return x.LabelMapListOffset
+	}
+	return 0
+}
+
+func (x *RuntimeConfig) GetLabelByteLen() uint32 {
+	if x != nil {
+		return x.LabelByteLen
+	}
+	return 0
+}
+
+func (x *RuntimeConfig) GetLabelKeyOffset() uint32 {
+	if x != nil {
+		return x.LabelKeyOffset
+	}
+	return 0
+}
+
+func (x *RuntimeConfig) GetLabelValueOffset() uint32 {
+	if x != nil {
+		return x.LabelValueOffset
+	}
+	return 0
+}
+
+func (x *RuntimeConfig) GetGGopcOffset() uint32 {
+	if x != nil {
+		return x.GGopcOffset
+	}
+	return 0
+}
+
+func (x *RuntimeConfig) GetGParentGoidOffset() uint32 {
+	if x != nil {
+		return x.GParentGoidOffset
+	}
+	return 0
+}
+
+func (x *RuntimeConfig) GetGWaitingOffset() uint32 {
+	if x != nil {
+		return x.GWaitingOffset
+	}
+	return 0
+}
+
+func (x *RuntimeConfig) GetSudogElemOffset() uint32 {
+	if x != nil {
+		return x.SudogElemOffset
+	}
+	return 0
+}
+
+func (x *RuntimeConfig) GetSudogCOffset() uint32 {
+	if x != nil {
+		return x.SudogCOffset
+	}
+	return 0
+}
+
+func (x *RuntimeConfig) GetSudogWaitlinkOffset() uint32 {
+	if x != nil {
+		return x.SudogWaitlinkOffset
+	}
+	return 0
+}
+
+func (x *RuntimeConfig) GetHchanType() uint32 {
+	if x != nil {
+		return x.HchanType
+	}
+	return 0
+}
+
+func (x *RuntimeConfig) GetMstatsLastGcNanotimeOffset() uint32 {
+	if x != nil {
+		return x.MstatsLastGcNanotimeOffset
+	}
+	return 0
+}
+
+func (x *RuntimeConfig) GetMstatsPauseTotalNsOffset() uint32 {
+	if x != nil {
+		return x.MstatsPauseTotalNsOffset
+	}
+	return 0
+}
+
+func (x *RuntimeConfig) GetMstatsNumgcOffset() uint32 {
+	if x != nil {
+		return x.MstatsNumgcOffset
+	}
+	return 0
+}
+
+func (x *RuntimeConfig) GetMstatsNumforcedgcOffset() uint32 {
+	if x != nil {
+		return x.MstatsNumforcedgcOffset
+	}
+	return 0
+}
+
+func (x *RuntimeConfig) GetMstatsGcCpuFractionOffset() uint32 {
+	if x != nil {
+		return x.MstatsGcCpuFractionOffset
+	}
+	return 0
+}
+
 func (x *RuntimeConfig) GetMPreemptOffOffset() uint32 {
 	if x != nil {
 		return x.MPreemptOffOffset
@@ -216,6 +413,27 @@ func (x *RuntimeConfig) GetModuledataBssOffset() uint32 {
 	return 0
 }
 
+func (x *RuntimeConfig) GetModuledataNextOffset() uint32 {
+	if x != nil {
+		return x.ModuledataNextOffset
+	}
+	return 0
+}
+
+func (x *RuntimeConfig) GetModuledataPluginpathOffset() uint32 {
+	if x != nil {
+		return x.ModuledataPluginpathOffset
+	}
+	return 0
+}
+
+func (x *RuntimeConfig) GetModuledataModulenameOffset() uint32 {
+	if x != nil {
+		return x.ModuledataModulenameOffset
+	}
+	return 0
+}
+
 func (x *RuntimeConfig) GetVariableRuntimeDotMemstats() uint64 {
 	if x != nil {
 		return x.VariableRuntimeDotMemstats
@@ -523,10 +741,13 @@ type TypeInfo struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	EnqueuePc              uint32         `protobuf:"varint,1,opt,name=enqueue_pc,json=enqueuePc,proto3" json:"enqueue_pc,omitempty"`
-	ByteLen                uint32         `protobuf:"varint,2,opt,name=byte_len,json=byteLen,proto3" json:"byte_len,omitempty"`
-	SerializeBeforeEnqueue bool           `protobuf:"varint,7,opt,name=serialize_before_enqueue,json=serializeBeforeEnqueue,proto3" json:"serialize_before_enqueue,omitempty"`
-	GoContextImpl          *GoContextImpl `protobuf:"bytes,3,opt,name=go_context_impl,json=goContextImpl,proto3" json:"go_context_impl,omitempty"`
+	EnqueuePc              uint32 `protobuf:"varint,1,opt,name=enqueue_pc,json=enqueuePc,proto3" json:"enqueue_pc,omitempty"`
+	ByteLen                uint32 `protobuf:"varint,2,opt,name=byte_len,json=byteLen,proto3" json:"byte_len,omitempty"`
+	SerializeBeforeEnqueue bool   `protobuf:"varint,7,opt,name=serialize_before_enqueue,json=serializeBeforeEnqueue,proto3" json:"serialize_before_enqueue,omitempty"`
+	// The maximum number of pointer hops from a root (a stack frame or a static
+	// variable) at which values of this type are captured. Zero means no limit.
+	MaxDepth      uint32         `protobuf:"varint,8,opt,name=max_depth,json=maxDepth,proto3" json:"max_depth,omitempty"`
+	GoContextImpl *GoContextImpl `protobuf:"bytes,3,opt,name=go_context_impl,json=goContextImpl,proto3" json:"go_context_impl,omitempty"`
 	// Go context value spec identified by this type being the key.
 	GoContextKey          *GoContextValueType `protobuf:"bytes,4,opt,name=go_context_key,json=goContextKey,proto3" json:"go_context_key,omitempty"`
 	GoContextKeyValueType *uint32             `protobuf:"varint,5,opt,name=go_context_key_value_type,json=goContextKeyValueType,proto3,oneof" json:"go_context_key_value_type,omitempty"`
@@ -587,6 +808,13 @@ func (x *TypeInfo) GetSerializeBeforeEnqueue() bool {
 	return false
 }
 
+func (x *TypeInfo) GetMaxDepth() uint32 {
+	if x != nil {
+		return x.MaxDepth
+	}
+	return 0
+}
+
 func (x *TypeInfo) GetGoContextImpl() *GoContextImpl {
 	if x != nil {
 		return x.GoContextImpl
@@ -620,12 +848,20 @@ type SnapshotProgram struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	RuntimeConfig         *RuntimeConfig        `protobuf:"bytes,1,opt,name=runtime_config,json=runtimeConfig,proto3" json:"runtime_config,omitempty"`
-	PcClassifier          *PcClassifier         `protobuf:"bytes,2,opt,name=pc_classifier,json=pcClassifier,proto3" json:"pc_classifier,omitempty"`
-	SubroutineClassifier  *SubroutineClassifier `protobuf:"bytes,6,opt,name=subroutine_classifier,json=subroutineClassifier,proto3" json:"subroutine_classifier,omitempty"`
-	GoRuntimeTypeToTypeId map[uint64]uint32     `protobuf:"bytes,3,rep,name=go_runtime_type_to_type_id,json=goRuntimeTypeToTypeId,proto3" json:"go_runtime_type_to_type_id,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
-	TypeInfo              map[uint32]*TypeInfo  `protobuf:"bytes,4,rep,name=type_info,json=typeInfo,proto3" json:"type_info,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	Prog                  []byte                `protobuf:"bytes,5,opt,name=prog,proto3" json:"prog,omitempty"`
+	RuntimeConfig        *RuntimeConfig        `protobuf:"bytes,1,opt,name=runtime_config,json=runtimeConfig,proto3" json:"runtime_config,omitempty"`
+	PcClassifier         *PcClassifier         `protobuf:"bytes,2,opt,name=pc_classifier,json=pcClassifier,proto3" json:"pc_classifier,omitempty"`
+	SubroutineClassifier *SubroutineClassifier `protobuf:"bytes,6,opt,name=subroutine_classifier,json=subroutineClassifier,proto3" json:"subroutine_classifier,omitempty"`
+	// Maps go runtime type ids to type ids. The go runtime type id of a type is
+	// its offset from the start of its module's types section. For types in the
+	// first module, that is the whole id. For types in other modules, the index
+	// of the module in module_names plus one is stored in the upper 32 bits.
+	GoRuntimeTypeToTypeId map[uint64]uint32 `protobuf:"bytes,3,rep,name=go_runtime_type_to_type_id,json=goRuntimeTypeToTypeId,proto3" json:"go_runtime_type_to_type_id,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// Identifies the modules other than the first one (e.g. plugins and shared
+	// libraries) whose types appear in go_runtime_type_to_type_id. A plugin is
+	// identified by its plugin path, and other modules by their module name.
+	ModuleNames []string             `protobuf:"bytes,7,rep,name=module_names,json=moduleNames,proto3" json:"module_names,omitempty"`
+	TypeInfo    map[uint32]*TypeInfo `protobuf:"bytes,4,rep,name=type_info,json=typeInfo,proto3" json:"type_info,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Prog        []byte               `protobuf:"bytes,5,opt,name=prog,proto3" json:"prog,omitempty"`
 }
 
 func (x *SnapshotProgram) Reset() {
@@ -688,6 +924,13 @@ func (x *SnapshotProgram) GetGoRuntimeTypeToTypeId() map[uint64]uint32 {
 	return nil
 }
 
+func (x *SnapshotProgram) GetModuleNames() []string {
+	if x != nil {
+		return x.ModuleNames
+	}
+	return nil
+}
+
 func (x *SnapshotProgram) GetTypeInfo() map[uint32]*TypeInfo {
 	if x != nil {
 		return x.TypeInfo
@@ -762,7 +1005,7 @@ var File_snapshot_program_proto protoreflect.FileDescriptor
 var file_snapshot_program_proto_rawDesc = []byte{
 	0x0a, 0x16, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x5f, 0x70, 0x72, 0x6f, 0x67, 0x72,
 	0x61, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x10, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68,
-	0x6f, 0x74, 0x5f, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x22, 0xb5, 0x0a, 0x0a, 0x0d, 0x52,
+	0x6f, 0x74, 0x5f, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x22, 0xab, 0x13, 0x0a, 0x0d, 0x52,
 	0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x24, 0x0a, 0x0e,
 	0x67, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x64, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x01,
 	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x67, 0x53, 0x63, 0x68, 0x65, 0x64, 0x4f, 0x66, 0x66, 0x73,
@@ -777,174 +1020,249 @@ var file_snapshot_program_proto_rawDesc = []byte{
 	0x6c, 0x50, 0x63, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x2d, 0x0a, 0x13, 0x67, 0x5f, 0x73,
 	0x79, 0x73, 0x63, 0x61, 0x6c, 0x6c, 0x5f, 0x73, 0x70, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74,
 	0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x10, 0x67, 0x53, 0x79, 0x73, 0x63, 0x61, 0x6c, 0x6c,
-	0x53, 0x70, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x67, 0x5f, 0x67, 0x6f,
-	0x69, 0x64, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52,
-	0x0b, 0x67, 0x47, 0x6f, 0x69, 0x64, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x32, 0x0a, 0x15,
-	0x67, 0x5f, 0x61, 0x74, 0x6f, 0x6d, 0x69, 0x63, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x6f,
-	0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x13, 0x67, 0x41, 0x74,
-	0x6f, 0x6d, 0x69, 0x63, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74,
-	0x12, 0x2a, 0x0a, 0x11, 0x67, 0x5f, 0x73, 0x74, 0x6b, 0x74, 0x6f, 0x70, 0x73, 0x70, 0x5f, 0x6f,
-	0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x67, 0x53, 0x74,
-	0x6b, 0x74, 0x6f, 0x70, 0x73, 0x70, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x2f, 0x0a, 0x14,
-	0x6d, 0x5f, 0x70, 0x72, 0x65, 0x65, 0x6d, 0x70, 0x74, 0x5f, 0x6f, 0x66, 0x66, 0x5f, 0x6f, 0x66,
-	0x66, 0x73, 0x65, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x11, 0x6d, 0x50, 0x72, 0x65,
-	0x65, 0x6d, 0x70, 0x74, 0x4f, 0x66, 0x66, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x4f, 0x0a,
-	0x24, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d,
-	0x65, 0x5f, 0x64, 0x6f, 0x74, 0x5f, 0x66, 0x69, 0x72, 0x73, 0x74, 0x6d, 0x6f, 0x64, 0x75, 0x6c,
-	0x65, 0x64, 0x61, 0x74, 0x61, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x21, 0x76, 0x61, 0x72,
-	0x69, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x44, 0x6f, 0x74, 0x46,
-	0x69, 0x72, 0x73, 0x74, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x61, 0x74, 0x61, 0x12, 0x3b,
-	0x0a, 0x1a, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x72, 0x75, 0x6e, 0x74, 0x69,
-	0x6d, 0x65, 0x5f, 0x64, 0x6f, 0x74, 0x5f, 0x61, 0x6c, 0x6c, 0x67, 0x73, 0x18, 0x13, 0x20, 0x01,
-	0x28, 0x04, 0x52, 0x17, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x75, 0x6e, 0x74,
-	0x69, 0x6d, 0x65, 0x44, 0x6f, 0x74, 0x41, 0x6c, 0x6c, 0x67, 0x73, 0x12, 0x36, 0x0a, 0x17, 0x6d,
-	0x6f, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x5f,
-	0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x15, 0x6d, 0x6f,
-	0x64, 0x75, 0x6c, 0x65, 0x64, 0x61, 0x74, 0x61, 0x54, 0x79, 0x70, 0x65, 0x73, 0x4f, 0x66, 0x66,
-	0x73, 0x65, 0x74, 0x12, 0x38, 0x0a, 0x18, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x61, 0x74,
-	0x61, 0x5f, 0x65, 0x74, 0x79, 0x70, 0x65, 0x73, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18,
-	0x0b, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x16, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x61, 0x74,
-	0x61, 0x45, 0x74, 0x79, 0x70, 0x65, 0x73, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x34, 0x0a,
-	0x16, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x74, 0x65, 0x78, 0x74,
-	0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x14, 0x6d,
-	0x6f, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x61, 0x74, 0x61, 0x54, 0x65, 0x78, 0x74, 0x4f, 0x66, 0x66,
-	0x73, 0x65, 0x74, 0x12, 0x32, 0x0a, 0x15, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x61, 0x74,
-	0x61, 0x5f, 0x62, 0x73, 0x73, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x14, 0x20, 0x01,
-	0x28, 0x0d, 0x52, 0x13, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x61, 0x74, 0x61, 0x42, 0x73,
-	0x73, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x41, 0x0a, 0x1d, 0x76, 0x61, 0x72, 0x69, 0x61,
-	0x62, 0x6c, 0x65, 0x5f, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x64, 0x6f, 0x74, 0x5f,
-	0x6d, 0x65, 0x6d, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x17, 0x20, 0x01, 0x28, 0x04, 0x52, 0x1a,
+	0x53, 0x70, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x2d, 0x0a, 0x13, 0x67, 0x5f, 0x73, 0x79,
+	0x73, 0x63, 0x61, 0x6c, 0x6c, 0x5f, 0x62, 0x70, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18,
+	0x1e, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x10, 0x67, 0x53, 0x79, 0x73, 0x63, 0x61, 0x6c, 0x6c, 0x42,
+	0x70, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x67, 0x5f, 0x67, 0x6f, 0x69,
+	0x64, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b,
+	0x67, 0x47, 0x6f, 0x69, 0x64, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x32, 0x0a, 0x15, 0x67,
+	0x5f, 0x61, 0x74, 0x6f, 0x6d, 0x69, 0x63, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x6f, 0x66,
+	0x66, 0x73, 0x65, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x13, 0x67, 0x41, 0x74, 0x6f,
+	0x6d, 0x69, 0x63, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12,
+	0x2a, 0x0a, 0x11, 0x67, 0x5f, 0x73, 0x74, 0x6b, 0x74, 0x6f, 0x70, 0x73, 0x70, 0x5f, 0x6f, 0x66,
+	0x66, 0x73, 0x65, 0x74, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x67, 0x53, 0x74, 0x6b,
+	0x74, 0x6f, 0x70, 0x73, 0x70, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x2c, 0x0a, 0x12, 0x67,
+	0x5f, 0x77, 0x61, 0x69, 0x74, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65,
+	0x74, 0x18, 0x19, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x10, 0x67, 0x57, 0x61, 0x69, 0x74, 0x73, 0x69,
+	0x6e, 0x63, 0x65, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x2e, 0x0a, 0x13, 0x67, 0x5f, 0x77,
+	0x61, 0x69, 0x74, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74,
+	0x18, 0x1a, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x11, 0x67, 0x57, 0x61, 0x69, 0x74, 0x72, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x26, 0x0a, 0x0f, 0x67, 0x5f, 0x6c,
+	0x61, 0x62, 0x65, 0x6c, 0x73, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x22, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0d, 0x67, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x4f, 0x66, 0x66, 0x73, 0x65,
+	0x74, 0x12, 0x31, 0x0a, 0x15, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x5f, 0x6d, 0x61, 0x70, 0x5f, 0x6c,
+	0x69, 0x73, 0x74, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x23, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x12, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x4d, 0x61, 0x70, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x66,
+	0x66, 0x73, 0x65, 0x74, 0x12, 0x24, 0x0a, 0x0e, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x5f, 0x62, 0x79,
+	0x74, 0x65, 0x5f, 0x6c, 0x65, 0x6e, 0x18, 0x24, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x6c, 0x61,
+	0x62, 0x65, 0x6c, 0x42, 0x79, 0x74, 0x65, 0x4c, 0x65, 0x6e, 0x12, 0x28, 0x0a, 0x10, 0x6c, 0x61,
+	0x62, 0x65, 0x6c, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x25,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x4b, 0x65, 0x79, 0x4f, 0x66,
+	0x66, 0x73, 0x65, 0x74, 0x12, 0x2c, 0x0a, 0x12, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x5f, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x26, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x10, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x4f, 0x66, 0x66, 0x73,
+	0x65, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x67, 0x5f, 0x67, 0x6f, 0x70, 0x63, 0x5f, 0x6f, 0x66, 0x66,
+	0x73, 0x65, 0x74, 0x18, 0x27, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x67, 0x47, 0x6f, 0x70, 0x63,
+	0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x2f, 0x0a, 0x14, 0x67, 0x5f, 0x70, 0x61, 0x72, 0x65,
+	0x6e, 0x74, 0x5f, 0x67, 0x6f, 0x69, 0x64, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x28,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x11, 0x67, 0x50, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x47, 0x6f, 0x69,
+	0x64, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x28, 0x0a, 0x10, 0x67, 0x5f, 0x77, 0x61, 0x69,
+	0x74, 0x69, 0x6e, 0x67, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x29, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x0e, 0x67, 0x57, 0x61, 0x69, 0x74, 0x69, 0x6e, 0x67, 0x4f, 0x66, 0x66, 0x73, 0x65,
+	0x74, 0x12, 0x2a, 0x0a, 0x11, 0x73, 0x75, 0x64, 0x6f, 0x67, 0x5f, 0x65, 0x6c, 0x65, 0x6d, 0x5f,
+	0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x2a, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x73, 0x75,
+	0x64, 0x6f, 0x67, 0x45, 0x6c, 0x65, 0x6d, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x24, 0x0a,
+	0x0e, 0x73, 0x75, 0x64, 0x6f, 0x67, 0x5f, 0x63, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18,
+	0x2b, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x73, 0x75, 0x64, 0x6f, 0x67, 0x43, 0x4f, 0x66, 0x66,
+	0x73, 0x65, 0x74, 0x12, 0x32, 0x0a, 0x15, 0x73, 0x75, 0x64, 0x6f, 0x67, 0x5f, 0x77, 0x61, 0x69,
+	0x74, 0x6c, 0x69, 0x6e, 0x6b, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x2c, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x13, 0x73, 0x75, 0x64, 0x6f, 0x67, 0x57, 0x61, 0x69, 0x74, 0x6c, 0x69, 0x6e,
+	0x6b, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x68, 0x63, 0x68, 0x61, 0x6e,
+	0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x2d, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x68, 0x63, 0x68,
+	0x61, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x42, 0x0a, 0x1e, 0x6d, 0x73, 0x74, 0x61, 0x74, 0x73,
+	0x5f, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x67, 0x63, 0x5f, 0x6e, 0x61, 0x6e, 0x6f, 0x74, 0x69, 0x6d,
+	0x65, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x2e, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x1a,
+	0x6d, 0x73, 0x74, 0x61, 0x74, 0x73, 0x4c, 0x61, 0x73, 0x74, 0x47, 0x63, 0x4e, 0x61, 0x6e, 0x6f,
+	0x74, 0x69, 0x6d, 0x65, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x3e, 0x0a, 0x1c, 0x6d, 0x73,
+	0x74, 0x61, 0x74, 0x73, 0x5f, 0x70, 0x61, 0x75, 0x73, 0x65, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c,
+	0x5f, 0x6e, 0x73, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x2f, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x18, 0x6d, 0x73, 0x74, 0x61, 0x74, 0x73, 0x50, 0x61, 0x75, 0x73, 0x65, 0x54, 0x6f, 0x74,
+	0x61, 0x6c, 0x4e, 0x73, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x2e, 0x0a, 0x13, 0x6d, 0x73,
+	0x74, 0x61, 0x74, 0x73, 0x5f, 0x6e, 0x75, 0x6d, 0x67, 0x63, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65,
+	0x74, 0x18, 0x30, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x11, 0x6d, 0x73, 0x74, 0x61, 0x74, 0x73, 0x4e,
+	0x75, 0x6d, 0x67, 0x63, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x3a, 0x0a, 0x19, 0x6d, 0x73,
+	0x74, 0x61, 0x74, 0x73, 0x5f, 0x6e, 0x75, 0x6d, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x64, 0x67, 0x63,
+	0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x31, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x17, 0x6d,
+	0x73, 0x74, 0x61, 0x74, 0x73, 0x4e, 0x75, 0x6d, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x64, 0x67, 0x63,
+	0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x40, 0x0a, 0x1d, 0x6d, 0x73, 0x74, 0x61, 0x74, 0x73,
+	0x5f, 0x67, 0x63, 0x5f, 0x63, 0x70, 0x75, 0x5f, 0x66, 0x72, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x32, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x19, 0x6d,
+	0x73, 0x74, 0x61, 0x74, 0x73, 0x47, 0x63, 0x43, 0x70, 0x75, 0x46, 0x72, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x2f, 0x0a, 0x14, 0x6d, 0x5f, 0x70, 0x72,
+	0x65, 0x65, 0x6d, 0x70, 0x74, 0x5f, 0x6f, 0x66, 0x66, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x11, 0x6d, 0x50, 0x72, 0x65, 0x65, 0x6d, 0x70, 0x74,
+	0x4f, 0x66, 0x66, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x4f, 0x0a, 0x24, 0x76, 0x61, 0x72,
+	0x69, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x64, 0x6f,
+	0x74, 0x5f, 0x66, 0x69, 0x72, 0x73, 0x74, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x61, 0x74,
+	0x61, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x21, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c,
+	0x65, 0x52, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x44, 0x6f, 0x74, 0x46, 0x69, 0x72, 0x73, 0x74,
+	0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x61, 0x74, 0x61, 0x12, 0x3b, 0x0a, 0x1a, 0x76, 0x61,
+	0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x64,
+	0x6f, 0x74, 0x5f, 0x61, 0x6c, 0x6c, 0x67, 0x73, 0x18, 0x13, 0x20, 0x01, 0x28, 0x04, 0x52, 0x17,
 	0x76, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x44,
-	0x6f, 0x74, 0x4d, 0x65, 0x6d, 0x73, 0x74, 0x61, 0x74, 0x73, 0x12, 0x3a, 0x0a, 0x1a, 0x6d, 0x73,
-	0x74, 0x61, 0x74, 0x73, 0x5f, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x67, 0x63, 0x5f, 0x75, 0x6e, 0x69,
-	0x78, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x18, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x16,
-	0x6d, 0x73, 0x74, 0x61, 0x74, 0x73, 0x4c, 0x61, 0x73, 0x74, 0x47, 0x63, 0x55, 0x6e, 0x69, 0x78,
-	0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x33, 0x0a, 0x16, 0x67, 0x6f, 0x5f, 0x72, 0x75, 0x6e,
-	0x74, 0x69, 0x6d, 0x65, 0x5f, 0x62, 0x73, 0x73, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
-	0x18, 0x15, 0x20, 0x01, 0x28, 0x04, 0x52, 0x13, 0x67, 0x6f, 0x52, 0x75, 0x6e, 0x74, 0x69, 0x6d,
-	0x65, 0x42, 0x73, 0x73, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x59, 0x0a, 0x10, 0x73,
-	0x74, 0x61, 0x74, 0x69, 0x63, 0x5f, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x18,
-	0x16, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
+	0x6f, 0x74, 0x41, 0x6c, 0x6c, 0x67, 0x73, 0x12, 0x36, 0x0a, 0x17, 0x6d, 0x6f, 0x64, 0x75, 0x6c,
+	0x65, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x5f, 0x6f, 0x66, 0x66, 0x73,
+	0x65, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x15, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65,
+	0x64, 0x61, 0x74, 0x61, 0x54, 0x79, 0x70, 0x65, 0x73, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12,
+	0x38, 0x0a, 0x18, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x65, 0x74,
+	0x79, 0x70, 0x65, 0x73, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x16, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x61, 0x74, 0x61, 0x45, 0x74, 0x79,
+	0x70, 0x65, 0x73, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x34, 0x0a, 0x16, 0x6d, 0x6f, 0x64,
+	0x75, 0x6c, 0x65, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x74, 0x65, 0x78, 0x74, 0x5f, 0x6f, 0x66, 0x66,
+	0x73, 0x65, 0x74, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x14, 0x6d, 0x6f, 0x64, 0x75, 0x6c,
+	0x65, 0x64, 0x61, 0x74, 0x61, 0x54, 0x65, 0x78, 0x74, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12,
+	0x32, 0x0a, 0x15, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x62, 0x73,
+	0x73, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x13,
+	0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x61, 0x74, 0x61, 0x42, 0x73, 0x73, 0x4f, 0x66, 0x66,
+	0x73, 0x65, 0x74, 0x12, 0x34, 0x0a, 0x16, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x61, 0x74,
+	0x61, 0x5f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x1f, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x14, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x61, 0x74, 0x61, 0x4e,
+	0x65, 0x78, 0x74, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x40, 0x0a, 0x1c, 0x6d, 0x6f, 0x64,
+	0x75, 0x6c, 0x65, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x70, 0x61,
+	0x74, 0x68, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x20, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x1a, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x61, 0x74, 0x61, 0x50, 0x6c, 0x75, 0x67, 0x69,
+	0x6e, 0x70, 0x61, 0x74, 0x68, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x40, 0x0a, 0x1c, 0x6d,
+	0x6f, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65,
+	0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x21, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x1a, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x61, 0x74, 0x61, 0x4d, 0x6f, 0x64,
+	0x75, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x41, 0x0a,
+	0x1d, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d,
+	0x65, 0x5f, 0x64, 0x6f, 0x74, 0x5f, 0x6d, 0x65, 0x6d, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x17,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x1a, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x75,
+	0x6e, 0x74, 0x69, 0x6d, 0x65, 0x44, 0x6f, 0x74, 0x4d, 0x65, 0x6d, 0x73, 0x74, 0x61, 0x74, 0x73,
+	0x12, 0x3a, 0x0a, 0x1a, 0x6d, 0x73, 0x74, 0x61, 0x74, 0x73, 0x5f, 0x6c, 0x61, 0x73, 0x74, 0x5f,
+	0x67, 0x63, 0x5f, 0x75, 0x6e, 0x69, 0x78, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x18,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x16, 0x6d, 0x73, 0x74, 0x61, 0x74, 0x73, 0x4c, 0x61, 0x73, 0x74,
+	0x47, 0x63, 0x55, 0x6e, 0x69, 0x78, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x33, 0x0a, 0x16,
+	0x67, 0x6f, 0x5f, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x62, 0x73, 0x73, 0x5f, 0x61,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x15, 0x20, 0x01, 0x28, 0x04, 0x52, 0x13, 0x67, 0x6f,
+	0x52, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x42, 0x73, 0x73, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x12, 0x59, 0x0a, 0x10, 0x73, 0x74, 0x61, 0x74, 0x69, 0x63, 0x5f, 0x76, 0x61, 0x72, 0x69,
+	0x61, 0x62, 0x6c, 0x65, 0x73, 0x18, 0x16, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x73, 0x6e,
+	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x5f, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x2e, 0x52,
+	0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x53, 0x74, 0x61,
+	0x74, 0x69, 0x63, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x0f, 0x73, 0x74, 0x61,
+	0x74, 0x69, 0x63, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x12, 0x30, 0x0a, 0x14,
+	0x64, 0x65, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x5f, 0x70, 0x63, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x04, 0x52, 0x12, 0x64, 0x65, 0x72, 0x65,
+	0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x53, 0x74, 0x61, 0x72, 0x74, 0x50, 0x63, 0x12, 0x2c,
+	0x0a, 0x12, 0x64, 0x65, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x65, 0x6e,
+	0x64, 0x5f, 0x70, 0x63, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x64, 0x65, 0x72, 0x65,
+	0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x45, 0x6e, 0x64, 0x50, 0x63, 0x12, 0x3a, 0x0a, 0x1a,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x68, 0x65, 0x5f, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x5f,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x18, 0x11, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x16, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x68, 0x65, 0x57, 0x6f, 0x72, 0x6c, 0x64, 0x53,
+	0x74, 0x61, 0x72, 0x74, 0x41, 0x64, 0x64, 0x72, 0x12, 0x38, 0x0a, 0x19, 0x73, 0x74, 0x6f, 0x70,
+	0x5f, 0x74, 0x68, 0x65, 0x5f, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x5f, 0x61, 0x64, 0x64, 0x72, 0x18, 0x12, 0x20, 0x01, 0x28, 0x04, 0x52, 0x15, 0x73, 0x74, 0x6f,
+	0x70, 0x54, 0x68, 0x65, 0x57, 0x6f, 0x72, 0x6c, 0x64, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x64,
+	0x64, 0x72, 0x1a, 0x3e, 0x0a, 0x0e, 0x53, 0x74, 0x61, 0x74, 0x69, 0x63, 0x56, 0x61, 0x72, 0x69,
+	0x61, 0x62, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x4a, 0x04, 0x08, 0x0f, 0x10, 0x10, 0x22, 0x44, 0x0a, 0x0c, 0x50, 0x63, 0x43, 0x6c,
+	0x61, 0x73, 0x73, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x5f, 0x70, 0x63, 0x18, 0x01, 0x20, 0x03, 0x28, 0x04, 0x52, 0x08, 0x74, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x50, 0x63, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x67, 0x5f, 0x70, 0x63,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x06, 0x70, 0x72, 0x6f, 0x67, 0x50, 0x63, 0x22, 0x83,
+	0x01, 0x0a, 0x14, 0x53, 0x75, 0x62, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x65, 0x43, 0x6c, 0x61,
+	0x73, 0x73, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x3c, 0x0a, 0x1a, 0x75, 0x6e, 0x72, 0x65, 0x73,
+	0x6f, 0x6c, 0x76, 0x65, 0x64, 0x5f, 0x73, 0x75, 0x62, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x65,
+	0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x18, 0x75, 0x6e, 0x72,
+	0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x53, 0x75, 0x62, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e,
+	0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x5f, 0x70,
+	0x63, 0x18, 0x01, 0x20, 0x03, 0x28, 0x04, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x50, 0x63,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x22, 0xba, 0x01, 0x0a, 0x0d, 0x47, 0x6f, 0x43, 0x6f, 0x6e, 0x74, 0x65,
+	0x78, 0x74, 0x49, 0x6d, 0x70, 0x6c, 0x12, 0x2a, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78,
+	0x74, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x00,
+	0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x88,
+	0x01, 0x01, 0x12, 0x22, 0x0a, 0x0a, 0x6b, 0x65, 0x79, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x01, 0x52, 0x09, 0x6b, 0x65, 0x79, 0x4f, 0x66, 0x66,
+	0x73, 0x65, 0x74, 0x88, 0x01, 0x01, 0x12, 0x26, 0x0a, 0x0c, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f,
+	0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x02, 0x52, 0x0b,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x88, 0x01, 0x01, 0x42, 0x11,
+	0x0a, 0x0f, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65,
+	0x74, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74,
+	0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65,
+	0x74, 0x22, 0x56, 0x0a, 0x12, 0x47, 0x6f, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x16, 0x0a,
+	0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6f,
+	0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0xdd, 0x03, 0x0a, 0x08, 0x54, 0x79,
+	0x70, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x6e, 0x71, 0x75, 0x65, 0x75,
+	0x65, 0x5f, 0x70, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x65, 0x6e, 0x71, 0x75,
+	0x65, 0x75, 0x65, 0x50, 0x63, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x79, 0x74, 0x65, 0x5f, 0x6c, 0x65,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x62, 0x79, 0x74, 0x65, 0x4c, 0x65, 0x6e,
+	0x12, 0x38, 0x0a, 0x18, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x5f, 0x62, 0x65,
+	0x66, 0x6f, 0x72, 0x65, 0x5f, 0x65, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x16, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x42, 0x65, 0x66,
+	0x6f, 0x72, 0x65, 0x45, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61,
+	0x78, 0x5f, 0x64, 0x65, 0x70, 0x74, 0x68, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x6d,
+	0x61, 0x78, 0x44, 0x65, 0x70, 0x74, 0x68, 0x12, 0x47, 0x0a, 0x0f, 0x67, 0x6f, 0x5f, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x78, 0x74, 0x5f, 0x69, 0x6d, 0x70, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1f, 0x2e, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x5f, 0x70, 0x72, 0x6f, 0x67,
+	0x72, 0x61, 0x6d, 0x2e, 0x47, 0x6f, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x49, 0x6d, 0x70,
+	0x6c, 0x52, 0x0d, 0x67, 0x6f, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x49, 0x6d, 0x70, 0x6c,
+	0x12, 0x4a, 0x0a, 0x0e, 0x67, 0x6f, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x5f, 0x6b,
+	0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x73, 0x6e, 0x61, 0x70, 0x73,
+	0x68, 0x6f, 0x74, 0x5f, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x2e, 0x47, 0x6f, 0x43, 0x6f,
+	0x6e, 0x74, 0x65, 0x78, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0c,
+	0x67, 0x6f, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x4b, 0x65, 0x79, 0x12, 0x3d, 0x0a, 0x19,
+	0x67, 0x6f, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x48,
+	0x00, 0x52, 0x15, 0x67, 0x6f, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x4b, 0x65, 0x79, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x54, 0x79, 0x70, 0x65, 0x88, 0x01, 0x01, 0x12, 0x4e, 0x0a, 0x10, 0x67,
+	0x6f, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
+	0x5f, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x2e, 0x47, 0x6f, 0x43, 0x6f, 0x6e, 0x74, 0x65,
+	0x78, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0e, 0x67, 0x6f, 0x43,
+	0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x1c, 0x0a, 0x1a, 0x5f,
+	0x67, 0x6f, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x22, 0x9c, 0x05, 0x0a, 0x0f, 0x53, 0x6e,
+	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x12, 0x46, 0x0a,
+	0x0e, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
 	0x5f, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x2e, 0x52, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65,
-	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x69, 0x63, 0x56, 0x61, 0x72,
-	0x69, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x0f, 0x73, 0x74, 0x61, 0x74, 0x69, 0x63, 0x56, 0x61, 0x72,
-	0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x12, 0x30, 0x0a, 0x14, 0x64, 0x65, 0x72, 0x65, 0x66, 0x65,
-	0x72, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x70, 0x63, 0x18, 0x0c,
-	0x20, 0x01, 0x28, 0x04, 0x52, 0x12, 0x64, 0x65, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63,
-	0x65, 0x53, 0x74, 0x61, 0x72, 0x74, 0x50, 0x63, 0x12, 0x2c, 0x0a, 0x12, 0x64, 0x65, 0x72, 0x65,
-	0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x65, 0x6e, 0x64, 0x5f, 0x70, 0x63, 0x18, 0x0d,
-	0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x64, 0x65, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63,
-	0x65, 0x45, 0x6e, 0x64, 0x50, 0x63, 0x12, 0x3a, 0x0a, 0x1a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f,
-	0x74, 0x68, 0x65, 0x5f, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f,
-	0x61, 0x64, 0x64, 0x72, 0x18, 0x11, 0x20, 0x01, 0x28, 0x04, 0x52, 0x16, 0x73, 0x74, 0x61, 0x72,
-	0x74, 0x54, 0x68, 0x65, 0x57, 0x6f, 0x72, 0x6c, 0x64, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x64,
-	0x64, 0x72, 0x12, 0x38, 0x0a, 0x19, 0x73, 0x74, 0x6f, 0x70, 0x5f, 0x74, 0x68, 0x65, 0x5f, 0x77,
-	0x6f, 0x72, 0x6c, 0x64, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x18,
-	0x12, 0x20, 0x01, 0x28, 0x04, 0x52, 0x15, 0x73, 0x74, 0x6f, 0x70, 0x54, 0x68, 0x65, 0x57, 0x6f,
-	0x72, 0x6c, 0x64, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x64, 0x64, 0x72, 0x1a, 0x3e, 0x0a, 0x0e,
-	0x53, 0x74, 0x61, 0x74, 0x69, 0x63, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x12,
-	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x74, 0x79,
-	0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x04, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x4a, 0x04, 0x08, 0x0f,
-	0x10, 0x10, 0x22, 0x44, 0x0a, 0x0c, 0x50, 0x63, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x69, 0x66, 0x69,
-	0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x70, 0x63, 0x18,
-	0x01, 0x20, 0x03, 0x28, 0x04, 0x52, 0x08, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x50, 0x63, 0x12,
-	0x17, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x67, 0x5f, 0x70, 0x63, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0d,
-	0x52, 0x06, 0x70, 0x72, 0x6f, 0x67, 0x50, 0x63, 0x22, 0x83, 0x01, 0x0a, 0x14, 0x53, 0x75, 0x62,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0d, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x43, 0x0a, 0x0d, 0x70, 0x63, 0x5f, 0x63, 0x6c, 0x61, 0x73,
+	0x73, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x73,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x5f, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x2e,
+	0x50, 0x63, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x69, 0x66, 0x69, 0x65, 0x72, 0x52, 0x0c, 0x70, 0x63,
+	0x43, 0x6c, 0x61, 0x73, 0x73, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x5b, 0x0a, 0x15, 0x73, 0x75,
+	0x62, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x65, 0x5f, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x69, 0x66,
+	0x69, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x73, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x5f, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x2e, 0x53, 0x75, 0x62,
 	0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x65, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x69, 0x66, 0x69, 0x65,
-	0x72, 0x12, 0x3c, 0x0a, 0x1a, 0x75, 0x6e, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x5f,
-	0x73, 0x75, 0x62, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x18, 0x75, 0x6e, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65,
-	0x64, 0x53, 0x75, 0x62, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12,
-	0x19, 0x0a, 0x08, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x5f, 0x70, 0x63, 0x18, 0x01, 0x20, 0x03, 0x28,
-	0x04, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x50, 0x63, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79,
-	0x70, 0x65, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0xba,
-	0x01, 0x0a, 0x0d, 0x47, 0x6f, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x49, 0x6d, 0x70, 0x6c,
-	0x12, 0x2a, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x5f, 0x6f, 0x66, 0x66, 0x73,
-	0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x00, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x74,
-	0x65, 0x78, 0x74, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x88, 0x01, 0x01, 0x12, 0x22, 0x0a, 0x0a,
-	0x6b, 0x65, 0x79, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d,
-	0x48, 0x01, 0x52, 0x09, 0x6b, 0x65, 0x79, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x88, 0x01, 0x01,
-	0x12, 0x26, 0x0a, 0x0c, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x02, 0x52, 0x0b, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x4f,
-	0x66, 0x66, 0x73, 0x65, 0x74, 0x88, 0x01, 0x01, 0x42, 0x11, 0x0a, 0x0f, 0x5f, 0x63, 0x6f, 0x6e,
-	0x74, 0x65, 0x78, 0x74, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x42, 0x0d, 0x0a, 0x0b, 0x5f,
-	0x6b, 0x65, 0x79, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x22, 0x56, 0x0a, 0x12, 0x47,
-	0x6f, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x54, 0x79, 0x70,
-	0x65, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d,
-	0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65,
-	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12,
-	0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x74,
-	0x79, 0x70, 0x65, 0x22, 0xc0, 0x03, 0x0a, 0x08, 0x54, 0x79, 0x70, 0x65, 0x49, 0x6e, 0x66, 0x6f,
-	0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x5f, 0x70, 0x63, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x65, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x50, 0x63, 0x12,
-	0x19, 0x0a, 0x08, 0x62, 0x79, 0x74, 0x65, 0x5f, 0x6c, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0d, 0x52, 0x07, 0x62, 0x79, 0x74, 0x65, 0x4c, 0x65, 0x6e, 0x12, 0x38, 0x0a, 0x18, 0x73, 0x65,
-	0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x5f, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x5f, 0x65,
-	0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x16, 0x73, 0x65,
-	0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x45, 0x6e, 0x71,
-	0x75, 0x65, 0x75, 0x65, 0x12, 0x47, 0x0a, 0x0f, 0x67, 0x6f, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x65,
-	0x78, 0x74, 0x5f, 0x69, 0x6d, 0x70, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e,
-	0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x5f, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d,
-	0x2e, 0x47, 0x6f, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x49, 0x6d, 0x70, 0x6c, 0x52, 0x0d,
-	0x67, 0x6f, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x49, 0x6d, 0x70, 0x6c, 0x12, 0x4a, 0x0a,
-	0x0e, 0x67, 0x6f, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x5f, 0x6b, 0x65, 0x79, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
-	0x5f, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x2e, 0x47, 0x6f, 0x43, 0x6f, 0x6e, 0x74, 0x65,
-	0x78, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0c, 0x67, 0x6f, 0x43,
-	0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x4b, 0x65, 0x79, 0x12, 0x3d, 0x0a, 0x19, 0x67, 0x6f, 0x5f,
-	0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x00, 0x52, 0x15,
-	0x67, 0x6f, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x4b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75,
-	0x65, 0x54, 0x79, 0x70, 0x65, 0x88, 0x01, 0x01, 0x12, 0x4e, 0x0a, 0x10, 0x67, 0x6f, 0x5f, 0x63,
-	0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x06, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x24, 0x2e, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x5f, 0x70, 0x72,
-	0x6f, 0x67, 0x72, 0x61, 0x6d, 0x2e, 0x47, 0x6f, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x56,
-	0x61, 0x6c, 0x75, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0e, 0x67, 0x6f, 0x43, 0x6f, 0x6e, 0x74,
-	0x65, 0x78, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x1c, 0x0a, 0x1a, 0x5f, 0x67, 0x6f, 0x5f,
-	0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x22, 0xf9, 0x04, 0x0a, 0x0f, 0x53, 0x6e, 0x61, 0x70, 0x73,
-	0x68, 0x6f, 0x74, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x12, 0x46, 0x0a, 0x0e, 0x72, 0x75,
-	0x6e, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x5f, 0x70, 0x72,
-	0x6f, 0x67, 0x72, 0x61, 0x6d, 0x2e, 0x52, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x43, 0x6f, 0x6e,
-	0x66, 0x69, 0x67, 0x52, 0x0d, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x43, 0x6f, 0x6e, 0x66,
-	0x69, 0x67, 0x12, 0x43, 0x0a, 0x0d, 0x70, 0x63, 0x5f, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x69, 0x66,
-	0x69, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x73, 0x6e, 0x61, 0x70,
-	0x73, 0x68, 0x6f, 0x74, 0x5f, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x2e, 0x50, 0x63, 0x43,
-	0x6c, 0x61, 0x73, 0x73, 0x69, 0x66, 0x69, 0x65, 0x72, 0x52, 0x0c, 0x70, 0x63, 0x43, 0x6c, 0x61,
-	0x73, 0x73, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x5b, 0x0a, 0x15, 0x73, 0x75, 0x62, 0x72, 0x6f,
-	0x75, 0x74, 0x69, 0x6e, 0x65, 0x5f, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x69, 0x66, 0x69, 0x65, 0x72,
-	0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
-	0x74, 0x5f, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x2e, 0x53, 0x75, 0x62, 0x72, 0x6f, 0x75,
-	0x74, 0x69, 0x6e, 0x65, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x69, 0x66, 0x69, 0x65, 0x72, 0x52, 0x14,
-	0x73, 0x75, 0x62, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x65, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x69,
-	0x66, 0x69, 0x65, 0x72, 0x12, 0x77, 0x0a, 0x1a, 0x67, 0x6f, 0x5f, 0x72, 0x75, 0x6e, 0x74, 0x69,
-	0x6d, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x74, 0x6f, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x5f,
-	0x69, 0x64, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3c, 0x2e, 0x73, 0x6e, 0x61, 0x70, 0x73,
-	0x68, 0x6f, 0x74, 0x5f, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x2e, 0x53, 0x6e, 0x61, 0x70,
-	0x73, 0x68, 0x6f, 0x74, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x2e, 0x47, 0x6f, 0x52, 0x75,
-	0x6e, 0x74, 0x69, 0x6d, 0x65, 0x54, 0x79, 0x70, 0x65, 0x54, 0x6f, 0x54, 0x79, 0x70, 0x65, 0x49,
-	0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x15, 0x67, 0x6f, 0x52, 0x75, 0x6e, 0x74, 0x69, 0x6d,
-	0x65, 0x54, 0x79, 0x70, 0x65, 0x54, 0x6f, 0x54, 0x79, 0x70, 0x65, 0x49, 0x64, 0x12, 0x4c, 0x0a,
-	0x09, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x2f, 0x2e, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x5f, 0x70, 0x72, 0x6f, 0x67,
-	0x72, 0x61, 0x6d, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x50, 0x72, 0x6f, 0x67,
-	0x72, 0x61, 0x6d, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x45, 0x6e, 0x74, 0x72,
-	0x79, 0x52, 0x08, 0x74, 0x79, 0x70, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x70,
-	0x72, 0x6f, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x70, 0x72, 0x6f, 0x67, 0x1a,
-	0x48, 0x0a, 0x1a, 0x47, 0x6f, 0x52, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x54, 0x79, 0x70, 0x65,
-	0x54, 0x6f, 0x54, 0x79, 0x70, 0x65, 0x49, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
-	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
-	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x57, 0x0a, 0x0d, 0x54, 0x79, 0x70,
-	0x65, 0x49, 0x6e, 0x66, 0x6f, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
-	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x30, 0x0a, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x73, 0x6e,
-	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x5f, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x2e, 0x54,
-	0x79, 0x70, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
-	0x38, 0x01, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x72, 0x52, 0x14, 0x73, 0x75, 0x62, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x65, 0x43, 0x6c, 0x61,
+	0x73, 0x73, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x77, 0x0a, 0x1a, 0x67, 0x6f, 0x5f, 0x72, 0x75,
+	0x6e, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x74, 0x6f, 0x5f, 0x74, 0x79,
+	0x70, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3c, 0x2e, 0x73, 0x6e,
+	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x5f, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x2e, 0x53,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x2e, 0x47,
+	0x6f, 0x52, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x54, 0x79, 0x70, 0x65, 0x54, 0x6f, 0x54, 0x79,
+	0x70, 0x65, 0x49, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x15, 0x67, 0x6f, 0x52, 0x75, 0x6e,
+	0x74, 0x69, 0x6d, 0x65, 0x54, 0x79, 0x70, 0x65, 0x54, 0x6f, 0x54, 0x79, 0x70, 0x65, 0x49, 0x64,
+	0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73,
+	0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x4e, 0x61,
+	0x6d, 0x65, 0x73, 0x12, 0x4c, 0x0a, 0x09, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x69, 0x6e, 0x66, 0x6f,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x5f, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x49, 0x6e,
+	0x66, 0x6f, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x74, 0x79, 0x70, 0x65, 0x49, 0x6e, 0x66,
+	0x6f, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x72, 0x6f, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x04, 0x70, 0x72, 0x6f, 0x67, 0x1a, 0x48, 0x0a, 0x1a, 0x47, 0x6f, 0x52, 0x75, 0x6e, 0x74, 0x69,
+	0x6d, 0x65, 0x54, 0x79, 0x70, 0x65, 0x54, 0x6f, 0x54, 0x79, 0x70, 0x65, 0x49, 0x64, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a,
+	0x57, 0x0a, 0x0d, 0x54, 0x79, 0x70, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x30, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x5f, 0x70, 0x72, 0x6f,
+	0x67, 0x72, 0x61, 0x6d, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
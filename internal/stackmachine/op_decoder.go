@@ -161,7 +161,10 @@ type (
 		DataByteLen uint32
 		TypeID      uint32
 	}
-	OpPrepareGoContext struct {
+	OpPrepareExprEval    struct{}
+	OpPreparePointeeData struct{}
+	OpConcludeFrameData  struct{}
+	OpPrepareGoContext   struct {
 		DataByteLen  uint32
 		TypeID       uint32
 		CaptureCount uint8
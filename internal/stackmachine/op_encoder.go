@@ -0,0 +1,126 @@
+package stackmachine
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// OpEncoder encodes stack machine operations, in the format read by
+// OpDecoder. It is used to assemble programs for tests and dry runs.
+type OpEncoder struct {
+	opBuf []byte
+}
+
+// PC returns the program counter of the next operation to be encoded.
+func (e *OpEncoder) PC() uint32 {
+	return uint32(len(e.opBuf))
+}
+
+// Bytes returns the encoded program.
+func (e *OpEncoder) Bytes() []byte {
+	return e.opBuf
+}
+
+// Encode appends an operation, one of the Op* structs, to the program and
+// returns its program counter. The operands are encoded in the order of the
+// struct's fields.
+func (e *OpEncoder) Encode(op any) uint32 {
+	code, ok := opCodeOf(op)
+	if !ok {
+		panic(fmt.Sprintf("stackmachine: cannot encode %T", op))
+	}
+	pc := e.PC()
+	e.opBuf = append(e.opBuf, byte(code))
+	buf, err := binary.Append(e.opBuf, binary.LittleEndian, op)
+	if err != nil {
+		panic(fmt.Sprintf("stackmachine: cannot encode %T: %v", op, err))
+	}
+	e.opBuf = buf
+	return pc
+}
+
+// SetTarget sets the target of the OpCall, OpJump or OpCondJump at pc, which
+// allows jumping forward to operations that are not encoded yet.
+func (e *OpEncoder) SetTarget(pc uint32, target uint32) {
+	switch OpCode(e.opBuf[pc]) {
+	case OpCodeCall, OpCodeJump, OpCodeCondJump:
+		binary.LittleEndian.PutUint32(e.opBuf[pc+1:], target)
+	default:
+		panic(fmt.Sprintf("stackmachine: %s at pc %d has no target", OpCode(e.opBuf[pc]), pc))
+	}
+}
+
+func opCodeOf(op any) (OpCode, bool) {
+	switch op.(type) {
+	case OpCall:
+		return OpCodeCall, true
+	case OpCondJump:
+		return OpCodeCondJump, true
+	case OpDecrement:
+		return OpCodeDecrement, true
+	case OpEnqueueEmptyInterface:
+		return OpCodeEnqueueEmptyInterface, true
+	case OpEnqueueInterface:
+		return OpCodeEnqueueInterface, true
+	case OpEnqueuePointer:
+		return OpCodeEnqueuePointer, true
+	case OpEnqueueSliceHeader:
+		return OpCodeEnqueueSliceHeader, true
+	case OpEnqueueStringHeader:
+		return OpCodeEnqueueStringHeader, true
+	case OpEnqueueHMapHeader:
+		return OpCodeEnqueueHMapHeader, true
+	case OpEnqueueSwissMap:
+		return OpCodeEnqueueSwissMap, true
+	case OpEnqueueSwissMapGroups:
+		return OpCodeEnqueueSwissMapGroups, true
+	case OpEnqueueSubroutine:
+		return OpCodeEnqueueSubroutine, true
+	case OpJump:
+		return OpCodeJump, true
+	case OpPop:
+		return OpCodePop, true
+	case OpPushImm:
+		return OpCodePushImm, true
+	case OpPushOffset:
+		return OpCodePushOffset, true
+	case OpPushSliceLen:
+		return OpCodePushSliceLen, true
+	case OpReturn:
+		return OpCodeReturn, true
+	case OpSetOffset:
+		return OpCodeSetOffset, true
+	case OpAdvanceOffset:
+		return OpCodeAdvanceOffset, true
+	case OpDereferenceCFAOffset:
+		return OpCodeDereferenceCFAOffset, true
+	case OpCopyFromRegister:
+		return OpCodeCopyFromRegister, true
+	case OpSaveExprResult:
+		return OpCodeSaveExprResult, true
+	case OpDereferencePtr:
+		return OpCodeDereferencePtr, true
+	case OpZeroFill:
+		return OpCodeZeroFill, true
+	case OpSetPresenceBit:
+		return OpCodeSetPresenceBit, true
+	case OpPrepareFrameData:
+		return OpCodePrepareFrameData, true
+	case OpPrepareExprEval:
+		return OpCodePrepareExprEval, true
+	case OpPreparePointeeData:
+		return OpCodePreparePointeeData, true
+	case OpConcludeFrameData:
+		return OpCodeConcludeFrameData, true
+	case OpPrepareGoContext:
+		return OpCodePrepareGoContext, true
+	case OpTraverseGoContext:
+		return OpCodeTraverseGoContext, true
+	case OpConcludeGoContext:
+		return OpCodeConcludeGoContext, true
+	case OpIllegal:
+		return OpCodeIllegal, true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,47 @@
+package stackmachine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpEncoderRoundTrip(t *testing.T) {
+	var e OpEncoder
+	ops := []any{
+		OpPrepareFrameData{ProgID: 1, DataByteLen: 24, TypeID: 2},
+		OpDereferenceCFAOffset{Offset: -16, ByteLen: 24, PointerBias: 3},
+		OpEnqueueHMapHeader{
+			BucketsArrayType: 4, BucketByteLen: 144,
+			FlagsOffset: 8, BOffset: 9, BucketsOffset: 16, OldBucketsOffset: 24,
+		},
+		OpCopyFromRegister{Register: 7, ByteSize: 8},
+		OpPrepareGoContext{DataByteLen: 32, TypeID: 5, CaptureCount: 2},
+		OpCall{},
+		OpPreparePointeeData{},
+		OpReturn{},
+	}
+	var pcs []uint32
+	for _, op := range ops {
+		pcs = append(pcs, e.Encode(op))
+	}
+	e.SetTarget(pcs[5], pcs[7])
+
+	d := MakeOpDecoder(e.Bytes())
+	require.Equal(t, OpCodePrepareFrameData, d.PopOpCode())
+	require.Equal(t, ops[0], d.DecodePrepareFrameData())
+	require.Equal(t, OpCodeDereferenceCFAOffset, d.PopOpCode())
+	require.Equal(t, ops[1], d.DecodeDereferenceCFAOffset())
+	require.Equal(t, OpCodeEnqueueHMapHeader, d.PopOpCode())
+	require.Equal(t, ops[2], d.DecodeEnqueueHMapHeader())
+	require.Equal(t, OpCodeCopyFromRegister, d.PopOpCode())
+	require.Equal(t, ops[3], d.DecodeCopyFromRegister())
+	require.Equal(t, OpCodePrepareGoContext, d.PopOpCode())
+	require.Equal(t, ops[4], d.DecodePrepareGoContext())
+	require.Equal(t, OpCodeCall, d.PopOpCode())
+	require.Equal(t, OpCall{Pc: pcs[7]}, d.DecodeCall())
+	require.Equal(t, OpCodePreparePointeeData, d.PopOpCode())
+	require.Equal(t, pcs[7], d.PC())
+	require.Equal(t, OpCodeReturn, d.PopOpCode())
+	require.Equal(t, e.PC(), d.PC())
+}
@@ -0,0 +1,571 @@
+package stackmachine
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/DataExMachina-dev/side-eye-go/internal/snapshotpb"
+)
+
+// MaxStackDepth bounds the number of entries, values and return addresses
+// alike, on the stack of the stack machine while it runs a verified program.
+const MaxStackDepth = 64
+
+// operandLen is the length of the operands of the opcodes that the stack
+// machine executes. Opcodes that are not in the map are rejected by Verify.
+var operandLen = map[OpCode]uint32{
+	OpCodeCall:                  4,
+	OpCodeCondJump:              4,
+	OpCodeDecrement:             0,
+	OpCodeEnqueueEmptyInterface: 0,
+	OpCodeEnqueueInterface:      0,
+	OpCodeEnqueuePointer:        4,
+	OpCodeEnqueueSliceHeader:    8,
+	OpCodeEnqueueStringHeader:   4,
+	OpCodeEnqueueHMapHeader:     12,
+	OpCodeEnqueueSwissMap:       10,
+	OpCodeEnqueueSwissMapGroups: 10,
+	OpCodeEnqueueSubroutine:     0,
+	OpCodeJump:                  4,
+	OpCodePop:                   0,
+	OpCodePushImm:               4,
+	OpCodePushOffset:            0,
+	OpCodePushSliceLen:          4,
+	OpCodeReturn:                0,
+	OpCodeSetOffset:             0,
+	OpCodeAdvanceOffset:         4,
+	OpCodeDereferenceCFAOffset:  12,
+	OpCodeCopyFromRegister:      3,
+	OpCodePrepareExprEval:       0,
+	OpCodeSaveExprResult:        8,
+	OpCodeDereferencePtr:        8,
+	OpCodeZeroFill:              4,
+	OpCodeSetPresenceBit:        4,
+	OpCodePreparePointeeData:    0,
+	OpCodePrepareFrameData:      12,
+	OpCodeConcludeFrameData:     0,
+	OpCodePrepareGoContext:      9,
+	OpCodeTraverseGoContext:     0,
+	OpCodeConcludeGoContext:     0,
+}
+
+// Verify checks that the bytecode of a snapshot program can be executed
+// safely by the stack machine. Starting from the programs of the frames in the
+// PcClassifier and of the types in TypeInfo, it checks that:
+//   - every reachable instruction has a known opcode and fits in the program,
+//     and instructions do not overlap;
+//   - jumps and calls target instructions, and execution cannot run off the
+//     end of the program;
+//   - the stack cannot underflow, has the same depth on all the paths to an
+//     instruction, is empty on return, and stays within MaxStackDepth;
+//   - SetOffset only restores offsets saved by PushOffset;
+//   - the data accessed at statically known offsets lies within the frame or
+//     type being serialized, as given by PrepareFrameData or TypeInfo.ByteLen.
+//
+// Verify also checks the consistency of the classifiers that the snapshotter
+// uses to find the programs.
+func Verify(p *snapshotpb.SnapshotProgram) error {
+	if p.PcClassifier == nil {
+		return fmt.Errorf("missing pc classifier")
+	}
+	if err := verifyClassifier("pc", p.PcClassifier.TargetPc, len(p.PcClassifier.ProgPc)); err != nil {
+		return err
+	}
+	if sc := p.SubroutineClassifier; sc != nil {
+		if err := verifyClassifier("subroutine", sc.EntryPc, len(sc.Type)); err != nil {
+			return err
+		}
+	}
+	v := verifier{
+		p:     p,
+		owner: make([]int32, len(p.Prog)),
+		funcs: make(map[funcKey]*funcResult),
+	}
+	for i := range v.owner {
+		v.owner[i] = -1
+	}
+	for i, pc := range p.PcClassifier.ProgPc {
+		if pc == 0 {
+			continue
+		}
+		if err := v.verifyEntry(funcKey{pc: pc}); err != nil {
+			return fmt.Errorf("program of frame at pc %#x: %w", p.PcClassifier.TargetPc[i], err)
+		}
+	}
+	typeIDs := make([]uint32, 0, len(p.TypeInfo))
+	for t := range p.TypeInfo {
+		typeIDs = append(typeIDs, t)
+	}
+	// Verify in a deterministic order, so that the same error is reported for
+	// the same program.
+	slices.Sort(typeIDs)
+	for _, t := range typeIDs {
+		ti := p.TypeInfo[t]
+		if ti.EnqueuePc == 0 {
+			continue
+		}
+		k := funcKey{pc: ti.EnqueuePc, pointee: known(ti.ByteLen), serialized: ti.SerializeBeforeEnqueue}
+		if ti.ByteLen == 0 {
+			k.pointee = absValue{}
+		}
+		if err := v.verifyEntry(k); err != nil {
+			return fmt.Errorf("program of type %d: %w", t, err)
+		}
+	}
+	return nil
+}
+
+func verifyClassifier(name string, pcs []uint64, n int) error {
+	if len(pcs) != n {
+		return fmt.Errorf("%s classifier has %d pcs and %d values", name, len(pcs), n)
+	}
+	if !slices.IsSorted(pcs) {
+		return fmt.Errorf("%s classifier pcs are not sorted", name)
+	}
+	return nil
+}
+
+// absValue is a value known when the program is verified, or unknown.
+type absValue struct {
+	v     uint32
+	known bool
+}
+
+func known(v uint32) absValue {
+	return absValue{v: v, known: true}
+}
+
+func (a absValue) join(b absValue) absValue {
+	if a == b {
+		return a
+	}
+	return absValue{}
+}
+
+// slot is an entry of the stack. Offsets pushed by PushOffset remember the
+// region they point into, so that SetOffset can restore it.
+type slot struct {
+	isOffset       bool
+	offset, region absValue
+}
+
+func (s slot) join(o slot) slot {
+	if s.isOffset != o.isOffset {
+		return slot{}
+	}
+	return slot{
+		isOffset: s.isOffset,
+		offset:   s.offset.join(o.offset),
+		region:   s.region.join(o.region),
+	}
+}
+
+// state is the abstract state of the stack machine before an instruction.
+type state struct {
+	stack []slot
+	// offset is the offset of the stack machine relative to the start of the
+	// region of data, of length region, that it points into.
+	offset, region absValue
+	// results is the length of the frame or pointee data being serialized.
+	results absValue
+}
+
+// join merges o into s, returning whether s changed.
+func (s *state) join(o *state) (changed bool, _ error) {
+	if len(s.stack) != len(o.stack) {
+		return false, fmt.Errorf("stack depth is %d or %d depending on the path", len(s.stack), len(o.stack))
+	}
+	j := state{
+		stack:   make([]slot, len(s.stack)),
+		offset:  s.offset.join(o.offset),
+		region:  s.region.join(o.region),
+		results: s.results.join(o.results),
+	}
+	for i := range s.stack {
+		j.stack[i] = s.stack[i].join(o.stack[i])
+	}
+	changed = j.offset != s.offset || j.region != s.region || j.results != s.results ||
+		!slices.Equal(j.stack, s.stack)
+	*s = j
+	return changed, nil
+}
+
+func (s *state) clone() *state {
+	c := *s
+	c.stack = slices.Clone(s.stack)
+	return &c
+}
+
+// forgetOffset marks the offset and the region it points into as unknown.
+func (s *state) forgetOffset() {
+	s.offset, s.region = absValue{}, absValue{}
+}
+
+// funcKey identifies a function of the program: an entry point or the target
+// of a call, along with what is known about its initial state.
+type funcKey struct {
+	pc uint32
+	// pointee is the length of the data of the type whose program this is,
+	// and serialized is whether the data is serialized before the program
+	// runs.
+	pointee    absValue
+	serialized bool
+}
+
+type funcResult struct {
+	// done is false while the function is being verified, to detect
+	// recursion.
+	done bool
+	// maxDepth is the maximum depth of the stack while the function runs,
+	// including the functions it calls.
+	maxDepth int
+}
+
+type verifier struct {
+	p *snapshotpb.SnapshotProgram
+	// owner maps every byte of the program that belongs to a decoded
+	// instruction to the pc of that instruction, or -1.
+	owner []int32
+	funcs map[funcKey]*funcResult
+}
+
+func (v *verifier) verifyEntry(k funcKey) error {
+	r, err := v.verifyFunc(k)
+	if err != nil {
+		return err
+	}
+	if r.maxDepth > MaxStackDepth {
+		return fmt.Errorf("stack depth %d exceeds the maximum of %d", r.maxDepth, MaxStackDepth)
+	}
+	return nil
+}
+
+// inst is a decoded instruction.
+type inst struct {
+	code OpCode
+	// next is the pc of the following instruction.
+	next uint32
+	d    OpDecoder
+}
+
+// decode decodes the instruction at pc, checking that it is valid and does not
+// overlap with other instructions.
+func (v *verifier) decode(pc uint32) (inst, error) {
+	prog := v.p.Prog
+	if pc >= uint32(len(prog)) {
+		return inst{}, fmt.Errorf("pc %d is beyond the end of the program (%d bytes)", pc, len(prog))
+	}
+	code := OpCode(prog[pc])
+	n, ok := operandLen[code]
+	if !ok {
+		return inst{}, fmt.Errorf("pc %d: invalid opcode %s", pc, code)
+	}
+	next := uint64(pc) + 1 + uint64(n)
+	if next > uint64(len(prog)) {
+		return inst{}, fmt.Errorf("pc %d: %s is truncated by the end of the program", pc, code)
+	}
+	if o := v.owner[pc]; o != int32(pc) {
+		for i := uint64(pc); i < next; i++ {
+			if v.owner[i] != -1 {
+				return inst{}, fmt.Errorf("pc %d: %s overlaps the instruction at pc %d", pc, code, v.owner[i])
+			}
+		}
+		for i := uint64(pc); i < next; i++ {
+			v.owner[i] = int32(pc)
+		}
+	}
+	d := MakeOpDecoder(prog)
+	d.SetPC(pc)
+	d.PopOpCode()
+	return inst{code: code, next: uint32(next), d: d}, nil
+}
+
+func (v *verifier) verifyFunc(k funcKey) (*funcResult, error) {
+	if r, ok := v.funcs[k]; ok {
+		if !r.done {
+			return nil, fmt.Errorf("recursive call to pc %d", k.pc)
+		}
+		return r, nil
+	}
+	r := &funcResult{}
+	v.funcs[k] = r
+
+	entry := &state{}
+	if k.serialized {
+		entry.offset, entry.region, entry.results = known(0), k.pointee, k.pointee
+	}
+	states := map[uint32]*state{k.pc: entry}
+	work := []uint32{k.pc}
+	flow := func(pc uint32, s *state) error {
+		if pc >= uint32(len(v.p.Prog)) {
+			return fmt.Errorf("execution continues at pc %d, beyond the end of the program", pc)
+		}
+		if len(s.stack) > r.maxDepth {
+			r.maxDepth = len(s.stack)
+		}
+		existing, ok := states[pc]
+		if !ok {
+			states[pc] = s
+			work = append(work, pc)
+			return nil
+		}
+		changed, err := existing.join(s)
+		if err != nil {
+			return fmt.Errorf("at pc %d: %w", pc, err)
+		}
+		if changed {
+			work = append(work, pc)
+		}
+		return nil
+	}
+	for len(work) > 0 {
+		pc := work[len(work)-1]
+		work = work[:len(work)-1]
+		in, err := v.decode(pc)
+		if err != nil {
+			return nil, err
+		}
+		s := states[pc].clone()
+		succs, err := v.step(k, pc, in, s, r)
+		if err != nil {
+			return nil, fmt.Errorf("pc %d: %s: %w", pc, in.code, err)
+		}
+		for _, succ := range succs {
+			if err := flow(succ, s.clone()); err != nil {
+				return nil, err
+			}
+		}
+	}
+	r.done = true
+	return r, nil
+}
+
+// step applies the instruction at pc to s and returns its successors.
+func (v *verifier) step(k funcKey, pc uint32, in inst, s *state, r *funcResult) ([]uint32, error) {
+	need := func(n int) error {
+		if len(s.stack) < n {
+			return fmt.Errorf("needs %d stack entries, has %d", n, len(s.stack))
+		}
+		return nil
+	}
+	// access checks that size bytes at the offset lie within the region.
+	access := func(size uint32) error {
+		if s.offset.known && s.region.known && uint64(s.offset.v)+uint64(size) > uint64(s.region.v) {
+			return fmt.Errorf(
+				"accesses %d bytes at offset %d of data of %d bytes", size, s.offset.v, s.region.v,
+			)
+		}
+		return nil
+	}
+	d := &in.d
+	switch in.code {
+	case OpCodeCall:
+		op := d.DecodeCall()
+		if op.Pc >= uint32(len(v.p.Prog)) {
+			return nil, fmt.Errorf("call target %d is out of bounds", op.Pc)
+		}
+		callee, err := v.verifyFunc(funcKey{pc: op.Pc})
+		if err != nil {
+			return nil, fmt.Errorf("in call to pc %d: %w", op.Pc, err)
+		}
+		if d := len(s.stack) + 1 + callee.maxDepth; d > r.maxDepth {
+			r.maxDepth = d
+		}
+		// The callee cannot access the caller's entries of the stack, but it
+		// may move the offset.
+		s.forgetOffset()
+		s.results = absValue{}
+		return []uint32{in.next}, nil
+
+	case OpCodeCondJump:
+		op := d.DecodeCondJump()
+		if err := need(1); err != nil {
+			return nil, err
+		}
+		return []uint32{in.next, op.Pc}, nil
+
+	case OpCodeJump:
+		op := d.DecodeJump()
+		return []uint32{op.Pc}, nil
+
+	case OpCodeReturn:
+		if len(s.stack) != 0 {
+			return nil, fmt.Errorf("returns with %d entries on the stack", len(s.stack))
+		}
+		return nil, nil
+
+	case OpCodeDecrement:
+		if err := need(1); err != nil {
+			return nil, err
+		}
+		s.stack[len(s.stack)-1] = slot{}
+
+	case OpCodePop:
+		if err := need(1); err != nil {
+			return nil, err
+		}
+		s.stack = s.stack[:len(s.stack)-1]
+
+	case OpCodePushImm:
+		_ = d.DecodePushImm()
+		s.stack = append(s.stack, slot{})
+
+	case OpCodePushOffset:
+		s.stack = append(s.stack, slot{isOffset: true, offset: s.offset, region: s.region})
+
+	case OpCodePushSliceLen:
+		op := d.DecodePushSliceLen()
+		if op.ElemByteLen == 0 {
+			return nil, fmt.Errorf("element length is zero")
+		}
+		s.stack = append(s.stack, slot{})
+
+	case OpCodeSetOffset:
+		if err := need(1); err != nil {
+			return nil, err
+		}
+		top := s.stack[len(s.stack)-1]
+		if !top.isOffset {
+			return nil, fmt.Errorf("top of the stack is not an offset pushed by PushOffset")
+		}
+		s.offset, s.region = top.offset, top.region
+
+	case OpCodeAdvanceOffset:
+		op := d.DecodeAdvanceOffset()
+		if s.offset.known {
+			next := uint64(s.offset.v) + uint64(op.Increment)
+			if s.region.known && next > uint64(s.region.v) {
+				return nil, fmt.Errorf(
+					"advances offset %d by %d beyond data of %d bytes", s.offset.v, op.Increment, s.region.v,
+				)
+			}
+			s.offset = known(uint32(next))
+		}
+
+	case OpCodeEnqueueEmptyInterface, OpCodeEnqueueInterface, OpCodeEnqueueSliceHeader:
+		if in.code == OpCodeEnqueueSliceHeader {
+			_ = d.DecodeEnqueueSliceHeader()
+		}
+		if err := access(16); err != nil {
+			return nil, err
+		}
+
+	case OpCodeEnqueueStringHeader:
+		_ = d.DecodeEnqueueStringHeader()
+		if err := access(16); err != nil {
+			return nil, err
+		}
+
+	case OpCodeEnqueuePointer:
+		op := d.DecodeEnqueuePointer()
+		if op.ElemType == 0 {
+			return nil, fmt.Errorf("element type is zero")
+		}
+		if err := access(8); err != nil {
+			return nil, err
+		}
+
+	case OpCodeEnqueueHMapHeader:
+		op := d.DecodeEnqueueHMapHeader()
+		if err := need(1); err != nil {
+			return nil, err
+		}
+		s.stack[len(s.stack)-1] = slot{}
+		size := max(
+			uint32(op.FlagsOffset)+1, uint32(op.BOffset)+1,
+			uint32(op.BucketsOffset)+8, uint32(op.OldBucketsOffset)+8,
+		)
+		if err := access(size); err != nil {
+			return nil, err
+		}
+
+	case OpCodeEnqueueSwissMap:
+		op := d.DecodeEnqueueSwissMap()
+		if err := access(max(uint32(op.DirPtrOffset), uint32(op.DirLenOffset)) + 8); err != nil {
+			return nil, err
+		}
+
+	case OpCodeEnqueueSwissMapGroups:
+		op := d.DecodeEnqueueSwissMapGroups()
+		if err := access(max(uint32(op.DataOffset), uint32(op.LengthMaskOffset)) + 8); err != nil {
+			return nil, err
+		}
+
+	case OpCodeEnqueueSubroutine:
+		if v.p.SubroutineClassifier == nil {
+			return nil, fmt.Errorf("missing subroutine classifier")
+		}
+		if err := access(8); err != nil {
+			return nil, err
+		}
+
+	case OpCodeDereferenceCFAOffset:
+		op := d.DecodeDereferenceCFAOffset()
+		if err := access(op.ByteLen); err != nil {
+			return nil, err
+		}
+
+	case OpCodeCopyFromRegister:
+		op := d.DecodeCopyFromRegister()
+		if err := access(uint32(op.ByteSize)); err != nil {
+			return nil, err
+		}
+
+	case OpCodeZeroFill:
+		op := d.DecodeZeroFill()
+		if err := access(op.ByteLen); err != nil {
+			return nil, err
+		}
+
+	case OpCodePrepareExprEval:
+		// The expression is evaluated in scratch space past the end of the
+		// output.
+		s.forgetOffset()
+
+	case OpCodeSaveExprResult:
+		op := d.DecodeSaveExprResult()
+		end := uint64(op.ResultOffset) + uint64(op.ByteLen)
+		if s.results.known && end > uint64(s.results.v) {
+			return nil, fmt.Errorf(
+				"saves %d bytes at offset %d of data of %d bytes", op.ByteLen, op.ResultOffset, s.results.v,
+			)
+		}
+		s.offset, s.region = known(op.ResultOffset), s.results
+
+	case OpCodeDereferencePtr:
+		op := d.DecodeDereferencePtr()
+		// When the dereference fails, the stack machine returns.
+		if len(s.stack) != 0 {
+			return nil, fmt.Errorf("may return with %d entries on the stack", len(s.stack))
+		}
+		if err := access(8); err != nil {
+			return nil, err
+		}
+		s.offset, s.region = known(0), known(op.ByteLen)
+
+	case OpCodeSetPresenceBit:
+		op := d.DecodeSetPresenceBit()
+		if s.results.known && op.BitOffset/8 >= s.results.v {
+			return nil, fmt.Errorf("bit %d is beyond data of %d bytes", op.BitOffset, s.results.v)
+		}
+
+	case OpCodePreparePointeeData:
+		s.offset, s.region, s.results = known(0), k.pointee, k.pointee
+
+	case OpCodePrepareFrameData:
+		op := d.DecodePrepareFrameData()
+		s.offset, s.region, s.results = known(0), known(op.DataByteLen), known(op.DataByteLen)
+
+	case OpCodePrepareGoContext:
+		_ = d.DecodePrepareGoContext()
+		if err := access(16); err != nil {
+			return nil, err
+		}
+
+	case OpCodeConcludeFrameData, OpCodeTraverseGoContext, OpCodeConcludeGoContext:
+
+	default:
+		return nil, fmt.Errorf("unsupported opcode")
+	}
+	return []uint32{in.next}, nil
+}
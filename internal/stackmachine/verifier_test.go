@@ -0,0 +1,269 @@
+package stackmachine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataExMachina-dev/side-eye-go/internal/snapshotpb"
+)
+
+// frameProgram returns a snapshot program in which the bytecode at pc is the
+// program of a frame.
+func frameProgram(prog []byte, pc uint32) *snapshotpb.SnapshotProgram {
+	return &snapshotpb.SnapshotProgram{
+		PcClassifier: &snapshotpb.PcClassifier{
+			TargetPc: []uint64{0x1000},
+			ProgPc:   []uint32{pc},
+		},
+		Prog: prog,
+	}
+}
+
+func TestVerifyValid(t *testing.T) {
+	var e OpEncoder
+	// Leave pc 0 unused, as a program pc of 0 means no program.
+	e.Encode(OpIllegal{})
+
+	// A subroutine that captures a pointer at the current offset.
+	elem := e.Encode(OpEnqueuePointer{ElemType: 7})
+	e.Encode(OpReturn{})
+
+	// A frame of 32 bytes, holding an array of 3 pointers and a slice header
+	// computed by an expression.
+	frame := e.Encode(OpPrepareFrameData{ProgID: 1, DataByteLen: 40, TypeID: 2})
+	e.Encode(OpDereferenceCFAOffset{Offset: -8, ByteLen: 24})
+	e.Encode(OpPushOffset{})
+	e.Encode(OpPushImm{Value: 3})
+	loop := e.Encode(OpCall{Pc: elem})
+	e.Encode(OpAdvanceOffset{Increment: 8})
+	e.Encode(OpDecrement{})
+	e.Encode(OpCondJump{Pc: loop})
+	e.Encode(OpPop{})
+	e.Encode(OpSetOffset{})
+	e.Encode(OpPop{})
+	e.Encode(OpPrepareExprEval{})
+	e.Encode(OpDereferenceCFAOffset{Offset: -16, ByteLen: 8})
+	e.Encode(OpDereferencePtr{ByteLen: 16})
+	e.Encode(OpSaveExprResult{ResultOffset: 24, ByteLen: 16})
+	e.Encode(OpEnqueueSliceHeader{ArrayType: 8, ElemByteLen: 1})
+	e.Encode(OpSetPresenceBit{BitOffset: 39 * 8})
+	e.Encode(OpConcludeFrameData{})
+	e.Encode(OpReturn{})
+
+	// The program of a type of 16 bytes that is serialized before it is
+	// chased.
+	typeProg := e.Encode(OpEnqueueStringHeader{StringDataType: 9})
+	e.Encode(OpReturn{})
+
+	p := frameProgram(e.Bytes(), frame)
+	p.TypeInfo = map[uint32]*snapshotpb.TypeInfo{
+		8: {ByteLen: 16, EnqueuePc: typeProg, SerializeBeforeEnqueue: true},
+	}
+	require.NoError(t, Verify(p))
+}
+
+func TestVerifyInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		// build assembles the program and returns the pc of the frame's
+		// program.
+		build func(e *OpEncoder) (prog []byte, pc uint32)
+		err   string
+	}{
+		{
+			name: "unknown opcode",
+			build: func(e *OpEncoder) ([]byte, uint32) {
+				return append(e.Bytes(), 0xff), e.PC()
+			},
+			err: "invalid opcode",
+		},
+		{
+			name: "illegal",
+			build: func(e *OpEncoder) ([]byte, uint32) {
+				pc := e.Encode(OpIllegal{})
+				return e.Bytes(), pc
+			},
+			err: "invalid opcode OpCodeIllegal",
+		},
+		{
+			name: "truncated",
+			build: func(e *OpEncoder) ([]byte, uint32) {
+				pc := e.Encode(OpPushImm{Value: 1})
+				return e.Bytes()[:e.PC()-1], pc
+			},
+			err: "truncated",
+		},
+		{
+			name: "jump out of bounds",
+			build: func(e *OpEncoder) ([]byte, uint32) {
+				pc := e.Encode(OpJump{Pc: 1000})
+				return e.Bytes(), pc
+			},
+			err: "beyond the end of the program",
+		},
+		{
+			name: "jump into an instruction",
+			build: func(e *OpEncoder) ([]byte, uint32) {
+				pc := e.Encode(OpPushImm{Value: 1})
+				e.Encode(OpCondJump{Pc: pc + 1})
+				e.Encode(OpPop{})
+				e.Encode(OpReturn{})
+				return e.Bytes(), pc
+			},
+			err: "overlaps",
+		},
+		{
+			name: "runs off the end",
+			build: func(e *OpEncoder) ([]byte, uint32) {
+				pc := e.Encode(OpPrepareExprEval{})
+				return e.Bytes(), pc
+			},
+			err: "beyond the end of the program",
+		},
+		{
+			name: "stack underflow",
+			build: func(e *OpEncoder) ([]byte, uint32) {
+				pc := e.Encode(OpPop{})
+				e.Encode(OpReturn{})
+				return e.Bytes(), pc
+			},
+			err: "needs 1 stack entries, has 0",
+		},
+		{
+			name: "return with values on the stack",
+			build: func(e *OpEncoder) ([]byte, uint32) {
+				pc := e.Encode(OpPushImm{Value: 1})
+				e.Encode(OpReturn{})
+				return e.Bytes(), pc
+			},
+			err: "returns with 1 entries on the stack",
+		},
+		{
+			name: "inconsistent stack depth",
+			build: func(e *OpEncoder) ([]byte, uint32) {
+				pc := e.Encode(OpPushImm{Value: 1})
+				j := e.Encode(OpCondJump{})
+				e.Encode(OpPushImm{Value: 2})
+				e.SetTarget(j, e.Encode(OpPop{}))
+				e.Encode(OpReturn{})
+				return e.Bytes(), pc
+			},
+			err: "stack depth is",
+		},
+		{
+			name: "set offset from an immediate",
+			build: func(e *OpEncoder) ([]byte, uint32) {
+				pc := e.Encode(OpPushImm{Value: 1})
+				e.Encode(OpSetOffset{})
+				e.Encode(OpPop{})
+				e.Encode(OpReturn{})
+				return e.Bytes(), pc
+			},
+			err: "not an offset",
+		},
+		{
+			name: "access beyond the frame",
+			build: func(e *OpEncoder) ([]byte, uint32) {
+				pc := e.Encode(OpPrepareFrameData{ProgID: 1, DataByteLen: 16, TypeID: 2})
+				e.Encode(OpAdvanceOffset{Increment: 12})
+				e.Encode(OpEnqueuePointer{ElemType: 3})
+				e.Encode(OpReturn{})
+				return e.Bytes(), pc
+			},
+			err: "accesses 8 bytes at offset 12 of data of 16 bytes",
+		},
+		{
+			name: "save beyond the frame",
+			build: func(e *OpEncoder) ([]byte, uint32) {
+				pc := e.Encode(OpPrepareFrameData{ProgID: 1, DataByteLen: 16, TypeID: 2})
+				e.Encode(OpPrepareExprEval{})
+				e.Encode(OpSaveExprResult{ResultOffset: 8, ByteLen: 16})
+				e.Encode(OpReturn{})
+				return e.Bytes(), pc
+			},
+			err: "saves 16 bytes at offset 8 of data of 16 bytes",
+		},
+		{
+			name: "zero element length",
+			build: func(e *OpEncoder) ([]byte, uint32) {
+				pc := e.Encode(OpPushSliceLen{})
+				e.Encode(OpPop{})
+				e.Encode(OpReturn{})
+				return e.Bytes(), pc
+			},
+			err: "element length is zero",
+		},
+		{
+			name: "recursion",
+			build: func(e *OpEncoder) ([]byte, uint32) {
+				pc := e.PC()
+				e.Encode(OpCall{Pc: pc})
+				e.Encode(OpReturn{})
+				return e.Bytes(), pc
+			},
+			err: "recursive call",
+		},
+		{
+			name: "stack too deep",
+			build: func(e *OpEncoder) ([]byte, uint32) {
+				pc := e.PC()
+				for i := 0; i <= MaxStackDepth; i++ {
+					e.Encode(OpPushImm{Value: uint32(i)})
+				}
+				for i := 0; i <= MaxStackDepth; i++ {
+					e.Encode(OpPop{})
+				}
+				e.Encode(OpReturn{})
+				return e.Bytes(), pc
+			},
+			err: "exceeds the maximum",
+		},
+		{
+			name: "dereference with values on the stack",
+			build: func(e *OpEncoder) ([]byte, uint32) {
+				pc := e.Encode(OpPushImm{Value: 1})
+				e.Encode(OpDereferencePtr{ByteLen: 8})
+				e.Encode(OpPop{})
+				e.Encode(OpReturn{})
+				return e.Bytes(), pc
+			},
+			err: "may return with 1 entries on the stack",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var e OpEncoder
+			e.Encode(OpIllegal{})
+			prog, pc := tc.build(&e)
+			require.ErrorContains(t, Verify(frameProgram(prog, pc)), tc.err)
+		})
+	}
+}
+
+func TestVerifyPointeeBounds(t *testing.T) {
+	var e OpEncoder
+	e.Encode(OpIllegal{})
+	pc := e.Encode(OpPreparePointeeData{})
+	e.Encode(OpEnqueueSliceHeader{ArrayType: 8, ElemByteLen: 1})
+	e.Encode(OpReturn{})
+	p := &snapshotpb.SnapshotProgram{
+		PcClassifier: &snapshotpb.PcClassifier{},
+		TypeInfo: map[uint32]*snapshotpb.TypeInfo{
+			5: {ByteLen: 8, EnqueuePc: pc},
+		},
+		Prog: e.Bytes(),
+	}
+	require.ErrorContains(t, Verify(p), "program of type 5: pc 2: OpCodeEnqueueSliceHeader: accesses 16 bytes")
+	p.TypeInfo[5].ByteLen = 24
+	require.NoError(t, Verify(p))
+}
+
+func TestVerifyClassifiers(t *testing.T) {
+	p := &snapshotpb.SnapshotProgram{
+		PcClassifier: &snapshotpb.PcClassifier{TargetPc: []uint64{2, 1}, ProgPc: []uint32{0, 0}},
+	}
+	require.ErrorContains(t, Verify(p), "not sorted")
+	p.PcClassifier.TargetPc = []uint64{1}
+	require.ErrorContains(t, Verify(p), "has 1 pcs and 2 values")
+	require.ErrorContains(t, Verify(&snapshotpb.SnapshotProgram{}), "missing pc classifier")
+}
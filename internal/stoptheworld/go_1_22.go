@@ -1,4 +1,6 @@
-//go:build (go1.22 && !go1.23) || (go1.23 && !go1.24) || (go1.24 && !go1.25)
+// Contains logic for stopping the world in Go 1.22.
+
+//go:build go1.22 && !go1.23
 
 package stoptheworld
 
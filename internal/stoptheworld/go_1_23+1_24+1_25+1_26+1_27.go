@@ -0,0 +1,63 @@
+// Contains logic for stopping the world in Go 1.23 through 1.27.
+
+//go:build go1.23 && !go1.28
+
+package stoptheworld
+
+import (
+	"unsafe"
+)
+
+func GoVersionSupported() bool {
+	return true
+}
+
+var (
+	stopTheWorldImpl  stopTheWorldFunc
+	startTheWorldImpl startTheWorldFunc
+)
+
+func stopTheWorld(reason stwReason) worldStop {
+	fPtr := (*uintptr)(unsafe.Pointer(&stopTheWorldImpl))
+	*fPtr = (uintptr)(unsafe.Pointer(&state.stopTheWorldAddr))
+	return stopTheWorldImpl(reason)
+}
+
+func startTheWorld(ws worldStop) {
+	fPtr := (*uintptr)(unsafe.Pointer(&startTheWorldImpl))
+	*fPtr = (uintptr)(unsafe.Pointer(&state.startTheWorldAddr))
+	startTheWorldImpl(ws)
+}
+
+type stwReason int8
+
+// https://github.com/golang/go/blob/go1.25.5/src/runtime/proc.go#L1492-L1497
+type worldStop struct {
+	reason           stwReason
+	startedStopping  int64
+	finishedStopping int64
+	stoppingCPUTime  int64
+}
+
+type stopTheWorldFunc func(reason stwReason) worldStop
+type startTheWorldFunc func(ws worldStop)
+
+const (
+	stwUnknown                     stwReason = iota // "unknown"
+	stwGCMarkTerm                                   // "GC mark termination"
+	stwGCSweepTerm                                  // "GC sweep termination"
+	stwWriteHeapDump                                // "write heap dump"
+	stwGoroutineProfile                             // "goroutine profile"
+	stwGoroutineProfileCleanup                      // "goroutine profile cleanup"
+	stwAllGoroutinesStack                           // "all goroutines stack trace"
+	stwReadMemStats                                 // "read mem stats"
+	stwAllThreadsSyscall                            // "AllThreadsSyscall"
+	stwGOMAXPROCS                                   // "GOMAXPROCS"
+	stwStartTrace                                   // "start trace"
+	stwStopTrace                                    // "stop trace"
+	stwForTestCountPagesInUse                       // "CountPagesInUse (test)"
+	stwForTestReadMetricsSlow                       // "ReadMetricsSlow (test)"
+	stwForTestReadMemStatsSlow                      // "ReadMemStatsSlow (test)"
+	stwForTestPageCachePagesLeaked                  // "PageCachePagesLeaked (test)"
+	stwForTestResetDebugLog                         // "ResetDebugLog (test)"
+)
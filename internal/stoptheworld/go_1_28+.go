@@ -1,4 +1,7 @@
-//go:build go1.25
+// Go versions after 1.27 have not been validated; the runtime's stopTheWorld
+// and startTheWorld signatures may have changed.
+
+//go:build go1.28
 
 package stoptheworld
 
@@ -126,6 +126,12 @@ func sigsegvHandler()
 
 func setRecoveryState()
 
+// CurrentG returns the g of the goroutine that stopped the world. It is only
+// valid to call from within the function passed to StopTheWorld.
+func CurrentG() unsafe.Pointer {
+	return state.gPtr
+}
+
 func clearRecoveryState() {
 	state.recoveryFrameBaseOffset = 0
 	state.gPtr = nil
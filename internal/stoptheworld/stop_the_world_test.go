@@ -0,0 +1,71 @@
+package stoptheworld
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataExMachina-dev/side-eye-go/internal/testutil"
+)
+
+func TestStopTheWorld(t *testing.T) {
+	if err := PlatformSupported(); err != nil {
+		t.Skipf("platform not supported: %v", err)
+	}
+	cfg, err := testutil.StopTheWorldConfig()
+	require.NoError(t, err)
+
+	src := uint64(0xdeadbeef)
+	var dst uint64
+	var called, validOk, nilOk, unmappedOk bool
+	ok := StopTheWorld(cfg, func() {
+		called = true
+		validOk = Dereference(unsafe.Pointer(&dst), unsafe.Pointer(&src), 8)
+		nilOk = Dereference(unsafe.Pointer(&dst), nil, 8)
+		// The first pages of the address space are never mapped. The address
+		// is past the first page, which checkptr rejects as a pointer in race
+		// builds.
+		unmappedOk = Dereference(unsafe.Pointer(&dst), unsafe.Pointer(uintptr(0x1000)), 8)
+	})
+	require.True(t, ok)
+	require.True(t, called)
+	require.True(t, validOk)
+	require.Equal(t, src, dst)
+	require.False(t, nilOk)
+	require.False(t, unmappedOk)
+
+	// The world should be restarted and the handler removed, so other
+	// goroutines can run and we can stop the world again.
+	done := make(chan struct{})
+	go func() { close(done) }()
+	<-done
+	require.True(t, StopTheWorld(cfg, func() {}))
+}
+
+// TestStopTheWorldVersionMatrix runs TestStopTheWorld with each of the Go
+// toolchains listed in SIDE_EYE_TEST_GO_VERSIONS. The toolchains are obtained
+// through GOTOOLCHAIN, so they must be downloadable or already cached; the test
+// is skipped unless the variable is set. It should be run with the supported
+// Go versions whenever the version-specific files of this package change:
+//
+//	SIDE_EYE_TEST_GO_VERSIONS=go1.23.12,go1.24.13,go1.25.5,go1.26.0 \
+//		go test -run TestStopTheWorldVersionMatrix ./internal/stoptheworld
+func TestStopTheWorldVersionMatrix(t *testing.T) {
+	versions := os.Getenv("SIDE_EYE_TEST_GO_VERSIONS")
+	if versions == "" {
+		t.Skip("SIDE_EYE_TEST_GO_VERSIONS not set (e.g. go1.23.12,go1.24.13,go1.25.5,go1.26.0)")
+	}
+	for _, v := range strings.Split(versions, ",") {
+		v := strings.TrimSpace(v)
+		t.Run(v, func(t *testing.T) {
+			cmd := exec.Command("go", "test", "-count=1", "-run", "^TestStopTheWorld$", ".")
+			cmd.Env = append(os.Environ(), "GOTOOLCHAIN="+v)
+			out, err := cmd.CombinedOutput()
+			require.NoError(t, err, "%s", out)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+// Package testutil contains helpers for tests that stop the world.
+package testutil
+
+import (
+	"debug/elf"
+	"debug/gosym"
+	"debug/macho"
+	"fmt"
+	"os"
+
+	"github.com/DataExMachina-dev/side-eye-go/internal/snapshotpb"
+)
+
+// StopTheWorldConfig populates the parts of the runtime config that
+// stoptheworld.StopTheWorld relies on from the current executable's pclntab,
+// which, unlike the symbol table and DWARF, is present in binaries built by go
+// test.
+func StopTheWorldConfig() (*snapshotpb.RuntimeConfig, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+	tab, err := readLineTable(exe)
+	if err != nil {
+		return nil, err
+	}
+	var missing error
+	lookup := func(name string) *gosym.Func {
+		f := tab.LookupFunc(name)
+		if f == nil {
+			missing = fmt.Errorf("function %s not found", name)
+			return &gosym.Func{}
+		}
+		return f
+	}
+	deref := lookup("github.com/DataExMachina-dev/side-eye-go/internal/stoptheworld.Dereference")
+	cfg := &snapshotpb.RuntimeConfig{
+		DereferenceStartPc:     deref.Entry,
+		DereferenceEndPc:       deref.End,
+		StopTheWorldStartAddr:  lookup("runtime.stopTheWorld").Entry,
+		StartTheWorldStartAddr: lookup("runtime.startTheWorld").Entry,
+	}
+	if missing != nil {
+		return nil, missing
+	}
+	return cfg, nil
+}
+
+func readLineTable(exe string) (*gosym.Table, error) {
+	var pclntab []byte
+	var textStart uint64
+	if f, err := elf.Open(exe); err == nil {
+		defer f.Close()
+		pcln, text := f.Section(".gopclntab"), f.Section(".text")
+		if pcln == nil || text == nil {
+			return nil, fmt.Errorf("missing .gopclntab or .text section")
+		}
+		if pclntab, err = pcln.Data(); err != nil {
+			return nil, err
+		}
+		textStart = text.Addr
+	} else if f, err := macho.Open(exe); err == nil {
+		defer f.Close()
+		pcln, text := f.Section("__gopclntab"), f.Section("__text")
+		if pcln == nil || text == nil {
+			return nil, fmt.Errorf("missing __gopclntab or __text section")
+		}
+		if pclntab, err = pcln.Data(); err != nil {
+			return nil, err
+		}
+		textStart = text.Addr
+	} else {
+		return nil, fmt.Errorf("unrecognized executable format")
+	}
+	return gosym.NewTable(nil, gosym.NewLineTable(pclntab, textStart))
+}
@@ -0,0 +1,130 @@
+package sideeye
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/DataExMachina-dev/side-eye-go/internal/dwarfprogram"
+	"github.com/DataExMachina-dev/side-eye-go/internal/framing"
+	"github.com/DataExMachina-dev/side-eye-go/internal/machinapb"
+	"github.com/DataExMachina-dev/side-eye-go/internal/snapshot"
+	"github.com/DataExMachina-dev/side-eye-go/internal/snapshotpb"
+	"github.com/DataExMachina-dev/side-eye-go/internal/stackmachine"
+)
+
+// SnapshotProgram describes the data captured by a snapshot of a specific
+// binary: the layout of the runtime's data structures, the variables of
+// interest and how to serialize them.
+type SnapshotProgram = snapshotpb.SnapshotProgram
+
+// SnapshotResponse is the result of a snapshot. Its Data can be decoded with
+// the snapshotdata package.
+type SnapshotResponse = machinapb.SnapshotResponse
+
+// LoadSnapshotProgram reads a SnapshotProgram, encoded in the protobuf binary
+// format, from a file, and verifies that its bytecode is safe to execute.
+func LoadSnapshotProgram(path string) (*SnapshotProgram, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	p := &SnapshotProgram{}
+	if err := proto.Unmarshal(buf, p); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot program %s: %w", path, err)
+	}
+	if err := stackmachine.Verify(p); err != nil {
+		return nil, fmt.Errorf("invalid snapshot program %s: %w", path, err)
+	}
+	return p, nil
+}
+
+// CompileStackOnlyProgram compiles a snapshot program for the current process
+// from the DWARF debug information of its executable. The program captures the
+// stacks of all goroutines, but no variables. The executable must not be
+// stripped of its DWARF, e.g. with -ldflags=-w.
+func CompileStackOnlyProgram() (*SnapshotProgram, error) {
+	return dwarfprogram.CompileSelf()
+}
+
+// CaptureLocalSnapshot captures a snapshot of the current process with the
+// given program, without contacting the Side-Eye service. The program must
+// have been compiled for the binary of the current process. Its bytecode is
+// verified to be safe to execute before the world is stopped.
+//
+// Of the options, only those that configure snapshots, such as
+// WithMaxSnapshotSize, WithMaxSnapshotPause and WithSnapshotTrace, have an
+// effect.
+func CaptureLocalSnapshot(
+	ctx context.Context, program *SnapshotProgram, opts ...Option,
+) (*SnapshotResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if err := stackmachine.Verify(program); err != nil {
+		return nil, fmt.Errorf("invalid snapshot program: %w", err)
+	}
+	return captureLocalSnapshot(program, opts...)
+}
+
+// localArena is the snapshot.Arena of the snapshots captured locally. It is
+// retained across them, so that it grows to fit the snapshots of the process.
+var localArena struct {
+	mu    sync.Mutex
+	arena *snapshot.Arena
+}
+
+// captureLocalSnapshot captures a snapshot of the current process with a
+// program that has already been verified.
+func captureLocalSnapshot(program *SnapshotProgram, opts ...Option) (*SnapshotResponse, error) {
+	cfg := makeConfig("" /* programName */, opts...)
+	localArena.mu.Lock()
+	defer localArena.mu.Unlock()
+	if localArena.arena == nil {
+		localArena.arena = snapshot.NewArena()
+	}
+	res, err := snapshot.Snapshot(program, snapshot.Options{
+		MaxBytes:      cfg.MaxSnapshotBytes,
+		MaxPause:      cfg.MaxSnapshotPause,
+		MaxStackPause: cfg.MaxSnapshotStackPause,
+		Trace:         cfg.TraceSnapshots,
+		Arena:         localArena.arena,
+		// The data is decoded by the snapshotdata package of this module.
+		FramingVersion: framing.CurrentVersion,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// The data aliases the arena, which the next snapshot reuses.
+	res.Data = bytes.Clone(res.Data)
+	return res, nil
+}
+
+// CaptureLocalSnapshotToFile is like CaptureLocalSnapshot, but it reads the
+// program from programPath, as LoadSnapshotProgram does, and writes the
+// SnapshotResponse, encoded in the protobuf binary format, to outputPath.
+func CaptureLocalSnapshotToFile(
+	ctx context.Context, programPath string, outputPath string, opts ...Option,
+) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	// LoadSnapshotProgram verifies the program.
+	program, err := LoadSnapshotProgram(programPath)
+	if err != nil {
+		return err
+	}
+	res, err := captureLocalSnapshot(program, opts...)
+	if err != nil {
+		return err
+	}
+	buf, err := proto.Marshal(res)
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	return os.WriteFile(outputPath, buf, 0o644)
+}
@@ -0,0 +1,89 @@
+package sideeye_test
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/DataExMachina-dev/side-eye-go/internal/snapshotpb"
+	"github.com/DataExMachina-dev/side-eye-go/internal/stackmachine"
+	"github.com/DataExMachina-dev/side-eye-go/internal/stoptheworld"
+	"github.com/DataExMachina-dev/side-eye-go/sideeye"
+)
+
+func TestLoadSnapshotProgram(t *testing.T) {
+	dir := t.TempDir()
+	p := &sideeye.SnapshotProgram{
+		PcClassifier: &snapshotpb.PcClassifier{},
+		ModuleNames:  []string{"plugin"},
+	}
+	buf, err := proto.Marshal(p)
+	require.NoError(t, err)
+	path := filepath.Join(dir, "program.pb")
+	require.NoError(t, os.WriteFile(path, buf, 0o644))
+
+	loaded, err := sideeye.LoadSnapshotProgram(path)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(p, loaded))
+
+	badPath := filepath.Join(dir, "bad.pb")
+	require.NoError(t, os.WriteFile(badPath, []byte{0xff}, 0o644))
+	_, err = sideeye.LoadSnapshotProgram(badPath)
+	require.ErrorContains(t, err, "failed to decode snapshot program")
+
+	// Programs with invalid bytecode are rejected.
+	p.PcClassifier = &snapshotpb.PcClassifier{TargetPc: []uint64{0x1000}, ProgPc: []uint32{1}}
+	p.Prog = []byte{byte(stackmachine.OpCodeIllegal), byte(stackmachine.OpCodeIllegal)}
+	buf, err = proto.Marshal(p)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, buf, 0o644))
+	_, err = sideeye.LoadSnapshotProgram(path)
+	require.ErrorContains(t, err, "invalid snapshot program")
+}
+
+func TestCaptureLocalSnapshotErrors(t *testing.T) {
+	p := &sideeye.SnapshotProgram{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := sideeye.CaptureLocalSnapshot(ctx, p)
+	require.ErrorIs(t, err, context.Canceled)
+
+	// Programs with invalid bytecode are rejected before they run.
+	invalid := &sideeye.SnapshotProgram{
+		PcClassifier: &snapshotpb.PcClassifier{TargetPc: []uint64{0x1000}, ProgPc: []uint32{1}},
+		Prog:         []byte{byte(stackmachine.OpCodeIllegal), byte(stackmachine.OpCodeIllegal)},
+	}
+	_, err = sideeye.CaptureLocalSnapshot(context.Background(), invalid)
+	require.ErrorContains(t, err, "invalid snapshot program")
+
+	// A program without a runtime config cannot be used; no output file
+	// should be written.
+	dir := t.TempDir()
+	programPath := filepath.Join(dir, "program.pb")
+	require.NoError(t, os.WriteFile(programPath, nil, 0o644))
+	outputPath := filepath.Join(dir, "snapshot.pb")
+	err = sideeye.CaptureLocalSnapshotToFile(context.Background(), programPath, outputPath)
+	require.Error(t, err)
+	require.NoFileExists(t, outputPath)
+}
+
+// TestCaptureLocalSnapshot runs a program that captures a snapshot of itself
+// with CaptureLocalSnapshot. It is built separately, as go test strips the
+// DWARF of test binaries, which CompileStackOnlyProgram needs.
+func TestCaptureLocalSnapshot(t *testing.T) {
+	if err := stoptheworld.PlatformSupported(); err != nil {
+		t.Skipf("platform not supported: %v", err)
+	}
+	exe := filepath.Join(t.TempDir(), "localsnapshot")
+	out, err := exec.Command("go", "build", "-o", exe, "./testdata/localsnapshot").CombinedOutput()
+	require.NoError(t, err, "%s", out)
+	out, err = exec.Command(exe).CombinedOutput()
+	require.NoError(t, err, "%s", out)
+	t.Logf("%s", out)
+}
@@ -5,8 +5,11 @@ package sideeye
 import (
 	"context"
 	"fmt"
+	"time"
+
 	"github.com/DataExMachina-dev/side-eye-go/internal/apiclient"
 	"github.com/DataExMachina-dev/side-eye-go/internal/apipb"
+	"github.com/DataExMachina-dev/side-eye-go/internal/flightrecorder"
 	"github.com/DataExMachina-dev/side-eye-go/internal/sideeyeconn"
 	"github.com/DataExMachina-dev/side-eye-go/internal/stoptheworld"
 )
@@ -62,6 +65,68 @@ func WithProgramName(programName string) Option {
 	})
 }
 
+// WithMaxSnapshotSize sets the upper bound, in bytes, on the size of the data
+// captured by a snapshot of this process. Snapshots that would exceed it are
+// truncated. A buffer of this size is allocated by the first snapshot and
+// reused by the following ones. Defaults to 64 MiB.
+func WithMaxSnapshotSize(bytes uint32) Option {
+	return optionFunc(func(cfg *sideeyeconn.Config) {
+		cfg.MaxSnapshotBytes = bytes
+	})
+}
+
+// WithMaxSnapshotPause bounds the time for which a snapshot pauses this
+// process. Once the pause reaches the bound, the capture stops, the process is
+// resumed, and the snapshot is marked as partial. Capturing goroutine stacks is
+// limited to stackPause, a part of maxPause, so that some of the pause is left
+// for capturing the data they point to; if zero, it defaults to half of
+// maxPause. By default, the pause is not bounded.
+func WithMaxSnapshotPause(maxPause, stackPause time.Duration) Option {
+	return optionFunc(func(cfg *sideeyeconn.Config) {
+		cfg.MaxSnapshotPause = maxPause
+		cfg.MaxSnapshotStackPause = stackPause
+	})
+}
+
+// WithSnapshotTrace enables the tracing of the execution of snapshot programs
+// in every snapshot of this process. The trace, returned along with the
+// snapshot, records the operations executed, the memory that could not be
+// read, and the pointers and types that were not followed, which helps
+// understand why a snapshot is missing data. Tracing slows snapshots down, and
+// is meant for debugging.
+func WithSnapshotTrace() Option {
+	return optionFunc(func(cfg *sideeyeconn.Config) {
+		cfg.TraceSnapshots = true
+	})
+}
+
+// WithBlockProfileRate informs Side-Eye of the rate that the program passes to
+// runtime.SetBlockProfileRate, or 0 if the program does not enable block
+// profiling. Capturing a block profile over a duration through Side-Eye raises
+// the rate for the duration of the capture; as the runtime does not expose the
+// current rate, it is restored to this value afterwards. Without this option,
+// such captures fail rather than turn off the program's block profiling.
+func WithBlockProfileRate(rate int) Option {
+	return optionFunc(func(cfg *sideeyeconn.Config) {
+		cfg.BlockProfileRate = &rate
+	})
+}
+
+// WithFlightRecorder enables the flight recorder, which keeps the execution
+// trace of the most recent minAge of this process in memory, bounded by
+// maxBytes. Side-Eye can dump the flight recorder at any time, to look at what
+// led to something interesting after it happened. Zero values select the
+// defaults of 10s and 16 MiB.
+//
+// Before go1.25, the flight recorder occupies the runtime's execution tracer:
+// while it runs, execution traces cannot be captured through Side-Eye or
+// runtime/trace.
+func WithFlightRecorder(minAge time.Duration, maxBytes uint64) Option {
+	return optionFunc(func(cfg *sideeyeconn.Config) {
+		cfg.FlightRecorder = &flightrecorder.Config{MinAge: minAge, MaxBytes: maxBytes}
+	})
+}
+
 // WithErrorLogger sets a function to be called with errors (for example for
 // logging them).
 func WithErrorLogger(f func(err error)) Option {
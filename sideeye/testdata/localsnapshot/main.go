@@ -0,0 +1,76 @@
+// Command localsnapshot captures snapshots of itself with a stack-only
+// program, and checks that the first snapshot contains a goroutine blocked in
+// a known function.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+
+	"github.com/DataExMachina-dev/side-eye-go/sideeye"
+	"github.com/DataExMachina-dev/side-eye-go/snapshotdata"
+)
+
+//go:noinline
+func parked(ch chan struct{}, started chan struct{}) {
+	close(started)
+	<-ch
+}
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	ch, started := make(chan struct{}), make(chan struct{})
+	go parked(ch, started)
+	<-started
+	defer close(ch)
+
+	p, err := sideeye.CompileStackOnlyProgram()
+	if err != nil {
+		return err
+	}
+	res, err := sideeye.CaptureLocalSnapshot(context.Background(), p)
+	if err != nil {
+		return err
+	}
+	// The snapshots share their arena; a second snapshot must not overwrite
+	// the data of the first.
+	if _, err := sideeye.CaptureLocalSnapshot(context.Background(), p); err != nil {
+		return err
+	}
+	s, err := snapshotdata.Decode(res.Data)
+	if err != nil {
+		return err
+	}
+	for _, g := range s.Goroutines {
+		frames := runtime.CallersFrames(pcs(g.Stack, res.BssAddrShift))
+		for {
+			f, more := frames.Next()
+			if f.Function == "main.parked" {
+				fmt.Printf("found main.parked in goroutine %d of %d\n", g.Goid, len(s.Goroutines))
+				return nil
+			}
+			if !more {
+				break
+			}
+		}
+	}
+	return fmt.Errorf("main.parked not found in the snapshot of %d goroutines", len(s.Goroutines))
+}
+
+// pcs returns the program counters of a stack in the address space of the
+// process, given the load bias of the executable.
+func pcs(stack []uint64, loadBias uint64) []uintptr {
+	r := make([]uintptr, len(stack))
+	for i, pc := range stack {
+		r[i] = uintptr(pc + loadBias)
+	}
+	return r
+}
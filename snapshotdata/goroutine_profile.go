@@ -0,0 +1,163 @@
+package snapshotdata
+
+import (
+	"fmt"
+	"maps"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/google/pprof/profile"
+
+	"github.com/DataExMachina-dev/side-eye-go/internal/allgs"
+)
+
+// Labels attached to the samples of a goroutine profile.
+const (
+	StatusLabel     = "status"
+	WaitReasonLabel = "wait reason"
+)
+
+// GoroutineProfile converts the goroutines of the snapshot to a pprof
+// goroutine profile, like the one served by /debug/pprof/goroutine.
+// Goroutines are aggregated by stack, status, wait reason and pprof labels;
+// every sample counts the goroutines of an aggregate and is labeled with their
+// pprof labels, their status and, for waiting goroutines, their wait reason.
+// The status and the wait reason take precedence over pprof labels with the
+// same keys.
+//
+// The stacks are symbolized with the pclntab of the running binary, so the
+// snapshot must have been taken of the current process. loadBias is the
+// difference between the addresses the binary is loaded at and the addresses
+// in the snapshot, i.e. the BssAddrShift of the SnapshotResponse.
+func (s *Snapshot) GoroutineProfile(loadBias uint64) *profile.Profile {
+	goroutineType := &profile.ValueType{Type: "goroutine", Unit: "count"}
+	p := &profile.Profile{
+		SampleType: []*profile.ValueType{goroutineType},
+		PeriodType: goroutineType,
+		Period:     1,
+	}
+	b := profileBuilder{
+		p:         p,
+		loadBias:  loadBias,
+		locations: make(map[uint64]*profile.Location),
+		functions: make(map[profileFunctionKey]*profile.Function),
+	}
+
+	type sampleKey struct {
+		stackHash  uint64
+		status     uint32
+		waitReason uint8
+		labels     string
+	}
+	samples := make(map[sampleKey]*profile.Sample)
+	for i := range s.Goroutines {
+		g := &s.Goroutines[i]
+		status := allgs.Status(g.Status)
+		k := sampleKey{stackHash: g.StackHash, status: g.Status, labels: labelsKey(g.Labels)}
+		if status == allgs.Status_Gwaiting {
+			k.waitReason = g.WaitReason
+		}
+		if sample, ok := samples[k]; ok {
+			sample.Value[0]++
+			continue
+		}
+		sample := &profile.Sample{
+			Value:    []int64{1},
+			Location: b.stack(g.Stack),
+			Label:    make(map[string][]string, len(g.Labels)+2),
+		}
+		for k, v := range g.Labels {
+			sample.Label[k] = []string{v}
+		}
+		sample.Label[StatusLabel] = []string{status.String()}
+		if status == allgs.Status_Gwaiting {
+			sample.Label[WaitReasonLabel] = []string{allgs.WaitReason(g.WaitReason).String()}
+		}
+		samples[k] = sample
+		p.Sample = append(p.Sample, sample)
+	}
+	return p
+}
+
+// labelsKey returns a string that identifies a set of pprof labels.
+func labelsKey(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	for _, k := range slices.Sorted(maps.Keys(labels)) {
+		// Keys and values are prefixed with their lengths so that the
+		// encoding is unambiguous.
+		fmt.Fprintf(&b, "%d:%s%d:%s", len(k), k, len(labels[k]), labels[k])
+	}
+	return b.String()
+}
+
+type profileFunctionKey struct {
+	name, file string
+}
+
+// profileBuilder accumulates the locations and functions of a profile.
+type profileBuilder struct {
+	p         *profile.Profile
+	loadBias  uint64
+	locations map[uint64]*profile.Location
+	functions map[profileFunctionKey]*profile.Function
+}
+
+func (b *profileBuilder) stack(pcs []uint64) []*profile.Location {
+	locs := make([]*profile.Location, len(pcs))
+	for i, pc := range pcs {
+		locs[i] = b.location(pc + b.loadBias)
+	}
+	return locs
+}
+
+// location returns the location of the given pc, symbolizing it if it has not
+// been seen before. Calls inlined at the pc each get a line of the location,
+// innermost first.
+func (b *profileBuilder) location(pc uint64) *profile.Location {
+	if loc, ok := b.locations[pc]; ok {
+		return loc
+	}
+	loc := &profile.Location{
+		ID:      uint64(len(b.p.Location) + 1),
+		Address: pc,
+	}
+	// CallersFrames only reports the calls inlined at a pc if another pc
+	// follows it, hence the 0, whose frames are not used: the location ends
+	// with the frame of the function that the calls were inlined into.
+	frames := runtime.CallersFrames([]uintptr{uintptr(pc), 0})
+	for {
+		frame, more := frames.Next()
+		if frame.Function != "" {
+			loc.Line = append(loc.Line, profile.Line{
+				Function: b.function(frame.Function, frame.File),
+				Line:     int64(frame.Line),
+			})
+		}
+		if !more || frame.Func != nil {
+			break
+		}
+	}
+	b.locations[pc] = loc
+	b.p.Location = append(b.p.Location, loc)
+	return loc
+}
+
+func (b *profileBuilder) function(name, file string) *profile.Function {
+	k := profileFunctionKey{name: name, file: file}
+	if f, ok := b.functions[k]; ok {
+		return f
+	}
+	f := &profile.Function{
+		ID:         uint64(len(b.p.Function) + 1),
+		Name:       name,
+		SystemName: name,
+		Filename:   file,
+	}
+	b.functions[k] = f
+	b.p.Function = append(b.p.Function, f)
+	return f
+}
@@ -0,0 +1,124 @@
+package snapshotdata
+
+import (
+	"bytes"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/google/pprof/profile"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataExMachina-dev/side-eye-go/internal/allgs"
+)
+
+//go:noinline
+func callers() []uint64 {
+	pcs := make([]uintptr, 32)
+	pcs = pcs[:runtime.Callers(2, pcs)]
+	stack := make([]uint64, len(pcs))
+	for i, pc := range pcs {
+		stack[i] = uint64(pc)
+	}
+	return stack
+}
+
+func TestGoroutineProfile(t *testing.T) {
+	const loadBias = 0x1000
+	stack := callers()
+	for i := range stack {
+		stack[i] -= loadBias
+	}
+	const waitReason = 3
+	s := &Snapshot{
+		Goroutines: []Goroutine{
+			{
+				GoroutineHeader: GoroutineHeader{Goid: 1, StackHash: 1, Status: uint32(allgs.Status_Gwaiting), WaitReason: waitReason},
+				Stack:           stack,
+			},
+			{
+				GoroutineHeader: GoroutineHeader{Goid: 2, StackHash: 1, Status: uint32(allgs.Status_Gwaiting), WaitReason: waitReason},
+				Stack:           stack,
+			},
+			{
+				// The wait reason of goroutines that are not waiting is stale.
+				GoroutineHeader: GoroutineHeader{Goid: 3, StackHash: 1, Status: uint32(allgs.Status_Grunnable), WaitReason: waitReason},
+				Stack:           stack,
+			},
+			{
+				GoroutineHeader: GoroutineHeader{Goid: 4, StackHash: 2, Status: uint32(allgs.Status_Grunnable)},
+				Stack:           stack[1:],
+			},
+			{
+				// Goroutines with different labels are not aggregated.
+				GoroutineHeader: GoroutineHeader{Goid: 5, StackHash: 2, Status: uint32(allgs.Status_Grunnable)},
+				Labels:          map[string]string{"handler": "/debug", StatusLabel: "overridden"},
+				Stack:           stack[1:],
+			},
+		},
+	}
+	p := s.GoroutineProfile(loadBias)
+	require.NoError(t, p.CheckValid())
+
+	// The profile must survive a round trip through its encoding.
+	var buf bytes.Buffer
+	require.NoError(t, p.Write(&buf))
+	p, err := profile.Parse(&buf)
+	require.NoError(t, err)
+
+	require.Len(t, p.Sample, 4)
+	require.Equal(t, []int64{2}, p.Sample[0].Value)
+	require.Equal(t, map[string][]string{
+		StatusLabel:     {allgs.Status(allgs.Status_Gwaiting).String()},
+		WaitReasonLabel: {allgs.WaitReason(waitReason).String()},
+	}, p.Sample[0].Label)
+	require.Equal(t, []int64{1}, p.Sample[1].Value)
+	require.Equal(t, map[string][]string{
+		StatusLabel: {allgs.Status(allgs.Status_Grunnable).String()},
+	}, p.Sample[1].Label)
+	require.Equal(t, []int64{1}, p.Sample[2].Value)
+	require.Equal(t, []int64{1}, p.Sample[3].Value)
+	require.Equal(t, map[string][]string{
+		"handler":   {"/debug"},
+		StatusLabel: {allgs.Status(allgs.Status_Grunnable).String()},
+	}, p.Sample[3].Label)
+
+	// Locations are shared between the samples.
+	require.Len(t, p.Location, len(stack))
+	require.Equal(t, p.Sample[0].Location[1:], p.Sample[2].Location)
+	leaf := p.Sample[0].Location[0]
+	require.Equal(t, stack[0]+loadBias, leaf.Address)
+	require.NotEmpty(t, leaf.Line)
+	require.True(t, strings.HasSuffix(leaf.Line[0].Function.Name, "snapshotdata.TestGoroutineProfile"),
+		"unexpected leaf function %s", leaf.Line[0].Function.Name)
+	require.True(t, strings.HasSuffix(leaf.Line[0].Function.Filename, "goroutine_profile_test.go"))
+}
+
+// inlinedCallers is inlined into callsInlined.
+func inlinedCallers() []uint64 {
+	return callers()
+}
+
+//go:noinline
+func callsInlined() []uint64 {
+	return inlinedCallers()
+}
+
+func TestGoroutineProfileInlined(t *testing.T) {
+	// The stacks of a snapshot only hold the return addresses, without the
+	// virtual pcs that runtime.Callers adds for inlined calls.
+	s := &Snapshot{Goroutines: []Goroutine{{
+		GoroutineHeader: GoroutineHeader{Goid: 1, StackHash: 1, Status: uint32(allgs.Status_Grunnable)},
+		Stack:           callsInlined()[:1],
+	}}}
+	p := s.GoroutineProfile(0 /* loadBias */)
+	require.Len(t, p.Location, 1)
+	var names []string
+	for _, line := range p.Location[0].Line {
+		names = append(names, line.Function.Name)
+	}
+	require.Equal(t, []string{
+		"github.com/DataExMachina-dev/side-eye-go/snapshotdata.inlinedCallers",
+		"github.com/DataExMachina-dev/side-eye-go/snapshotdata.callsInlined",
+	}, names)
+}
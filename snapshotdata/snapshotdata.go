@@ -0,0 +1,393 @@
+// Package snapshotdata decodes the data of a snapshot, as found in
+// SnapshotResponse.Data, into Go values.
+//
+// A snapshot is laid out as a SnapshotHeader, followed by the goroutines and
+// then by the pointees that were chased from them. Every goroutine is a
+// GoroutineHeader followed by its pprof labels, what it is waiting on, the
+// program counters of its stack and the data captured from its frames. Stacks
+// are deduplicated by hash: only the first goroutine with a given stack has its
+// program counters in the data.
+package snapshotdata
+
+import (
+	"encoding/binary"
+	"fmt"
+	"unsafe"
+
+	"github.com/DataExMachina-dev/side-eye-go/internal/framing"
+)
+
+type (
+	// SnapshotHeader is the header at the start of a snapshot.
+	SnapshotHeader = framing.SnapshotHeader
+	// Statistics are the statistics recorded in the SnapshotHeader.
+	Statistics = framing.Statistics
+	// SnapshotFlags is a bitmask of properties of a snapshot.
+	SnapshotFlags = framing.SnapshotFlags
+	// GoroutineHeader is the header of every goroutine in a snapshot.
+	GoroutineHeader = framing.GoroutineHeader
+	// StackSource describes where the context used to unwind a goroutine's
+	// stack came from.
+	StackSource = framing.StackSource
+	// WaitingOn describes a channel or a semaphore that a goroutine is
+	// blocked on.
+	WaitingOn = framing.WaitingOn
+)
+
+const (
+	// SnapshotFlagTruncated is set if the snapshot data did not fit in the
+	// maximum buffer size and goroutines or pointees were dropped.
+	SnapshotFlagTruncated = framing.SnapshotFlagTruncated
+	// SnapshotFlagPartial is set if the snapshot's maximum pause elapsed
+	// before all goroutines or pointees were captured.
+	SnapshotFlagPartial = framing.SnapshotFlagPartial
+	// SnapshotFlagDropped is set if pointees were dropped because they did
+	// not fit in the memory that was set aside for chasing pointers before
+	// the world was stopped.
+	SnapshotFlagDropped = framing.SnapshotFlagDropped
+
+	// StackSourceSched, StackSourceSyscall and StackSourceNone are the values
+	// of GoroutineHeader.StackSource.
+	StackSourceSched   = framing.StackSourceSched
+	StackSourceSyscall = framing.StackSourceSyscall
+	StackSourceNone    = framing.StackSourceNone
+)
+
+// dereferenceFailedBit is set in the type of a queue entry whose data could
+// not be read from the process' memory.
+const dereferenceFailedBit = 1 << 31
+
+var (
+	labelHeaderLen = uint32(unsafe.Sizeof(framing.LabelHeader{}))
+	waitingOnLen   = uint32(unsafe.Sizeof(framing.WaitingOn{}))
+	frameHeaderLen = uint32(unsafe.Sizeof(framing.FrameHeader{}))
+	queueEntryLen  = uint32(unsafe.Sizeof(framing.QueueEntry{}))
+)
+
+// CurrentFramingVersion is the framing version of the snapshots captured by
+// this module's CaptureLocalSnapshot, and the newest version that Decode can
+// decode.
+const CurrentFramingVersion = framing.CurrentVersion
+
+// Snapshot is a decoded snapshot.
+type Snapshot struct {
+	// Header is the snapshot's header. Its fields that are not part of the
+	// snapshot's framing version are zero.
+	Header     SnapshotHeader
+	Goroutines []Goroutine
+	// Stacks maps the hash of every stack in the snapshot to its program
+	// counters, leaf first.
+	Stacks map[uint64][]uint64
+	// Pointees are the entries chased from the goroutines' frames and from
+	// other pointees, in the order in which they were captured.
+	Pointees []Entry
+}
+
+// Truncated returns true if the snapshot did not fit in its maximum size.
+func (s *Snapshot) Truncated() bool {
+	return s.Header.Flags&SnapshotFlagTruncated != 0
+}
+
+// Partial returns true if the snapshot's maximum pause elapsed before it was
+// complete.
+func (s *Snapshot) Partial() bool {
+	return s.Header.Flags&SnapshotFlagPartial != 0
+}
+
+// Dropped returns true if pointees were dropped because the memory set aside
+// for chasing pointers was exhausted.
+func (s *Snapshot) Dropped() bool {
+	return s.Header.Flags&SnapshotFlagDropped != 0
+}
+
+// ChannelWaiters returns the goids of the goroutines blocked on every channel,
+// keyed by the address of the channel's hchan. The hchan itself, if it was
+// captured, is the pointee with that address.
+func (s *Snapshot) ChannelWaiters() map[uint64][]uint64 {
+	m := make(map[uint64][]uint64)
+	for i := range s.Goroutines {
+		g := &s.Goroutines[i]
+		for _, w := range g.WaitingOn {
+			if w.Chan != 0 {
+				m[w.Chan] = append(m[w.Chan], g.Goid)
+			}
+		}
+	}
+	return m
+}
+
+// Goroutine is a goroutine captured in a snapshot.
+type Goroutine struct {
+	// GoroutineHeader is the goroutine's header. Its fields that are not part
+	// of the snapshot's framing version are zero.
+	GoroutineHeader
+	// Labels holds the goroutine's pprof labels. It is nil if the goroutine
+	// has none.
+	Labels map[string]string
+	// WaitingOn holds the channels or the semaphore that a waiting goroutine
+	// is blocked on. A goroutine in a select is blocked on all of its
+	// channels. Semaphores, on which sync.Mutex, sync.Cond and sync.WaitGroup
+	// block, are only recorded from go1.26.
+	WaitingOn []WaitingOn
+	// Stack holds the program counters of the goroutine's stack, leaf first.
+	// It is shared with the other goroutines with the same StackHash. It is
+	// nil for a running goroutine whose StackSource is StackSourceNone, as
+	// there was no context to unwind it from.
+	Stack []uint64
+	// Frames holds the data captured for the frames of interest of the
+	// stack.
+	Frames []Frame
+}
+
+// Frame is the data captured for a frame of a goroutine's stack.
+type Frame struct {
+	// Type is the id of the frame's type in the snapshot program.
+	Type uint32
+	// ProgID identifies the program that captured the frame.
+	ProgID uint32
+	// Depth is the index of the frame in the goroutine's stack.
+	Depth uint32
+	// Data is the data of the frame's variables.
+	Data []byte
+	// Entries are the entries that were captured along with the frame, such
+	// as the contents of its context.Context.
+	Entries []Entry
+}
+
+// Entry is a piece of data captured from the memory of the process.
+type Entry struct {
+	// Type is the id of the entry's type in the snapshot program.
+	Type uint32
+	// Addr is the address that the data was read from.
+	Addr uint64
+	// Data is the captured data. It is zeroed if DereferenceFailed is set.
+	Data []byte
+	// DereferenceFailed is set if Addr could not be read.
+	DereferenceFailed bool
+}
+
+// Decode decodes the data of a snapshot written in CurrentFramingVersion. The
+// returned Snapshot references data, which must not be modified while it is in
+// use.
+func Decode(data []byte) (*Snapshot, error) {
+	return DecodeVersion(data, CurrentFramingVersion)
+}
+
+// DecodeVersion is like Decode, but decodes data written in the given framing
+// version, as reported by SnapshotResponse.FramingVersion.
+func DecodeVersion(data []byte, version uint32) (*Snapshot, error) {
+	if version > CurrentFramingVersion {
+		return nil, fmt.Errorf("unsupported framing version %d", version)
+	}
+	snapshotHeaderLen := framing.SnapshotHeaderByteLen(version)
+	d := decoder{
+		data:               data,
+		goroutineHeaderLen: framing.GoroutineHeaderByteLen(version),
+	}
+	if err := d.readStruct(0, snapshotHeaderLen, unsafe.Pointer(&d.s.Header)); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot header: %w", err)
+	}
+	h := &d.s.Header
+	if uint64(h.DataByteLen) > uint64(len(data)) {
+		return nil, fmt.Errorf(
+			"snapshot data length %d exceeds the %d bytes available", h.DataByteLen, len(data),
+		)
+	}
+	d.data = data[:h.DataByteLen]
+	goroutinesEnd := uint64(snapshotHeaderLen) + uint64(h.GoroutinesByteLen)
+	if goroutinesEnd > uint64(h.DataByteLen) {
+		return nil, fmt.Errorf(
+			"goroutines length %d exceeds the snapshot data length %d",
+			h.GoroutinesByteLen, h.DataByteLen,
+		)
+	}
+	d.s.Stacks = make(map[uint64][]uint64)
+	for off := snapshotHeaderLen; off < uint32(goroutinesEnd); {
+		g, next, err := d.decodeGoroutine(off, uint32(goroutinesEnd))
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed to decode goroutine %d at offset %d: %w", len(d.s.Goroutines), off, err,
+			)
+		}
+		d.s.Goroutines = append(d.s.Goroutines, g)
+		off = next
+	}
+	pointees, err := d.decodeEntries(uint32(goroutinesEnd), h.DataByteLen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode pointees: %w", err)
+	}
+	d.s.Pointees = pointees
+	return &d.s, nil
+}
+
+type decoder struct {
+	data []byte
+	// goroutineHeaderLen is the length of a GoroutineHeader in the framing
+	// version of data.
+	goroutineHeaderLen uint32
+	s                  Snapshot
+}
+
+// readStruct copies size bytes at offset into the struct pointed to by dst.
+func (d *decoder) readStruct(offset uint32, size uint32, dst unsafe.Pointer) error {
+	if uint64(offset)+uint64(size) > uint64(len(d.data)) {
+		return fmt.Errorf("%d bytes at offset %d exceed the data length %d", size, offset, len(d.data))
+	}
+	copy(unsafe.Slice((*byte)(dst), size), d.data[offset:])
+	return nil
+}
+
+func (d *decoder) decodeGoroutine(offset uint32, end uint32) (g Goroutine, next uint32, _ error) {
+	if err := d.readStruct(offset, d.goroutineHeaderLen, unsafe.Pointer(&g.GoroutineHeader)); err != nil {
+		return Goroutine{}, 0, err
+	}
+	start := offset + d.goroutineHeaderLen
+	if uint64(start)+uint64(g.DataByteLen) > uint64(end) {
+		return Goroutine{}, 0, fmt.Errorf(
+			"data length %d exceeds the goroutines section", g.DataByteLen,
+		)
+	}
+	labelsLen := (uint64(g.LabelsByteLen) + 7) &^ 7
+	waitingOnsLen := uint64(g.NumWaitingOn) * uint64(waitingOnLen)
+	if labelsLen+waitingOnsLen+uint64(g.StackBytes) > uint64(g.DataByteLen) {
+		return Goroutine{}, 0, fmt.Errorf(
+			"labels length %d, %d waiting on entries and stack length %d exceed the data length %d",
+			g.LabelsByteLen, g.NumWaitingOn, g.StackBytes, g.DataByteLen,
+		)
+	}
+	next = start + g.DataByteLen
+	if g.LabelsByteLen != 0 {
+		labels, err := d.decodeLabels(start, start+g.LabelsByteLen)
+		if err != nil {
+			return Goroutine{}, 0, fmt.Errorf("failed to decode labels: %w", err)
+		}
+		g.Labels = labels
+	}
+	start += uint32(labelsLen)
+	if g.NumWaitingOn != 0 {
+		g.WaitingOn = make([]WaitingOn, g.NumWaitingOn)
+		for i := range g.WaitingOn {
+			if err := d.readStruct(start, waitingOnLen, unsafe.Pointer(&g.WaitingOn[i])); err != nil {
+				return Goroutine{}, 0, err
+			}
+			start += waitingOnLen
+		}
+	}
+	if g.StackBytes != 0 {
+		if g.StackBytes%8 != 0 {
+			return Goroutine{}, 0, fmt.Errorf("stack length %d is not a multiple of 8", g.StackBytes)
+		}
+		stack := make([]uint64, g.StackBytes/8)
+		for i := range stack {
+			stack[i] = binary.NativeEndian.Uint64(d.data[start+uint32(i)*8:])
+		}
+		d.s.Stacks[g.StackHash] = stack
+	}
+	if g.StackSource != StackSourceNone {
+		stack, ok := d.s.Stacks[g.StackHash]
+		if !ok {
+			return Goroutine{}, 0, fmt.Errorf("stack %#x was not written by a previous goroutine", g.StackHash)
+		}
+		g.Stack = stack
+	}
+
+	for off := start + g.StackBytes; off < next; {
+		f, frameEnd, err := d.decodeFrame(off, next)
+		if err != nil {
+			return Goroutine{}, 0, fmt.Errorf("failed to decode frame at offset %d: %w", off, err)
+		}
+		g.Frames = append(g.Frames, f)
+		off = frameEnd
+	}
+	return g, next, nil
+}
+
+// decodeLabels decodes the pprof labels in [offset, end).
+func (d *decoder) decodeLabels(offset uint32, end uint32) (map[string]string, error) {
+	labels := make(map[string]string)
+	for offset < end {
+		var lh framing.LabelHeader
+		if uint64(offset)+uint64(labelHeaderLen) > uint64(end) {
+			return nil, fmt.Errorf("label %d at offset %d exceeds the labels", len(labels), offset)
+		}
+		if err := d.readStruct(offset, labelHeaderLen, unsafe.Pointer(&lh)); err != nil {
+			return nil, err
+		}
+		keyStart := uint64(offset) + uint64(labelHeaderLen)
+		valueStart := keyStart + uint64(lh.KeyLen)
+		valueEnd := valueStart + uint64(lh.ValueLen)
+		if valueEnd > uint64(end) {
+			return nil, fmt.Errorf(
+				"label %d at offset %d: key length %d and value length %d exceed the labels",
+				len(labels), offset, lh.KeyLen, lh.ValueLen,
+			)
+		}
+		labels[string(d.data[keyStart:valueStart])] = string(d.data[valueStart:valueEnd])
+		offset = uint32(valueEnd)
+	}
+	return labels, nil
+}
+
+func (d *decoder) decodeFrame(offset uint32, end uint32) (f Frame, next uint32, _ error) {
+	var fh framing.FrameHeader
+	if err := d.readStruct(offset, frameHeaderLen, unsafe.Pointer(&fh)); err != nil {
+		return Frame{}, 0, err
+	}
+	start := offset + frameHeaderLen
+	if uint64(start)+uint64(fh.DataByteLen) > uint64(end) {
+		return Frame{}, 0, fmt.Errorf("data length %d exceeds the goroutine data", fh.DataByteLen)
+	}
+	next = start + fh.DataByteLen
+	entries, err := d.decodeEntries(start, next)
+	if err != nil {
+		return Frame{}, 0, err
+	}
+	if len(entries) == 0 {
+		return Frame{}, 0, fmt.Errorf("frame has no data")
+	}
+	// The frame's own entry is followed by the program id and the depth of
+	// the frame.
+	e := entries[0]
+	if len(e.Data) < 8 {
+		return Frame{}, 0, fmt.Errorf("frame data length %d is too short", len(e.Data))
+	}
+	dataLen := len(e.Data) - 8
+	f = Frame{
+		Type:   e.Type,
+		ProgID: binary.NativeEndian.Uint32(e.Data[dataLen:]),
+		Depth:  binary.NativeEndian.Uint32(e.Data[dataLen+4:]),
+		Data:   e.Data[:dataLen:dataLen],
+	}
+	if len(entries) > 1 {
+		f.Entries = entries[1:]
+	}
+	return f, next, nil
+}
+
+// decodeEntries decodes the queue entries in [offset, end).
+func (d *decoder) decodeEntries(offset uint32, end uint32) ([]Entry, error) {
+	var entries []Entry
+	for offset < end {
+		var qe framing.QueueEntry
+		if err := d.readStruct(offset, queueEntryLen, unsafe.Pointer(&qe)); err != nil {
+			return nil, fmt.Errorf("failed to decode entry %d: %w", len(entries), err)
+		}
+		dataStart := uint64(offset) + uint64(queueEntryLen)
+		paddedLen := (uint64(qe.Len) + 7) &^ 7
+		if dataStart+paddedLen > uint64(end) {
+			return nil, fmt.Errorf(
+				"entry %d at offset %d: data length %d exceeds the section",
+				len(entries), offset, qe.Len,
+			)
+		}
+		dataEnd := dataStart + uint64(qe.Len)
+		entries = append(entries, Entry{
+			Type:              qe.Type &^ dereferenceFailedBit,
+			Addr:              qe.Addr,
+			Data:              d.data[dataStart:dataEnd:dataEnd],
+			DereferenceFailed: qe.Type&dereferenceFailedBit != 0,
+		})
+		offset = uint32(dataStart + paddedLen)
+	}
+	return entries, nil
+}
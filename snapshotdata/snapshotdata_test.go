@@ -0,0 +1,190 @@
+package snapshotdata
+
+import (
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataExMachina-dev/side-eye-go/internal/framing"
+)
+
+// builder writes snapshot data in the layout produced by the snapshotter.
+type builder struct {
+	buf []byte
+}
+
+func appendStruct[T any](b *builder, v T) {
+	b.buf = append(b.buf, unsafe.Slice((*byte)(unsafe.Pointer(&v)), unsafe.Sizeof(v))...)
+}
+
+func (b *builder) pad() {
+	for len(b.buf)%8 != 0 {
+		b.buf = append(b.buf, 0)
+	}
+}
+
+func (b *builder) entry(t uint32, addr uint64, data []byte) {
+	appendStruct(b, framing.QueueEntry{Type: t, Len: uint32(len(data)), Addr: addr})
+	b.buf = append(b.buf, data...)
+	b.pad()
+}
+
+// frame writes a frame whose data is followed by the given extra entries.
+func (b *builder) frame(t, progID, depth uint32, data []byte, extra func()) {
+	headerOffset := len(b.buf)
+	appendStruct(b, framing.FrameHeader{})
+	start := len(b.buf)
+	appendStruct(b, framing.QueueEntry{Type: t, Len: uint32(len(data)) + 8})
+	b.buf = append(b.buf, data...)
+	appendStruct(b, progID)
+	appendStruct(b, depth)
+	b.pad()
+	if extra != nil {
+		extra()
+	}
+	(*framing.FrameHeader)(unsafe.Pointer(&b.buf[headerOffset])).DataByteLen = uint32(len(b.buf) - start)
+}
+
+// goroutineData is the data that follows a goroutine's header.
+type goroutineData struct {
+	labels    [][2]string
+	waitingOn []framing.WaitingOn
+	stack     []uint64
+	frames    func()
+}
+
+func (b *builder) goroutine(h framing.GoroutineHeader, d goroutineData) {
+	headerOffset := len(b.buf)
+	appendStruct(b, h)
+	start := len(b.buf)
+	for _, l := range d.labels {
+		appendStruct(b, framing.LabelHeader{KeyLen: uint32(len(l[0])), ValueLen: uint32(len(l[1]))})
+		b.buf = append(b.buf, l[0]...)
+		b.buf = append(b.buf, l[1]...)
+	}
+	labelsByteLen := len(b.buf) - start
+	b.pad()
+	for _, w := range d.waitingOn {
+		appendStruct(b, w)
+	}
+	for _, pc := range d.stack {
+		appendStruct(b, pc)
+	}
+	if d.frames != nil {
+		d.frames()
+	}
+	gh := (*framing.GoroutineHeader)(unsafe.Pointer(&b.buf[headerOffset]))
+	gh.LabelsByteLen = uint32(labelsByteLen)
+	gh.NumWaitingOn = uint32(len(d.waitingOn))
+	gh.StackBytes = uint32(len(d.stack) * 8)
+	gh.DataByteLen = uint32(len(b.buf) - start)
+}
+
+func TestDecode(t *testing.T) {
+	var b builder
+	appendStruct(&b, framing.SnapshotHeader{})
+	stack := []uint64{0x1010, 0x2020, 0x3030}
+	b.goroutine(framing.GoroutineHeader{
+		Goid:        1,
+		StackHash:   0xabc,
+		Status:      4,
+		WaitReason:  7,
+		StackSource: framing.StackSourceSched,
+		CreatorPc:   0x4040,
+		ParentGoid:  9,
+	}, goroutineData{
+		labels:    [][2]string{{"handler", "/debug"}, {"tenant", "7"}},
+		waitingOn: []framing.WaitingOn{{Chan: 0xc000, Elem: 0xe000}, {Chan: 0xc100, Elem: 0xe100}},
+		stack:     stack,
+		frames: func() {
+			b.frame(10, 2, 1, []byte{1, 2, 3}, func() {
+				b.entry(11, 0x5000, []byte{4, 5, 6, 7, 8, 9, 10, 11, 12})
+			})
+			b.frame(12, 3, 2, nil, nil)
+		},
+	})
+	// The second goroutine has the same stack, which is not written again.
+	b.goroutine(framing.GoroutineHeader{
+		Goid:        2,
+		StackHash:   0xabc,
+		StackSource: framing.StackSourceSyscall,
+	}, goroutineData{})
+	// The third goroutine was running without a context to unwind it from.
+	b.goroutine(framing.GoroutineHeader{
+		Goid:        3,
+		Status:      2,
+		StackSource: framing.StackSourceNone,
+	}, goroutineData{})
+	goroutinesByteLen := len(b.buf) - int(unsafe.Sizeof(framing.SnapshotHeader{}))
+	b.entry(13, 0x6000, []byte{1, 2, 3, 4, 5, 6, 7, 8})
+	b.entry(14|dereferenceFailedBit, 0x7000, make([]byte, 4))
+	*(*framing.SnapshotHeader)(unsafe.Pointer(&b.buf[0])) = framing.SnapshotHeader{
+		DataByteLen:       uint32(len(b.buf)),
+		GoroutinesByteLen: uint32(goroutinesByteLen),
+		Flags:             framing.SnapshotFlagPartial,
+	}
+
+	s, err := Decode(b.buf)
+	require.NoError(t, err)
+	require.True(t, s.Partial())
+	require.False(t, s.Truncated())
+	require.False(t, s.Dropped())
+	require.Equal(t, map[uint64][]uint64{0xabc: stack}, s.Stacks)
+
+	require.Len(t, s.Goroutines, 3)
+	g := s.Goroutines[0]
+	require.Equal(t, uint64(1), g.Goid)
+	require.Equal(t, uint32(4), g.Status)
+	require.Equal(t, uint8(7), g.WaitReason)
+	require.Equal(t, uint64(0x4040), g.CreatorPc)
+	require.Equal(t, uint64(9), g.ParentGoid)
+	require.Equal(t, map[string]string{"handler": "/debug", "tenant": "7"}, g.Labels)
+	require.Equal(t, []WaitingOn{{Chan: 0xc000, Elem: 0xe000}, {Chan: 0xc100, Elem: 0xe100}}, g.WaitingOn)
+	require.Equal(t, stack, g.Stack)
+	require.Equal(t, []Frame{
+		{
+			Type: 10, ProgID: 2, Depth: 1, Data: []byte{1, 2, 3},
+			Entries: []Entry{{Type: 11, Addr: 0x5000, Data: []byte{4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+		},
+		{Type: 12, ProgID: 3, Depth: 2, Data: []byte{}},
+	}, g.Frames)
+
+	g = s.Goroutines[1]
+	require.Equal(t, uint64(2), g.Goid)
+	require.Equal(t, StackSourceSyscall, g.StackSource)
+	require.Zero(t, g.StackBytes)
+	require.Nil(t, g.Labels)
+	require.Nil(t, g.WaitingOn)
+	require.Equal(t, stack, g.Stack)
+	require.Empty(t, g.Frames)
+
+	g = s.Goroutines[2]
+	require.Equal(t, uint64(3), g.Goid)
+	require.Equal(t, StackSourceNone, g.StackSource)
+	require.Nil(t, g.Stack)
+	require.Empty(t, g.Frames)
+
+	require.Equal(t, []Entry{
+		{Type: 13, Addr: 0x6000, Data: []byte{1, 2, 3, 4, 5, 6, 7, 8}},
+		{Type: 14, Addr: 0x7000, Data: make([]byte, 4), DereferenceFailed: true},
+	}, s.Pointees)
+
+	// Truncating the data anywhere must fail to decode rather than panic.
+	for n := 0; n < len(b.buf); n++ {
+		_, err := Decode(b.buf[:n])
+		require.Error(t, err, "length %d", n)
+	}
+}
+
+func TestDecodeUnknownStack(t *testing.T) {
+	var b builder
+	appendStruct(&b, framing.SnapshotHeader{})
+	b.goroutine(framing.GoroutineHeader{Goid: 1, StackHash: 0xabc}, goroutineData{})
+	*(*framing.SnapshotHeader)(unsafe.Pointer(&b.buf[0])) = framing.SnapshotHeader{
+		DataByteLen:       uint32(len(b.buf)),
+		GoroutinesByteLen: uint32(len(b.buf) - int(unsafe.Sizeof(framing.SnapshotHeader{}))),
+	}
+	_, err := Decode(b.buf)
+	require.ErrorContains(t, err, "stack 0xabc was not written")
+}